@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is the declarative input of the code generator: which upstream API
+// specs to read, which of their operations to generate, and how to map upstream
+// schemas to the module's existing hand-written types.
+type Config struct {
+	Services []*Service `json:"services"`
+}
+
+// Service configures the generated code of a single third-party service.
+type Service struct {
+	// Name is the name of the service, which is also the prefix of its activity names.
+	Name string `json:"name"`
+	// Package is the Go package directory of the service, relative to the module's root.
+	Package string `json:"package"`
+	// SpecURL is the location of the service's OpenAPI (v3) or Swagger (v2) spec.
+	SpecURL string `json:"spec_url"`
+	// DocURL is the fallback documentation link of operations without their own.
+	DocURL string `json:"doc_url,omitempty"`
+
+	// Types map upstream schema names to existing hand-written Go types in the
+	// service's package, instead of generating new types for them.
+	Types map[string]string `json:"types,omitempty"`
+	// SkipParams are upstream parameter names which are never included in
+	// requests, because the Timpani worker handles them (e.g. auth tokens).
+	SkipParams []string `json:"skip_params,omitempty"`
+	// ResponseEmbed is an existing hand-written struct which is embedded in all generated
+	// response structs, instead of its upstream fields (see [Service.ResponseEmbedFields]).
+	ResponseEmbed string `json:"response_embed,omitempty"`
+	// ResponseEmbedFields are the JSON names of the fields in [Service.ResponseEmbed].
+	ResponseEmbedFields []string `json:"response_embed_fields,omitempty"`
+
+	// Operations is the allow-list of upstream operations to generate.
+	Operations []*Operation `json:"operations"`
+}
+
+// Operation configures the generated code of a single Timpani activity.
+type Operation struct {
+	// ID is the operation's ID in the upstream spec, e.g. "pulls/get".
+	ID string `json:"id"`
+	// Activity is the name of the Timpani activity, e.g. "github.pulls.get".
+	Activity string `json:"activity"`
+	// Name is the name of the generated wrapper function, e.g. "PullRequestsGet", and
+	// the prefix of all the other generated Go identifiers (e.g. "PullRequestsGetAsync").
+	Name string `json:"name"`
+	// DocURL overrides the operation's documentation link in the upstream spec.
+	DocURL string `json:"doc_url,omitempty"`
+	// Mutating overrides the default, which is true for all HTTP methods except GET.
+	Mutating *bool `json:"mutating,omitempty"`
+
+	// Response overrides the upstream response type with an existing Go type, e.g. "PullRequest".
+	Response string `json:"response,omitempty"`
+	// Fields override the Go types of specific request fields, by their JSON names.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Omit lists upstream request fields, by their JSON names, which are not generated.
+	Omit []string `json:"omit,omitempty"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := new(Config)
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %w", path, err)
+	}
+
+	for _, s := range cfg.Services {
+		if s.Name == "" || s.Package == "" || s.SpecURL == "" {
+			return nil, fmt.Errorf("service in config file %q is missing a name, package or spec URL", path)
+		}
+		for _, op := range s.Operations {
+			if op.ID == "" || op.Activity == "" || op.Name == "" {
+				return nil, fmt.Errorf("%s operation in config file %q is missing an ID, activity or name", s.Name, path)
+			}
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,508 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// generator renders the Go code of a single service's allow-listed operations.
+type generator struct {
+	svc  *Service
+	spec *spec
+
+	existing map[string]bool   // Hand-written type names in the service's package.
+	named    map[string]string // Upstream schema names => Go type names.
+	pending  []string          // Upstream schema names which still need to be rendered.
+	usesTime bool
+
+	consts, types, funcs, async, validate bytes.Buffer
+}
+
+// entry is the information that the registry needs about a generated activity.
+type entry struct {
+	Name, Request, Response, DocURL string
+	Mutating                        bool
+}
+
+func newGenerator(root string, svc *Service, s *spec) (*generator, error) {
+	existing, err := declaredTypes(filepath.Join(root, svc.Package))
+	if err != nil {
+		return nil, err
+	}
+
+	named := map[string]string{}
+	for schemaName, goName := range svc.Types {
+		named[schemaName] = goName
+	}
+
+	return &generator{svc: svc, spec: s, existing: existing, named: named}, nil
+}
+
+// declaredTypes returns the names of all the types which are
+// declared in a Go package directory, except in generated files.
+func declaredTypes(dir string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Go files in %q: %w", dir, err)
+	}
+
+	names := map[string]bool{}
+	for _, path := range files {
+		if filepath.Base(path) == generatedFile || strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+		}
+		for _, d := range f.Decls {
+			if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, s := range gd.Specs {
+					if ts, ok := s.(*ast.TypeSpec); ok {
+						names[ts.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// operation renders the activity name, request and response
+// types, wrapper functions and validation of a single operation.
+func (g *generator) operation(op *Operation) (*entry, error) {
+	o, err := g.spec.operation(op.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{
+		Name:     op.Name + "ActivityName",
+		Request:  op.Name + "Request",
+		DocURL:   cmp.Or(op.DocURL, g.docURL(o)),
+		Mutating: o.Method != "GET",
+	}
+	if op.Mutating != nil {
+		e.Mutating = *op.Mutating
+	}
+	for _, name := range []string{e.Request, op.Name + "Response"} {
+		if g.existing[name] {
+			return nil, fmt.Errorf("type %s already exists in package %s", name, g.svc.Package)
+		}
+	}
+
+	fmt.Fprintf(&g.consts, "\t%s = %q\n", e.Name, op.Activity)
+
+	required, err := g.request(op, o, e)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Response = g.response(op, o, e.DocURL)
+	g.wrappers(op, e)
+	g.validation(e, required)
+
+	return e, nil
+}
+
+func (g *generator) docURL(o *operation) string {
+	if o.ExternalDocs != nil && o.ExternalDocs.URL != "" {
+		return o.ExternalDocs.URL
+	}
+	return cmp.Or(g.svc.DocURL, g.svc.SpecURL)
+}
+
+// field is a single field in a generated request struct.
+type field struct {
+	json, goType string
+	required     bool // Checked by the request's Validate method.
+	listed       bool // Listed as required in the upstream schema, so zero values are not omitted.
+}
+
+// request renders the request struct of an operation, and returns the JSON names of its required fields.
+func (g *generator) request(op *Operation, o *operation, e *entry) ([]field, error) {
+	var fields []field
+	add := func(name string, s *schema, required, listed bool) {
+		if slices.Contains(g.svc.SkipParams, name) || slices.Contains(op.Omit, name) {
+			return
+		}
+		if slices.ContainsFunc(fields, func(f field) bool { return f.json == name }) {
+			return
+		}
+		t, ok := op.Fields[name]
+		if !ok {
+			t = g.fieldType(s, listed)
+		}
+		fields = append(fields, field{json: name, goType: t, required: required, listed: listed})
+	}
+
+	for _, p := range o.Parameters {
+		p = g.spec.parameter(p)
+		switch p.In {
+		case "path", "query", "formData":
+			s := p.Schema
+			if s == nil {
+				s = &schema{Type: p.Type, Format: p.Format, Items: p.Items}
+			}
+			required := p.Required || p.In == "path"
+			add(p.Name, s, required, required)
+		case "body":
+			if err := g.body(p.Schema, p.Required, add); err != nil {
+				return nil, fmt.Errorf("operation %q: %w", op.ID, err)
+			}
+		}
+	}
+
+	if rb := g.spec.requestBody(o.RequestBody); rb != nil {
+		if err := g.body(jsonSchema(rb.Content), rb.Required, add); err != nil {
+			return nil, fmt.Errorf("operation %q: %w", op.ID, err)
+		}
+	}
+
+	fmt.Fprintf(&g.types, "// %s is based on:\n// %s\ntype %s struct {\n", e.Request, e.DocURL, e.Request)
+	fmt.Fprintf(&g.types, "\tThrippyLinkID string `json:\"thrippy_link_id,omitempty\"`\n")
+	if len(fields) > 0 {
+		g.types.WriteString("\n")
+	}
+	for _, f := range fields {
+		fmt.Fprintf(&g.types, "\t%s %s `json:\"%s%s\"`\n", goName(f.json), f.goType, f.json, omit(f.goType, f.listed))
+	}
+	g.types.WriteString("}\n\n")
+
+	return fields, nil
+}
+
+// body adds the top-level properties of a JSON request body as request fields. Properties
+// which are listed as required in the body's schema are not omitted when they're zero values,
+// but they're required by the request's Validate method only if the body itself is required.
+func (g *generator) body(s *schema, required bool, add func(string, *schema, bool, bool)) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if s = g.spec.schema(s.Ref); s == nil {
+			return fmt.Errorf("unresolved request body schema")
+		}
+	}
+
+	for _, a := range s.AllOf {
+		if err := g.body(a, required, add); err != nil {
+			return err
+		}
+	}
+	for _, p := range s.Properties {
+		listed := slices.Contains(s.Required, p.Name)
+		add(p.Name, p.Schema, required && listed, listed)
+	}
+	return nil
+}
+
+// response renders the response type of an operation, if it has
+// one, and returns its name, or an empty string if it doesn't.
+func (g *generator) response(op *Operation, o *operation, docURL string) string {
+	name := op.Name + "Response"
+	if op.Response != "" {
+		fmt.Fprintf(&g.types, "// %s is based on:\n// %s\ntype %s = %s\n\n", name, docURL, name, op.Response)
+		return name
+	}
+
+	var s *schema
+	for _, code := range []string{"200", "201", "202"} {
+		if r := g.spec.response(o.Responses[code]); r != nil {
+			if s = cmp.Or(r.Schema, jsonSchema(r.Content)); s != nil {
+				break
+			}
+		}
+	}
+	if s == nil {
+		return ""
+	}
+
+	if s.Ref == "" && s.Type.main() == "object" && len(s.Properties) > 0 {
+		fmt.Fprintf(&g.types, "// %s is based on:\n// %s\n", name, docURL)
+		g.structType(name, s)
+		return name
+	}
+
+	fmt.Fprintf(&g.types, "// %s is based on:\n// %s\ntype %s = %s\n\n", name, docURL, name, g.goType(s))
+	return name
+}
+
+// wrappers renders the synchronous and asynchronous wrapper functions of an operation.
+// The asynchronous ones are the same as the handwritten ones in the service packages.
+func (g *generator) wrappers(op *Operation, e *entry) {
+	fmt.Fprintf(&g.funcs, "// %s is based on:\n// %s\n", op.Name, e.DocURL)
+	fmt.Fprintf(&g.funcs, "func %s(ctx workflow.Context, req %s) ", op.Name, e.Request)
+
+	start, future, ret := "async.GoNoResp", "struct{}", "error"
+	if e.Response == "" {
+		fmt.Fprintf(&g.funcs, "error {\n\treturn internal.ExecuteTimpaniActivityNoResp(ctx, %s, req)\n}\n\n", e.Name)
+	} else {
+		start, future = "async.Go", "*"+e.Response
+		ret = fmt.Sprintf("(%s, error)", future)
+		fmt.Fprintf(&g.funcs, "%s {\n\treturn internal.ExecuteTimpaniActivity[%s](ctx, %s, req)\n}\n\n", ret, e.Response, e.Name)
+	}
+
+	fmt.Fprintf(&g.async, "// %sAsync is an asynchronous version of [%s].\n", op.Name, op.Name)
+	fmt.Fprintf(&g.async, "func %sAsync(ctx workflow.Context, req %s) async.Future[%s] {\n", op.Name, e.Request, future)
+	fmt.Fprintf(&g.async, "\treturn %s(ctx, func(ctx workflow.Context) %s {\n", start, ret)
+	fmt.Fprintf(&g.async, "\t\treturn %s(ctx, req)\n\t})\n}\n\n", op.Name)
+}
+
+// validation renders the Validate method of an operation's request, which checks its required fields.
+func (g *generator) validation(e *entry, fields []field) {
+	g.validate.WriteString("// Validate checks the request's required fields.\n")
+	fmt.Fprintf(&g.validate, "func (r %s) Validate() error {\n\treturn internal.NewValidator(%s).", e.Request, e.Name)
+	for _, f := range fields {
+		// Zero values of booleans are valid values.
+		if f.required && f.goType != "bool" {
+			fmt.Fprintf(&g.validate, "\n\t\tRequire(%q, r.%s).", f.json, goName(f.json))
+		}
+	}
+	g.validate.WriteString("\n\t\tErr()\n}\n\n")
+}
+
+// goType returns the Go type of a schema, and queues named schemas for rendering.
+func (g *generator) goType(s *schema) string {
+	if s == nil {
+		return "any"
+	}
+
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		if t, ok := g.named[name]; ok {
+			return t
+		}
+		target := g.spec.schema(s.Ref)
+		if target == nil {
+			return "any"
+		}
+		// Named primitives and arrays are inlined.
+		if t := target.Type.main(); t != "object" && len(target.Properties) == 0 && len(target.AllOf) == 0 {
+			return g.goType(target)
+		}
+		t := goName(name)
+		for g.existing[t] {
+			t += "Object"
+		}
+		g.named[name] = t
+		g.pending = append(g.pending, name)
+		return t
+	}
+
+	if len(s.AllOf) == 1 {
+		return g.goType(s.AllOf[0])
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0 {
+		return "any"
+	}
+
+	switch s.Type.main() {
+	case "string":
+		if s.Format == "date-time" {
+			g.usesTime = true
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items)
+	case "object":
+		values := new(schema)
+		if len(s.Properties) == 0 && json.Unmarshal(s.AdditionalProperties, values) == nil {
+			return "map[string]" + g.goType(values)
+		}
+		return "map[string]any"
+	default:
+		return "any"
+	}
+}
+
+// fieldType returns the Go type of a struct field. Optional
+// fields of named struct types are pointers, to allow nil values.
+func (g *generator) fieldType(s *schema, required bool) string {
+	t := g.goType(s)
+	if !required && slices.Contains(slices.Collect(maps.Values(g.named)), t) {
+		return "*" + t
+	}
+	return t
+}
+
+// structType renders a struct type, without its doc comment.
+func (g *generator) structType(name string, s *schema) {
+	fmt.Fprintf(&g.types, "type %s struct {\n", name)
+
+	var props []property
+	var required []string
+	var collect func(*schema)
+	collect = func(s *schema) {
+		if s.Ref != "" {
+			if s = g.spec.schema(s.Ref); s == nil {
+				return
+			}
+		}
+		for _, a := range s.AllOf {
+			collect(a)
+		}
+		props = append(props, s.Properties...)
+		required = append(required, s.Required...)
+	}
+	collect(s)
+
+	embedded := false
+	for _, p := range props {
+		if g.svc.ResponseEmbed != "" && slices.Contains(g.svc.ResponseEmbedFields, p.Name) {
+			if !embedded {
+				fmt.Fprintf(&g.types, "\t%s\n\n", g.svc.ResponseEmbed)
+				embedded = true
+			}
+			continue
+		}
+		req := slices.Contains(required, p.Name)
+		t := g.fieldType(p.Schema, req)
+		fmt.Fprintf(&g.types, "\t%s %s `json:\"%s%s\"`\n", goName(p.Name), t, p.Name, omit(t, req))
+	}
+
+	g.types.WriteString("}\n\n")
+}
+
+// schemas renders all the named schemas which were referenced so far, recursively.
+func (g *generator) schemas() {
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+
+		ref := "#/components/schemas/" + name
+		if g.spec.Components.Schemas[name] == nil {
+			ref = "#/definitions/" + name
+		}
+		fmt.Fprintf(&g.types, "// %s is based on the %q schema in:\n// %s\n", g.named[name], name, g.svc.SpecURL)
+		g.structType(g.named[name], &schema{Ref: ref})
+	}
+}
+
+// omit returns the "omitempty" or "omitzero" option of an optional field's JSON tag.
+func omit(goType string, required bool) string {
+	switch {
+	case required:
+		return ""
+	case goType == "time.Time":
+		return ",omitzero"
+	default:
+		return ",omitempty"
+	}
+}
+
+const generatedFile = "generated.go"
+
+const header = "// Code generated by cmd/codegen from upstream API specs; DO NOT EDIT.\n\n"
+
+// write formats and writes the generated code of the service.
+func (g *generator) write(root string) error {
+	g.schemas()
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", filepath.Base(g.svc.Package))
+	if g.usesTime {
+		b.WriteString("\t\"time\"\n\n")
+	}
+	b.WriteString("\t\"go.temporal.io/sdk/workflow\"\n\n")
+	b.WriteString("\t\"github.com/tzrikka/timpani-api/internal\"\n")
+	b.WriteString("\t\"github.com/tzrikka/timpani-api/pkg/async\"\n)\n\n")
+
+	fmt.Fprintf(&b, "//revive:disable:exported\nconst (\n%s) //revive:enable:exported\n\n", g.consts.String())
+	b.Write(g.types.Bytes())
+	b.Write(g.funcs.Bytes())
+	b.Write(g.async.Bytes())
+	b.Write(g.validate.Bytes())
+
+	return writeGo(filepath.Join(root, g.svc.Package, generatedFile), b.Bytes())
+}
+
+// writeGo formats Go source code, and writes it to a file.
+func writeGo(path string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("failed to format %q: %w", path, err)
+	}
+	if err := os.WriteFile(path, formatted, 0o600); err != nil {
+		return fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	return nil
+}
+
+// initialisms are capitalized entirely in Go names.
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IDS": true, "JSON": true,
+	"SHA": true, "SSH": true, "TS": true, "URL": true, "UUID": true,
+}
+
+// goName converts an upstream name (e.g. "pull_number", "issueIdOrKey",
+// "pull-request", "+1") into an exported Go name (e.g. "PullNumber",
+// "IssueIDOrKey", "PullRequest", "Plus1").
+func goName(name string) string {
+	switch {
+	case strings.HasPrefix(name, "+"):
+		name = "plus_" + name[1:]
+	case strings.HasPrefix(name, "-"):
+		name = "minus_" + name[1:]
+	}
+
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			words = append(words, string(runes[start:i]))
+			start = i + 1
+		case i > start && r >= 'A' && r <= 'Z' && runes[i-1] >= 'a' && runes[i-1] <= 'z':
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	var b strings.Builder
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+		if u := strings.ToUpper(w); initialisms[u] {
+			if u == "IDS" {
+				u = "IDs"
+			}
+			b.WriteString(u)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+
+	if s := b.String(); s != "" && (s[0] < '0' || s[0] > '9') {
+		return s
+	}
+	return "X" + b.String()
+}
@@ -0,0 +1,111 @@
+// Codegen generates request/response types, wrapper functions and registry
+// entries for Timpani activities, based on upstream API specs (GitHub's REST
+// OpenAPI description, Bitbucket Cloud's Swagger spec, Jira's platform
+// OpenAPI spec, and Slack's Web API spec).
+//
+// Only the operations in the config file's allow-list are generated, into a
+// "generated.go" file in each service's package, and "pkg/registry/generated.go".
+// Upstream schemas may be mapped to existing hand-written types in the config
+// file, and request fields may be omitted or overridden there as well.
+//
+// Usage, from the module's root directory:
+//
+//	go run ./cmd/codegen [-config cmd/codegen/config.json] [-specs <cache dir>]
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+
+	config := flag.String("config", filepath.Join("cmd", "codegen", "config.json"), "path to the generator's config file")
+	specs := flag.String("specs", filepath.Join(cacheDir, "timpani-api", "specs"), "cache directory of downloaded API specs")
+	root := flag.String("root", ".", "root directory of the timpani-api module")
+	flag.Parse()
+
+	if err := run(context.Background(), *config, *specs, *root); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, configPath, specsDir, root string) error {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	entries := map[string][]*entry{}
+	for _, svc := range cfg.Services {
+		if len(svc.Operations) == 0 {
+			if err := os.Remove(filepath.Join(root, svc.Package, generatedFile)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove stale generated file: %w", err)
+			}
+			continue
+		}
+
+		s, err := loadSpec(ctx, svc.Name, svc.SpecURL, specsDir)
+		if err != nil {
+			return err
+		}
+
+		g, err := newGenerator(root, svc, s)
+		if err != nil {
+			return err
+		}
+		for _, op := range svc.Operations {
+			e, err := g.operation(op)
+			if err != nil {
+				return fmt.Errorf("%s: %w", svc.Name, err)
+			}
+			entries[svc.Name] = append(entries[svc.Name], e)
+		}
+		if err := g.write(root); err != nil {
+			return err
+		}
+	}
+
+	return writeRegistry(root, cfg, entries)
+}
+
+// writeRegistry generates the registry entries of all the generated activities.
+func writeRegistry(root string, cfg *Config, entries map[string][]*entry) error {
+	var imports, list bytes.Buffer
+	for _, svc := range cfg.Services {
+		pkg := filepath.Base(svc.Package)
+		if len(entries[svc.Name]) > 0 {
+			fmt.Fprintf(&imports, "\t\"github.com/tzrikka/timpani-api/%s\"\n", filepath.ToSlash(svc.Package))
+		}
+		for _, e := range entries[svc.Name] {
+			fmt.Fprintf(&list, "\t{\n\t\tName:     %s.%s,\n", pkg, e.Name)
+			fmt.Fprintf(&list, "\t\tRequest:  reflect.TypeFor[%s.%s](),\n", pkg, e.Request)
+			if e.Response != "" {
+				fmt.Fprintf(&list, "\t\tResponse: reflect.TypeFor[%s.%s](),\n", pkg, e.Response)
+			}
+			if e.Mutating {
+				list.WriteString("\t\tMutating: true,\n")
+			}
+			fmt.Fprintf(&list, "\t\tDocURL:   %q,\n\t},\n", e.DocURL)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package registry\n\n")
+	if imports.Len() > 0 {
+		fmt.Fprintf(&b, "import (\n\t\"reflect\"\n\n%s)\n\n", imports.String())
+	}
+	fmt.Fprintf(&b, "var generatedEntries = []Entry{\n%s}\n", list.String())
+
+	return writeGo(filepath.Join(root, "pkg", "registry", generatedFile), b.Bytes())
+}
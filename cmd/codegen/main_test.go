@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// handWritten is an existing file in the fixture service's package, which
+// declares a type that the config maps to ("Owner"), and a type whose name
+// conflicts with an upstream schema name ("Gadget").
+const handWritten = `package widgets
+
+type Owner struct{}
+
+type Gadget struct{}
+`
+
+func TestRun(t *testing.T) {
+	root := t.TempDir()
+	for path, contents := range map[string]string{
+		"pkg/widgets/widgets.go":   handWritten,
+		"pkg/stale/generated.go":   "package stale\n",
+		"pkg/registry/registry.go": "package registry\n",
+	} {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := filepath.Join("testdata", "config.json")
+	specs := filepath.Join("testdata", "specs")
+	if err := run(t.Context(), config, specs, root); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	tests := []struct {
+		generated string
+		golden    string
+	}{
+		{
+			generated: "pkg/widgets/generated.go",
+			golden:    "widgets.golden",
+		},
+		{
+			generated: "pkg/registry/generated.go",
+			golden:    "registry.golden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(root, tt.generated))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(golden, got, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(filepath.Clean(golden))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("%s differs from %s:\n%s", tt.generated, golden, got)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "pkg", "stale", generatedFile)); !os.IsNotExist(err) {
+		t.Errorf("stale generated file was not removed: %v", err)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"pull_number", "PullNumber"},
+		{"issueIdOrKey", "IssueIDOrKey"},
+		{"pull-request", "PullRequest"},
+		{"html_url", "HTMLURL"},
+		{"user_ids", "UserIDs"},
+		{"thread_ts", "ThreadTS"},
+		{"+1", "Plus1"},
+		{"-1", "Minus1"},
+		{"2fa", "X2fa"},
+		{"$ref", "Ref"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goName(tt.name); got != tt.want {
+				t.Errorf("goName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		goType   string
+		required bool
+		want     string
+	}{
+		{"required_string", "string", true, ""},
+		{"required_bool", "bool", true, ""},
+		{"required_time", "time.Time", true, ""},
+		{"optional_string", "string", false, ",omitempty"},
+		{"optional_bool", "bool", false, ",omitempty"},
+		{"optional_time", "time.Time", false, ",omitzero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := omit(tt.goType, tt.required); got != tt.want {
+				t.Errorf("omit(%q, %v) = %q, want %q", tt.goType, tt.required, got, tt.want)
+			}
+		})
+	}
+}
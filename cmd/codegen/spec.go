@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// spec is the subset of OpenAPI v3 and Swagger v2 documents that the generator uses.
+type spec struct {
+	Paths map[string]pathItem `json:"paths"`
+
+	Components struct {
+		Schemas       map[string]*schema      `json:"schemas"`
+		Parameters    map[string]*parameter   `json:"parameters"`
+		RequestBodies map[string]*requestBody `json:"requestBodies"`
+		Responses     map[string]*response    `json:"responses"`
+	} `json:"components"`
+
+	// Swagger v2 only.
+	Definitions map[string]*schema    `json:"definitions"`
+	Parameters  map[string]*parameter `json:"parameters"`
+	Responses   map[string]*response  `json:"responses"`
+}
+
+type pathItem map[string]json.RawMessage
+
+type operation struct {
+	Method string `json:"-"`
+	Path   string `json:"-"`
+
+	OperationID  string `json:"operationId"`
+	Summary      string `json:"summary"`
+	ExternalDocs *struct {
+		URL string `json:"url"`
+	} `json:"externalDocs"`
+
+	Parameters  []*parameter         `json:"parameters"`
+	RequestBody *requestBody         `json:"requestBody"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"` // "path", "query", "header", "cookie", and in v2 also "body" and "formData".
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+
+	// Swagger v2 only.
+	Type   types   `json:"type"`
+	Format string  `json:"format"`
+	Items  *schema `json:"items"`
+}
+
+type requestBody struct {
+	Ref      string               `json:"$ref"`
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Ref     string               `json:"$ref"`
+	Content map[string]mediaType `json:"content"`
+	Schema  *schema              `json:"schema"` // Swagger v2 only.
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref         string     `json:"$ref"`
+	Type        types      `json:"type"`
+	Format      string     `json:"format"`
+	Description string     `json:"description"`
+	Properties  properties `json:"properties"`
+	Required    []string   `json:"required"`
+	Items       *schema    `json:"items"`
+	Nullable    bool       `json:"nullable"`
+
+	AllOf []*schema `json:"allOf"`
+	OneOf []*schema `json:"oneOf"`
+	AnyOf []*schema `json:"anyOf"`
+
+	AdditionalProperties json.RawMessage `json:"additionalProperties"`
+}
+
+// types is a schema's "type", which is a single string in OpenAPI
+// v3.0 and Swagger v2, or a list of strings in OpenAPI v3.1.
+type types []string
+
+func (t *types) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = types{s}
+		return nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return fmt.Errorf("invalid schema type: %w", err)
+	}
+	*t = ss
+	return nil
+}
+
+// main returns the first non-null type.
+func (t types) main() string {
+	for _, s := range t {
+		if s != "null" {
+			return s
+		}
+	}
+	return ""
+}
+
+// property is a named schema, in the order of its appearance in the spec.
+type property struct {
+	Name   string
+	Schema *schema
+}
+
+// properties preserve the order of schema properties in the spec,
+// so that generated struct fields are in the same order.
+type properties []property
+
+func (p *properties) UnmarshalJSON(b []byte) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	if _, err := d.Token(); err != nil { // "{".
+		return fmt.Errorf("invalid schema properties: %w", err)
+	}
+
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("invalid schema properties: %w", err)
+		}
+		name, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("invalid schema property name: %v", t)
+		}
+
+		s := new(schema)
+		if err := d.Decode(s); err != nil {
+			return fmt.Errorf("invalid schema property %q: %w", name, err)
+		}
+		*p = append(*p, property{Name: name, Schema: s})
+	}
+
+	return nil
+}
+
+// loadSpec reads an API spec from a local cache directory, or
+// downloads it (and caches it) if it's not there yet.
+func loadSpec(ctx context.Context, service, url, cacheDir string) (*spec, error) {
+	path := filepath.Join(cacheDir, service+".json")
+	b, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		if b, err = download(ctx, url); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(cacheDir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create spec cache directory: %w", err)
+		}
+		if err := os.WriteFile(path, b, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to cache %s spec: %w", service, err)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s spec: %w", service, err)
+	}
+
+	s := new(spec)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s spec: %w", service, err)
+	}
+	return s, nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %q: %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", url, err)
+	}
+	return b, nil
+}
+
+// operation returns an operation by its ID, with the path's
+// common parameters (if there are any) added to its own.
+func (s *spec) operation(id string) (*operation, error) {
+	for path, item := range s.Paths {
+		var common []*parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &common); err != nil {
+				return nil, fmt.Errorf("invalid parameters in path %q: %w", path, err)
+			}
+		}
+
+		for method, raw := range item {
+			switch method {
+			case "get", "put", "post", "delete", "patch":
+			default:
+				continue
+			}
+
+			op := new(operation)
+			if err := json.Unmarshal(raw, op); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if op.OperationID != id {
+				continue
+			}
+
+			op.Method, op.Path = strings.ToUpper(method), path
+			op.Parameters = slices.Concat(common, op.Parameters)
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operation %q not found", id)
+}
+
+// refName returns the last component of a JSON reference, e.g. "#/components/schemas/foo" => "foo".
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func (s *spec) schema(ref string) *schema {
+	if v, ok := s.Components.Schemas[refName(ref)]; ok && strings.HasPrefix(ref, "#/components/") {
+		return v
+	}
+	return s.Definitions[refName(ref)]
+}
+
+func (s *spec) parameter(p *parameter) *parameter {
+	if p.Ref == "" {
+		return p
+	}
+	if v, ok := s.Components.Parameters[refName(p.Ref)]; ok && strings.HasPrefix(p.Ref, "#/components/") {
+		return v
+	}
+	if v, ok := s.Parameters[refName(p.Ref)]; ok {
+		return v
+	}
+	return p
+}
+
+func (s *spec) requestBody(rb *requestBody) *requestBody {
+	if rb != nil && rb.Ref != "" {
+		if v, ok := s.Components.RequestBodies[refName(rb.Ref)]; ok {
+			return v
+		}
+	}
+	return rb
+}
+
+func (s *spec) response(r *response) *response {
+	if r != nil && r.Ref != "" {
+		if v, ok := s.Components.Responses[refName(r.Ref)]; ok {
+			return v
+		}
+		if v, ok := s.Responses[refName(r.Ref)]; ok {
+			return v
+		}
+	}
+	return r
+}
+
+// jsonSchema returns the JSON schema of a request body or response, if there is one.
+func jsonSchema(content map[string]mediaType) *schema {
+	if m, ok := content["application/json"]; ok {
+		return m.Schema
+	}
+	for mime, m := range content {
+		if strings.HasSuffix(mime, "+json") {
+			return m.Schema
+		}
+	}
+	return nil
+}
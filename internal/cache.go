@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"go.temporal.io/sdk/workflow"
+)
+
+// CacheKey is the key of a [CacheFunc] in workflow contexts.
+type CacheKey struct{}
+
+// CacheFunc returns the future of a Timpani activity: either a memoized
+// future of an identical request, or a new one which is returned by execute.
+type CacheFunc func(ctx workflow.Context, name string, req any, execute func() workflow.Future) workflow.Future
+
+// Cached calls the [CacheFunc] in the workflow context, if there
+// is one (see the cache package). Otherwise, it calls execute.
+func Cached(ctx workflow.Context, name string, req any, execute func() workflow.Future) workflow.Future {
+	fn, ok := ctx.Value(CacheKey{}).(CacheFunc)
+	if !ok {
+		return execute()
+	}
+	return fn(ctx, name, req, execute)
+}
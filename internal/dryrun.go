@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// DryRunKey is the key of a [DryRunFunc] in workflow contexts.
+type DryRunKey struct{}
+
+// DryRunFunc returns a synthetic response for a Timpani activity or child workflow,
+// instead of executing it, or false if it should be executed normally.
+type DryRunFunc func(ctx workflow.Context, name string, req any) (resp any, ok bool)
+
+// DryRun calls the [DryRunFunc] in the workflow context, if there is one
+// (see the dryrun package). Otherwise, it returns false.
+func DryRun(ctx workflow.Context, name string, req any) (any, bool) {
+	fn, ok := ctx.Value(DryRunKey{}).(DryRunFunc)
+	if !ok {
+		return nil, false
+	}
+	return fn(ctx, name, req)
+}
+
+// DryRunEnabled reports whether there's a [DryRunFunc] in the workflow context.
+func DryRunEnabled(ctx workflow.Context) bool {
+	_, ok := ctx.Value(DryRunKey{}).(DryRunFunc)
+	return ok
+}
+
+// convert returns a synthetic response as the expected response type,
+// either directly or by converting it through JSON, like real responses.
+func convert[T any](name string, resp any) (*T, error) {
+	if v, ok := resp.(*T); ok {
+		return v, nil
+	}
+
+	v := new(T)
+	if resp == nil {
+		return v, nil
+	}
+
+	b, err := json.Marshal(resp)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert synthetic %q response: %w", name, err)
+	}
+
+	return v, nil
+}
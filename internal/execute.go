@@ -3,29 +3,97 @@
 package internal
 
 import (
+	"strings"
+
 	"go.temporal.io/sdk/workflow"
 
+	"github.com/tzrikka/timpani-api/pkg/async"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/interceptor"
 	"github.com/tzrikka/timpani-api/pkg/temporal"
+	"github.com/tzrikka/timpani-api/pkg/versioning"
 )
 
 // ExecuteTimpaniActivity requests the [Timpani worker] to execute one
 // of its [activities] on behalf of the calling Temporal workflow, with
-// preconfigured [temporal.ActivityOptions] related to timeouts and retries.
+// preconfigured [temporal.ActivityOptions] related to timeouts and retries,
+// and any applicable [temporal.Overrides] (see [temporal.ActivityOptionsFor]).
+//
+// If the workflow context selects a Thrippy link for the activity's service,
+// it is added to the request, unless the request already specifies one.
+//
+// Requests which fail client-side validation (i.e. their "Validate() error" method
+// returns an error) are not sent to the Timpani worker at all.
+//
+// In dry-run mode (see [DryRun]), mutating activities are not sent
+// to the Timpani worker either, and return synthetic responses instead.
+//
+// If the workflow context has a cache (see [Cached]), identical
+// read-only requests may share the same activity execution.
+//
+// If the activity has a registered [versioning.Migration], the workflow's history
+// determines which version of the activity's name and request shape to use.
+//
+// All the registered [interceptor.Interceptor] functions are called around the execution.
+//
+// Activity failures are converted into typed errors when possible (see [errors.Classify]).
 //
 // [Timpani worker]: https://pkg.go.dev/github.com/tzrikka/timpani
 // [activities]: https://pkg.go.dev/github.com/tzrikka/timpani/pkg/api
 func ExecuteTimpaniActivity[T any](ctx workflow.Context, name string, req any) (*T, error) {
-	opts := temporal.ActivityOptions
-	if opts == nil {
-		opts = temporal.DefaultActivityOptions("timpani")
+	return StartTimpaniActivity[T](ctx, name, req).Get(ctx)
+}
+
+// StartTimpaniActivity is the asynchronous version of [ExecuteTimpaniActivity]:
+// it schedules the activity, and returns a typed future instead of waiting for it.
+func StartTimpaniActivity[T any](ctx workflow.Context, name string, req any) async.Future[*T] {
+	if err := Validate(name, req, workflow.Now(ctx)); err != nil {
+		return ready[*T](ctx, nil, err)
 	}
 
-	resp := new(T)
-	err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, *opts), name, req).Get(ctx, resp)
+	req = WithThrippyLink(ctx, name, req)
+	if resp, ok := DryRun(ctx, name, req); ok {
+		v, err := convert[T](name, resp)
+		return ready(ctx, v, err)
+	}
+
+	version, req, err := versioning.Resolve(ctx, name, req)
 	if err != nil {
-		return nil, err
+		return ready[*T](ctx, nil, err)
 	}
-	return resp, nil
+
+	service, _, _ := strings.Cut(name, ".")
+	call := &interceptor.Call{
+		Name:    version,
+		Service: service,
+		Request: req,
+		Options: temporal.ActivityOptionsFor(ctx, name),
+	}
+	f := Cached(ctx, name, req, func() workflow.Future {
+		return interceptor.Wrap(execute)(ctx, call)
+	})
+
+	return async.FromFuture(f, func(ctx workflow.Context) (*T, error) {
+		resp := new(T)
+		if err := f.Get(ctx, resp); err != nil {
+			return nil, errors.Classify(name, err)
+		}
+		return resp, nil
+	})
+}
+
+// ready returns a typed future which is already resolved.
+func ready[T any](ctx workflow.Context, v T, err error) async.Future[T] {
+	f, s := workflow.NewFuture(ctx)
+	s.SetError(err)
+	return async.FromFuture(f, func(workflow.Context) (T, error) {
+		return v, err
+	})
+}
+
+// execute is the final [interceptor.Invoker] of all Timpani activities.
+func execute(ctx workflow.Context, call *interceptor.Call) workflow.Future {
+	return workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, call.Options), call.Name, call.Request)
 }
 
 // ExecuteTimpaniActivityNoResp is a convenience wrapper around
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+// WithThrippyLink returns a copy of a Timpani activity's or child workflow's
+// request, with the Thrippy link ID which is selected in the workflow context
+// for the activity's service (see [temporal.WithThrippyLink]). This applies
+// only if the request is a struct (or a pointer to one) with an empty
+// "ThrippyLinkID" string field. Otherwise, the request is returned as-is.
+func WithThrippyLink(ctx workflow.Context, name string, req any) any {
+	service, _, _ := strings.Cut(name, ".")
+	linkID := temporal.ThrippyLink(ctx, service)
+	if linkID == "" || req == nil {
+		return req
+	}
+
+	v := reflect.ValueOf(req)
+	isPtr := v.Kind() == reflect.Pointer
+	if isPtr {
+		if v.IsNil() {
+			return req
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return req
+	}
+
+	f := v.FieldByName("ThrippyLinkID")
+	if !f.IsValid() || f.Kind() != reflect.String || f.String() != "" {
+		return req
+	}
+
+	// Don't modify the caller's request.
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+	c.Elem().FieldByName("ThrippyLinkID").SetString(linkID)
+
+	if isPtr {
+		return c.Interface()
+	}
+	return c.Elem().Interface()
+}
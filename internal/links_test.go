@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+type linkRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+	Text          string `json:"text"`
+}
+
+type noLinkRequest struct {
+	Text string `json:"text"`
+}
+
+func TestWithThrippyLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		links    [][2]string // Service and link ID, applied in order.
+		activity string
+		req      any
+		want     any
+	}{
+		{
+			name:     "no_link_in_context",
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{Text: "hi"},
+			want:     linkRequest{Text: "hi"},
+		},
+		{
+			name:     "struct",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{Text: "hi"},
+			want:     linkRequest{ThrippyLinkID: "link1", Text: "hi"},
+		},
+		{
+			name:     "pointer",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      &linkRequest{Text: "hi"},
+			want:     &linkRequest{ThrippyLinkID: "link1", Text: "hi"},
+		},
+		{
+			name:     "nil_pointer",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      (*linkRequest)(nil),
+			want:     (*linkRequest)(nil),
+		},
+		{
+			name:     "explicit_link_in_request",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{ThrippyLinkID: "link2"},
+			want:     linkRequest{ThrippyLinkID: "link2"},
+		},
+		{
+			name:     "other_service",
+			links:    [][2]string{{"github", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{Text: "hi"},
+			want:     linkRequest{Text: "hi"},
+		},
+		{
+			name:     "multiple_services",
+			links:    [][2]string{{"github", "link1"}, {"slack", "link2"}},
+			activity: "github.users.get",
+			req:      linkRequest{},
+			want:     linkRequest{ThrippyLinkID: "link1"},
+		},
+		{
+			name:     "nested_override",
+			links:    [][2]string{{"slack", "link1"}, {"slack", "link2"}},
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{},
+			want:     linkRequest{ThrippyLinkID: "link2"},
+		},
+		{
+			name:     "nested_default",
+			links:    [][2]string{{"slack", "link1"}, {"slack", ""}},
+			activity: "slack.chat.postMessage",
+			req:      linkRequest{},
+			want:     linkRequest{},
+		},
+		{
+			name:     "request_without_link_field",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      noLinkRequest{Text: "hi"},
+			want:     noLinkRequest{Text: "hi"},
+		},
+		{
+			name:     "non_struct_request",
+			links:    [][2]string{{"slack", "link1"}},
+			activity: "slack.chat.postMessage",
+			req:      map[string]any{"text": "hi"},
+			want:     map[string]any{"text": "hi"},
+		},
+		{
+			name:     "nil_request",
+			links:    [][2]string{{"timpani", "link1"}},
+			activity: "timpani.capabilities",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			var orig any
+			if p, ok := tt.req.(*linkRequest); ok && p != nil {
+				c := *p
+				orig = &c
+			}
+
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				for _, l := range tt.links {
+					ctx = temporal.WithThrippyLink(ctx, l[0], l[1])
+				}
+				got = WithThrippyLink(ctx, tt.activity, tt.req)
+				return nil
+			})
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithThrippyLink() = %#v, want %#v", got, tt.want)
+			}
+			if orig != nil && !reflect.DeepEqual(tt.req, orig) {
+				t.Errorf("WithThrippyLink() modified the caller's request: %#v", tt.req)
+			}
+		})
+	}
+}
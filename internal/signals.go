@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"encoding/json"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+	"weak"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+// MaxPendingSignals is the maximum number of unclaimed payloads
+// which are kept for each signal in each workflow execution.
+const MaxPendingSignals = 100
+
+// pending payloads of each signal, in each workflow execution. Payloads that
+// one receiver drains from a signal channel, but which don't match its filter,
+// are kept here for other receivers of the same signal, which check them before
+// blocking. The entries are keyed by the execution's [workflow.Info], which the
+// Temporal SDK allocates whenever it (re)builds an execution's state, so they
+// are rebuilt during replays too, and they are deleted when it's collected.
+var (
+	pendingMu sync.Mutex
+	pending   = map[weak.Pointer[workflow.Info]]map[string]*queue{}
+)
+
+// queue is the list of pending payloads of a single signal.
+type queue struct {
+	payloads []payload
+	seq      int // Sequence number of the last payload that was added.
+}
+
+// payload is a pending signal payload, with a sequence number that allows
+// receivers to check each pending payload only once, not whenever they wake up.
+type payload struct {
+	seq int
+	raw json.RawMessage
+}
+
+// ReceiveSignal waits for the next payload of a Temporal signal which matches a filter
+// (all payloads match if the filter is nil), and returns it. Payloads which cannot be
+// decoded as the expected type are discarded.
+//
+// Each payload is delivered to only one receiver, but receivers don't discard payloads
+// which don't match their filters: they keep them (up to [MaxPendingSignals] per signal)
+// for other receivers of the same signal in the same workflow, either concurrent ones
+// (e.g. in different coroutines) or later ones, which check them before blocking.
+//
+// If the timeout is positive and expires first, it returns [errors.ErrTimeout].
+// If the workflow context is canceled first, it returns the context's error.
+func ReceiveSignal[T any](ctx workflow.Context, name string, timeout time.Duration, filter func(*T) bool) (*T, error) {
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		event *T
+		seen  int
+	)
+	s := workflow.NewSelector(ctx)
+	addSignal(ctx, s, name, filter, &event)
+
+	err := selectUntil(ctx, s, timeout, func() bool {
+		event = claim(ctx, name, filter, event, &seen)
+		return event != nil
+	})
+	return event, err
+}
+
+// ReceiveEitherSignal is similar to [ReceiveSignal], but waits for the next matching payload
+// of either one of two Temporal signals. If there's no error, exactly one of them is returned.
+func ReceiveEitherSignal[A, B any](ctx workflow.Context, nameA, nameB string, timeout time.Duration,
+	filterA func(*A) bool, filterB func(*B) bool,
+) (*A, *B, error) {
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		a            *A
+		b            *B
+		seenA, seenB int
+	)
+	s := workflow.NewSelector(ctx)
+	addSignal(ctx, s, nameA, filterA, &a)
+	addSignal(ctx, s, nameB, filterB, &b)
+
+	err := selectUntil(ctx, s, timeout, func() bool {
+		if a = claim(ctx, nameA, filterA, a, &seenA); a == nil && b == nil {
+			b = claim(ctx, nameB, filterB, b, &seenB)
+		}
+		return a != nil || b != nil
+	})
+	return a, b, err
+}
+
+// addSignal adds a Temporal signal channel to a selector. Matching payloads are stored in
+// the given event pointer (unless it's already set), and all the others are kept pending.
+func addSignal[T any](ctx workflow.Context, s workflow.Selector, name string, filter func(*T) bool, event **T) {
+	s.AddReceive(workflow.GetSignalChannel(ctx, name), func(c workflow.ReceiveChannel, _ bool) {
+		var raw json.RawMessage
+		c.Receive(ctx, &raw)
+
+		e := new(T)
+		if jsonErr := json.Unmarshal(raw, e); jsonErr != nil {
+			workflow.GetLogger(ctx).Warn("discarding malformed signal payload", "signal", name, "error", jsonErr)
+			return
+		}
+		if *event == nil && (filter == nil || filter(e)) {
+			*event = e
+			return
+		}
+
+		q := pendingSignals(ctx, name)
+		if len(q.payloads) >= MaxPendingSignals {
+			workflow.GetLogger(ctx).Warn("discarding unclaimed signal payload", "signal", name)
+			q.payloads = q.payloads[1:]
+		}
+		q.seq++
+		q.payloads = append(q.payloads, payload{seq: q.seq, raw: raw})
+	})
+}
+
+// claim returns the given event if it's already set, or removes and returns the first pending
+// payload of a signal which matches the filter, or nil if there isn't one. It skips payloads
+// which the caller has already seen. Pending payloads are older than those in the signal channel.
+func claim[T any](ctx workflow.Context, name string, filter func(*T) bool, event *T, seen *int) *T {
+	if event != nil {
+		return event
+	}
+
+	q := pendingSignals(ctx, name)
+	for i, p := range q.payloads {
+		if p.seq <= *seen {
+			continue
+		}
+		*seen = p.seq
+
+		e := new(T)
+		if json.Unmarshal(p.raw, e) != nil || (filter != nil && !filter(e)) {
+			continue
+		}
+		q.payloads = slices.Delete(q.payloads, i, i+1)
+		return e
+	}
+	return nil
+}
+
+// pendingSignals returns the queue of pending payloads of a signal in the
+// current workflow execution. It may be called only by workflow coroutines,
+// which the Temporal SDK never runs concurrently in the same execution.
+func pendingSignals(ctx workflow.Context, name string) *queue {
+	info := workflow.GetInfo(ctx)
+	key := weak.Make(info)
+
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+
+	qs, ok := pending[key]
+	if !ok {
+		qs = map[string]*queue{}
+		pending[key] = qs
+		runtime.AddCleanup(info, func(key weak.Pointer[workflow.Info]) {
+			pendingMu.Lock()
+			defer pendingMu.Unlock()
+			delete(pending, key)
+		}, key)
+	}
+
+	q, ok := qs[name]
+	if !ok {
+		q = &queue{}
+		qs[name] = q
+	}
+	return q
+}
+
+// selectUntil runs a selector until a condition is met, the optional
+// timeout expires, or the workflow context is canceled. The condition
+// is also checked whenever another coroutine makes progress, because it
+// may claim pending signal payloads which other receivers have kept.
+func selectUntil(ctx workflow.Context, s workflow.Selector, timeout time.Duration, done func() bool) error {
+	var err error
+	s.AddReceive(ctx.Done(), func(workflow.ReceiveChannel, bool) {
+		err = ctx.Err()
+	})
+	if timeout > 0 {
+		s.AddFuture(workflow.NewTimer(ctx, timeout), func(f workflow.Future) {
+			if err = f.Get(ctx, nil); err == nil {
+				err = errors.ErrTimeout
+			}
+		})
+	}
+
+	for !done() && err == nil {
+		// Await doesn't return an error here: the selector handles cancellations.
+		_ = workflow.Await(ctx, func() bool { return s.HasPending() || done() })
+		if s.HasPending() {
+			s.Select(ctx)
+		}
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+const testSignal = "test.signal"
+
+type testEvent struct {
+	ID string `json:"id"`
+}
+
+func idFilter(id string) func(*testEvent) bool {
+	return func(e *testEvent) bool { return e.ID == id }
+}
+
+// signalWorkflow runs a test workflow, and sends it signal payloads
+// (in order, one minute apart), and then checks the workflow's error.
+func signalWorkflow(t *testing.T, wf func(ctx workflow.Context) error, payloads ...any) {
+	t.Helper()
+
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	for i, p := range payloads {
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(testSignal, p)
+		}, time.Duration(i+1)*time.Minute)
+	}
+
+	env.ExecuteWorkflow(wf)
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+}
+
+func TestReceiveSignalConcurrentReceivers(t *testing.T) {
+	got := map[string]time.Duration{} // Workflow time of each receiver's event.
+	signalWorkflow(t, func(ctx workflow.Context) error {
+		start := workflow.Now(ctx)
+		wg := workflow.NewWaitGroup(ctx)
+		for _, id := range []string{"a", "b", "c"} {
+			wg.Add(1)
+			workflow.Go(ctx, func(ctx workflow.Context) {
+				defer wg.Done()
+				if _, err := ReceiveSignal(ctx, testSignal, time.Hour, idFilter(id)); err == nil {
+					got[id] = workflow.Now(ctx).Sub(start)
+				}
+			})
+		}
+		wg.Wait(ctx)
+		return nil
+	}, testEvent{ID: "c"}, testEvent{ID: "x"}, testEvent{ID: "b"}, testEvent{ID: "a"})
+
+	want := map[string]time.Duration{"a": 4 * time.Minute, "b": 3 * time.Minute, "c": time.Minute}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReceiveSignal() times = %v, want %v", got, want)
+	}
+}
+
+func TestReceiveSignalPending(t *testing.T) {
+	var got []string
+	signalWorkflow(t, func(ctx workflow.Context) error {
+		// The first receiver keeps the non-matching payloads pending.
+		for _, id := range []string{"c", "b", "a", "d"} {
+			e, err := ReceiveSignal(ctx, testSignal, time.Hour, idFilter(id))
+			if err != nil {
+				return err
+			}
+			got = append(got, e.ID)
+		}
+
+		// All the payloads have been claimed, so there's nothing left for this one.
+		if _, err := ReceiveSignal[testEvent](ctx, testSignal, time.Minute, nil); !errors.IsTimeout(err) {
+			return fmt.Errorf("ReceiveSignal() error = %w, want a timeout", err)
+		}
+		return nil
+	}, testEvent{ID: "a"}, testEvent{ID: "b"}, testEvent{ID: "c"}, testEvent{ID: "d"})
+
+	if want := []string{"c", "b", "a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReceiveSignal() = %v, want %v", got, want)
+	}
+}
+
+func TestReceiveSignalMaxPending(t *testing.T) {
+	payloads := make([]any, MaxPendingSignals+2)
+	for i := range payloads {
+		payloads[i] = testEvent{ID: fmt.Sprint(i)}
+	}
+
+	signalWorkflow(t, func(ctx workflow.Context) error {
+		last := fmt.Sprint(len(payloads) - 1)
+		if _, err := ReceiveSignal(ctx, testSignal, 0, idFilter(last)); err != nil {
+			return err
+		}
+
+		// The oldest payload was discarded, and the next one is the oldest pending one.
+		e, err := ReceiveSignal[testEvent](ctx, testSignal, time.Second, nil)
+		if err != nil {
+			return err
+		}
+		if e.ID != "1" {
+			return fmt.Errorf("ReceiveSignal() = %q, want %q", e.ID, "1")
+		}
+		return nil
+	}, payloads...)
+}
+
+func TestReceiveSignalMalformed(t *testing.T) {
+	signalWorkflow(t, func(ctx workflow.Context) error {
+		e, err := ReceiveSignal[testEvent](ctx, testSignal, time.Hour, nil)
+		if err != nil {
+			return err
+		}
+		if e.ID != "a" {
+			return fmt.Errorf("ReceiveSignal() = %q, want %q", e.ID, "a")
+		}
+		return nil
+	}, "not an object", testEvent{ID: "a"})
+}
+
+func TestReceiveSignalCanceled(t *testing.T) {
+	signalWorkflow(t, func(ctx workflow.Context) error {
+		ctx, cancel := workflow.WithCancel(ctx)
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			_ = workflow.Sleep(ctx, time.Minute)
+			cancel()
+		})
+
+		if _, err := ReceiveSignal[testEvent](ctx, testSignal, time.Hour, nil); err == nil || errors.IsTimeout(err) {
+			return fmt.Errorf("ReceiveSignal() error = %v, want a cancellation", err)
+		}
+		return nil
+	})
+}
+
+func TestReceiveEitherSignalPending(t *testing.T) {
+	const otherSignal = "test.other"
+
+	var got []string
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	env.RegisterDelayedCallback(func() { env.SignalWorkflow(otherSignal, testEvent{ID: "b"}) }, time.Minute)
+	env.RegisterDelayedCallback(func() { env.SignalWorkflow(testSignal, testEvent{ID: "a"}) }, 2*time.Minute)
+
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		// A concurrent receiver which drains the other signal, and keeps the non-matching payload.
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			_, _ = ReceiveSignal(ctx, otherSignal, time.Hour, idFilter("z"))
+		})
+
+		for range 2 {
+			a, b, err := ReceiveEitherSignal(ctx, testSignal, otherSignal, time.Hour, idFilter("a"), idFilter("b"))
+			if err != nil {
+				return err
+			}
+			if a != nil {
+				got = append(got, "a:"+a.ID)
+			}
+			if b != nil {
+				got = append(got, "b:"+b.ID)
+			}
+		}
+		return nil
+	})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+	if want := []string{"b:b", "a:a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReceiveEitherSignal() = %v, want %v", got, want)
+	}
+}
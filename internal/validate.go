@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+// Validator collects client-side validation problems of a single Timpani
+// request, and reports them as a [errors.ValidationFailedError]. Problems
+// refer to request fields by their JSON names, as in the upstream APIs.
+type Validator struct {
+	activity string
+	problems []string
+}
+
+// NewValidator returns a new [Validator] for a request of a specific Timpani activity.
+// The activity's name may be empty if the request type is shared by multiple activities,
+// in which case [ExecuteTimpaniActivity] fills it in.
+func NewValidator(activity string) *Validator {
+	return &Validator{activity: activity}
+}
+
+// Require adds a problem if the value of a required field is missing (i.e. a zero value).
+func (v *Validator) Require(field string, value any) *Validator {
+	if isZero(value) {
+		v.problems = append(v.problems, fmt.Sprintf("missing required field %q", field))
+	}
+	return v
+}
+
+// RequireAny adds a problem if all the values of a set of fields are missing.
+// The fields are described in the problem exactly as specified.
+func (v *Validator) RequireAny(fields string, values ...any) *Validator {
+	if !slices.ContainsFunc(values, func(value any) bool { return !isZero(value) }) {
+		v.problems = append(v.problems, "at least one of "+fields+" is required")
+	}
+	return v
+}
+
+// Exclusive adds a problem if more than one of the values of a set
+// of fields is set. The fields are described in the problem exactly as specified.
+func (v *Validator) Exclusive(fields string, values ...any) *Validator {
+	set := 0
+	for _, value := range values {
+		if !isZero(value) {
+			set++
+		}
+	}
+	if set > 1 {
+		v.problems = append(v.problems, "only one of "+fields+" may be set")
+	}
+	return v
+}
+
+// Enum adds a problem if the value of a field is set, but is not one of the allowed values.
+func (v *Validator) Enum(field, value string, allowed ...string) *Validator {
+	if value != "" && !slices.Contains(allowed, value) {
+		v.problems = append(v.problems, fmt.Sprintf("invalid value %q in field %q (allowed: %s)",
+			value, field, strings.Join(allowed, ", ")))
+	}
+	return v
+}
+
+// Check adds a problem if a condition is false.
+func (v *Validator) Check(ok bool, problem string) *Validator {
+	if !ok {
+		v.problems = append(v.problems, problem)
+	}
+	return v
+}
+
+// Err returns a [errors.ValidationFailedError] with all the
+// problems that were found, or nil if there aren't any.
+func (v *Validator) Err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return &errors.ValidationFailedError{
+		Info:     errors.Info{Activity: v.activity, Message: message(v.activity, v.problems)},
+		Problems: v.problems,
+	}
+}
+
+const invalidRequest = "invalid request: "
+
+func message(activity string, problems []string) string {
+	if activity == "" {
+		return invalidRequest + strings.Join(problems, "; ")
+	}
+	return fmt.Sprintf("invalid %q request: %s", activity, strings.Join(problems, "; "))
+}
+
+func isZero(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// Validate runs the client-side validation of a Timpani request,
+// if its type has a "Validate() error" method (e.g. all the request
+// types in this module's service packages).
+//
+// Requests whose validity depends on the current time (e.g. scheduled Slack
+// messages) also have a "ValidateAt(time.Time) error" method, which is preferred,
+// with the given time: workflow.Now in workflows, for deterministic replays,
+// and the wall-clock time outside them.
+func Validate(name string, req any, now time.Time) error {
+	if v := reflect.ValueOf(req); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+
+	var err error
+	switch r := req.(type) {
+	case interface{ ValidateAt(now time.Time) error }:
+		err = r.ValidateAt(now)
+	case interface{ Validate() error }:
+		err = r.Validate()
+	default:
+		return nil
+	}
+	if info, ok := errors.InfoOf(err); ok && info.Activity == "" {
+		info.Activity = name
+		info.Message = message(name, []string{strings.TrimPrefix(info.Message, invalidRequest)})
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+// Package async provides typed futures for Timpani activities and child
+// workflows, so Temporal workflows can execute several of them in parallel.
+//
+// Every wrapper function in this module, including convenience wrappers such as
+// slack.RequestApproval and slack.ViewsOpenAndWait, has an asynchronous variant
+// with the suffix "Async", which returns a [Future] instead of waiting for the
+// result (iterators, with the suffix "Iter", don't have one):
+//
+//	f1 := slack.UsersInfoAsync(ctx, "U123")
+//	f2 := slack.UsersInfoAsync(ctx, "U456")
+//
+//	u1, err1 := f1.Get(ctx)
+//	u2, err2 := f2.Get(ctx)
+//
+// [Gather] executes the same wrapper function for a slice of
+// inputs, with bounded concurrency, and returns all the results.
+//
+// All of these are built on top of Temporal's own workflow futures and
+// coroutines, so they are deterministic, and therefore safe for replays.
+package async
+
+import (
+	"go.temporal.io/sdk/workflow"
+)
+
+// Future is a typed version of [workflow.Future].
+type Future[T any] struct {
+	future workflow.Future
+	get    func(workflow.Context) (T, error)
+}
+
+// FromFuture returns a typed future based on a [workflow.Future], and a function that
+// blocks until it's ready, and then returns its (possibly transformed) value or error.
+func FromFuture[T any](f workflow.Future, get func(workflow.Context) (T, error)) Future[T] {
+	return Future[T]{future: f, get: get}
+}
+
+// Go runs a function in a workflow coroutine (see [workflow.Go]),
+// and returns a typed future that will be resolved with its results.
+// This is useful to parallelize synchronous functions and wrappers.
+func Go[T any](ctx workflow.Context, fn func(workflow.Context) (T, error)) Future[T] {
+	f, s := workflow.NewFuture(ctx)
+	var v T
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		var err error
+		v, err = fn(ctx)
+		s.SetError(err)
+	})
+
+	return FromFuture(f, func(ctx workflow.Context) (T, error) {
+		err := f.Get(ctx, nil)
+		return v, err
+	})
+}
+
+// GoNoResp is similar to [Go], for functions which return only an error.
+func GoNoResp(ctx workflow.Context, fn func(workflow.Context) error) Future[struct{}] {
+	return Go(ctx, func(ctx workflow.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+}
+
+// Get blocks until the future is ready, and then returns its value or error.
+// It may be called multiple times, and always returns the same results.
+func (f Future[T]) Get(ctx workflow.Context) (T, error) {
+	return f.get(ctx)
+}
+
+// IsReady returns true if calling [Future.Get] won't block.
+func (f Future[T]) IsReady() bool {
+	return f.future.IsReady()
+}
+
+// Underlying returns the untyped [workflow.Future], e.g.
+// for use in [workflow.Selector.AddFuture] calls.
+func (f Future[T]) Underlying() workflow.Future {
+	return f.future
+}
+
+// Result is the value or error of a single item in [Gather].
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Gather starts a function which returns a [Future] for each input, with up to limit
+// futures in flight at the same time (0 = no limit), and waits for all of them to be
+// ready. It returns a result for each input, in the same order as the inputs, so a
+// failure of one item does not affect the others. For example:
+//
+//	results := async.Gather(ctx, userIDs, 10, slack.UsersInfoAsync)
+func Gather[In, T any](ctx workflow.Context, inputs []In, limit int, start func(workflow.Context, In) Future[T]) []Result[T] {
+	results := make([]Result[T], len(inputs))
+	sel := workflow.NewSelector(ctx)
+
+	pending := 0
+	for i, in := range inputs {
+		if limit > 0 && pending >= limit {
+			sel.Select(ctx)
+			pending--
+		}
+
+		f := start(ctx, in)
+		sel.AddFuture(f.Underlying(), func(workflow.Future) {
+			results[i].Value, results[i].Err = f.Get(ctx)
+		})
+		pending++
+	}
+
+	for ; pending > 0; pending-- {
+		sel.Select(ctx)
+	}
+
+	return results
+}
@@ -0,0 +1,157 @@
+package async_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+)
+
+type gatherResult struct {
+	Values      []int
+	Errors      []string
+	MaxInFlight int
+}
+
+// gatherWorkflow gathers the squares of the inputs, in coroutines which sleep
+// for (10 - input) seconds, so they finish in a different order than they start.
+// Negative inputs fail. It also returns the maximum number of coroutines in flight.
+func gatherWorkflow(ctx workflow.Context, inputs []int, limit int) (*gatherResult, error) {
+	inFlight, maxInFlight := 0, 0
+	square := func(ctx workflow.Context, n int) async.Future[int] {
+		return async.Go(ctx, func(ctx workflow.Context) (int, error) {
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			defer func() { inFlight-- }()
+
+			if err := workflow.Sleep(ctx, time.Duration(10-n)*time.Second); err != nil {
+				return 0, err
+			}
+			if n < 0 {
+				return 0, fmt.Errorf("negative input %d", n)
+			}
+			return n * n, nil
+		})
+	}
+
+	r := &gatherResult{}
+	for _, res := range async.Gather(ctx, inputs, limit, square) {
+		r.Values = append(r.Values, res.Value)
+		msg := ""
+		if res.Err != nil {
+			msg = res.Err.Error()
+		}
+		r.Errors = append(r.Errors, msg)
+	}
+	r.MaxInFlight = maxInFlight
+	return r, nil
+}
+
+func TestGather(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs []int
+		limit  int
+		want   gatherResult
+	}{
+		{
+			name: "no_inputs",
+			want: gatherResult{},
+		},
+		{
+			name:   "no_limit",
+			inputs: []int{1, 2, 3, 4},
+			want:   gatherResult{Values: []int{1, 4, 9, 16}, Errors: []string{"", "", "", ""}, MaxInFlight: 4},
+		},
+		{
+			name:   "limit",
+			inputs: []int{1, 2, 3, 4, 5},
+			limit:  2,
+			want:   gatherResult{Values: []int{1, 4, 9, 16, 25}, Errors: []string{"", "", "", "", ""}, MaxInFlight: 2},
+		},
+		{
+			name:   "limit_above_inputs",
+			inputs: []int{1, 2},
+			limit:  5,
+			want:   gatherResult{Values: []int{1, 4}, Errors: []string{"", ""}, MaxInFlight: 2},
+		},
+		{
+			name:   "partial_failure",
+			inputs: []int{1, -2, 3},
+			limit:  1,
+			want:   gatherResult{Values: []int{1, 0, 9}, Errors: []string{"", "negative input -2", ""}, MaxInFlight: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			env.ExecuteWorkflow(gatherWorkflow, tt.inputs, tt.limit)
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			var got gatherResult
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatalf("failed to get workflow result: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Gather() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+var errFailed = errors.New("failed")
+
+// futureWorkflow checks the readiness and repeated results of typed futures.
+func futureWorkflow(ctx workflow.Context) ([]string, error) {
+	var log []string
+
+	f := async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return "value", workflow.Sleep(ctx, time.Minute)
+	})
+	log = append(log, fmt.Sprintf("ready before: %v", f.IsReady()))
+
+	for range 2 {
+		v, err := f.Get(ctx)
+		log = append(log, fmt.Sprintf("get: %q %v", v, err))
+	}
+	log = append(log, fmt.Sprintf("ready after: %v", f.IsReady()))
+
+	nr := async.GoNoResp(ctx, func(workflow.Context) error { return errFailed })
+	workflow.NewSelector(ctx).AddFuture(nr.Underlying(), func(workflow.Future) {}).Select(ctx)
+	_, err := nr.Get(ctx)
+	log = append(log, fmt.Sprintf("no resp: %v %v", nr.IsReady(), err))
+
+	return log, nil
+}
+
+func TestFuture(t *testing.T) {
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	env.ExecuteWorkflow(futureWorkflow)
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+
+	var got []string
+	if err := env.GetWorkflowResult(&got); err != nil {
+		t.Fatalf("failed to get workflow result: %v", err)
+	}
+
+	want := []string{
+		"ready before: false",
+		`get: "value" <nil>`,
+		`get: "value" <nil>`,
+		"ready after: true",
+		"no resp: true failed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("workflow log = %q, want %q", got, want)
+	}
+}
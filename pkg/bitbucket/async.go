@@ -0,0 +1,161 @@
+package bitbucket
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+)
+
+// CommitsDiffAsync is an asynchronous version of [CommitsDiff].
+func CommitsDiffAsync(ctx workflow.Context, req CommitsDiffRequest) async.Future[string] {
+	return async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return CommitsDiff(ctx, req)
+	})
+}
+
+// CommitsDiffstatAsync is an asynchronous version of [CommitsDiffstat].
+func CommitsDiffstatAsync(ctx workflow.Context, req CommitsDiffstatRequest) async.Future[[]Diffstat] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Diffstat, error) {
+		return CommitsDiffstat(ctx, req)
+	})
+}
+
+// PullRequestsApproveAsync is an asynchronous version of [PullRequestsApprove].
+func PullRequestsApproveAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsApprove(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsCreateCommentAsync is an asynchronous version of [PullRequestsCreateComment].
+func PullRequestsCreateCommentAsync(ctx workflow.Context, req PullRequestsCreateCommentRequest) async.Future[*PullRequestsCreateCommentResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullRequestsCreateCommentResponse, error) {
+		return PullRequestsCreateComment(ctx, req)
+	})
+}
+
+// PullRequestsDeclineAsync is an asynchronous version of [PullRequestsDecline].
+func PullRequestsDeclineAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsDecline(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsDeleteCommentAsync is an asynchronous version of [PullRequestsDeleteComment].
+func PullRequestsDeleteCommentAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID, commentID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsDeleteComment(ctx, thrippyLinkID, workspace, repo, prID, commentID)
+	})
+}
+
+// PullRequestsDiffstatAsync is an asynchronous version of [PullRequestsDiffstat].
+func PullRequestsDiffstatAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[[]Diffstat] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Diffstat, error) {
+		return PullRequestsDiffstat(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsGetAsync is an asynchronous version of [PullRequestsGet].
+func PullRequestsGetAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) (map[string]any, error) {
+		return PullRequestsGet(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsGetCommentAsync is an asynchronous version of [PullRequestsGetComment].
+func PullRequestsGetCommentAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID, commentID string) async.Future[*Comment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Comment, error) {
+		return PullRequestsGetComment(ctx, thrippyLinkID, workspace, repo, prID, commentID)
+	})
+}
+
+// PullRequestsListActivityLogAsync is an asynchronous version of [PullRequestsListActivityLog].
+func PullRequestsListActivityLogAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[[]map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]map[string]any, error) {
+		return PullRequestsListActivityLog(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsListCommitsAsync is an asynchronous version of [PullRequestsListCommits].
+func PullRequestsListCommitsAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[[]Commit] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Commit, error) {
+		return PullRequestsListCommits(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsListForCommitAsync is an asynchronous version of [PullRequestsListForCommit].
+func PullRequestsListForCommitAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, commit string) async.Future[[]map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]map[string]any, error) {
+		return PullRequestsListForCommit(ctx, thrippyLinkID, workspace, repo, commit)
+	})
+}
+
+// PullRequestsListTasksAsync is an asynchronous version of [PullRequestsListTasks].
+func PullRequestsListTasksAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[[]Task] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Task, error) {
+		return PullRequestsListTasks(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsMergeAsync is an asynchronous version of [PullRequestsMerge].
+func PullRequestsMergeAsync(ctx workflow.Context, req PullRequestsMergeRequest) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsMerge(ctx, req)
+	})
+}
+
+// PullRequestsUnapproveAsync is an asynchronous version of [PullRequestsUnapprove].
+func PullRequestsUnapproveAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsUnapprove(ctx, thrippyLinkID, workspace, repo, prID)
+	})
+}
+
+// PullRequestsUpdateAsync is an asynchronous version of [PullRequestsUpdate].
+func PullRequestsUpdateAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string, update map[string]any) async.Future[map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) (map[string]any, error) {
+		return PullRequestsUpdate(ctx, thrippyLinkID, workspace, repo, prID, update)
+	})
+}
+
+// PullRequestsUpdateCommentAsync is an asynchronous version of [PullRequestsUpdateComment].
+func PullRequestsUpdateCommentAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, prID, commentID, markdown string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsUpdateComment(ctx, thrippyLinkID, workspace, repo, prID, commentID, markdown)
+	})
+}
+
+// SourceGetFileAsync is an asynchronous version of [SourceGetFile].
+func SourceGetFileAsync(ctx workflow.Context, thrippyLinkID, workspace, repo, commit, path string) async.Future[string] {
+	return async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return SourceGetFile(ctx, thrippyLinkID, workspace, repo, commit, path)
+	})
+}
+
+// UsersGetByAccountIDAsync is an asynchronous version of [UsersGetByAccountID].
+func UsersGetByAccountIDAsync(ctx workflow.Context, accountID string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetByAccountID(ctx, accountID)
+	})
+}
+
+// UsersGetByUUIDAsync is an asynchronous version of [UsersGetByUUID].
+func UsersGetByUUIDAsync(ctx workflow.Context, uuid string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetByUUID(ctx, uuid)
+	})
+}
+
+// UsersGetCurrentAsync is an asynchronous version of [UsersGetCurrent].
+func UsersGetCurrentAsync(ctx workflow.Context) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetCurrent(ctx)
+	})
+}
+
+// WorkspacesListMembersAsync is an asynchronous version of [WorkspacesListMembers].
+func WorkspacesListMembersAsync(ctx workflow.Context, workspace string, emailsFilter []string) async.Future[[]User] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]User, error) {
+		return WorkspacesListMembers(ctx, workspace, emailsFilter)
+	})
+}
@@ -1,9 +1,12 @@
 package bitbucket
 
 import (
+	"iter"
+
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -51,9 +54,15 @@ type CommitsDiffstatRequest struct {
 	PageLen string `json:"pagelen,omitempty"`
 	Page    string `json:"page,omitempty"`
 
-	Next string `json:"next,omitempty"` // Populated and used only in Timpani, for pagination.
+	// Next is populated and used only in Timpani, for pagination. Leave it empty to start
+	// from the first page. "start" also means the first page, as it did before iterators.
+	Next string `json:"next,omitempty"`
 }
 
+// firstPage is the legacy value of [CommitsDiffstatRequest.Next] which means
+// the first page. It is never sent to Timpani.
+const firstPage = "start"
+
 // CommitsDiffstatResponse is based on:
 // https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
 type CommitsDiffstatResponse struct {
@@ -71,22 +80,22 @@ type CommitsDiffstatResponse struct {
 //
 // It retrieves the full list of diffstat entries by handling pagination internally.
 func CommitsDiffstat(ctx workflow.Context, req CommitsDiffstatRequest) ([]Diffstat, error) {
-	var ds []Diffstat
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[CommitsDiffstatResponse](ctx, CommitsDiffstatActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		ds = append(ds, resp.Values...)
-		req.Next = resp.Next
+	return pagination.Collect(CommitsDiffstatIter(ctx, req), 0)
+}
+
+// CommitsDiffstatIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
+//
+// It returns an iterator over all the diffstat entries,
+// and handles pagination internally.
+func CommitsDiffstatIter(ctx workflow.Context, req CommitsDiffstatRequest) iter.Seq2[Diffstat, error] {
+	if req.Next == firstPage {
+		req.Next = ""
 	}
 
-	return ds, nil
+	setNext := func(r *CommitsDiffstatRequest, next string) { r.Next = next }
+	page := func(r *CommitsDiffstatResponse) ([]Diffstat, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, CommitsDiffstatActivityName, req, setNext, page)
 }
 
 // Diffstat is based on:
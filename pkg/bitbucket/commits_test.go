@@ -0,0 +1,89 @@
+package bitbucket_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+func TestCommitsDiffstat(t *testing.T) {
+	ds := make([]bitbucket.Diffstat, 5)
+	for i := range ds {
+		ds[i] = bitbucket.Diffstat{Status: "modified", New: &bitbucket.CommitFile{Path: "file" + strconv.Itoa(i)}}
+	}
+
+	tests := []struct {
+		name      string
+		next      string
+		wantPaths []string
+		wantNexts []string // In the requests that were sent to Timpani.
+	}{
+		{
+			name:      "first_page",
+			wantPaths: []string{"file0", "file1", "file2", "file3", "file4"},
+			wantNexts: []string{"", "2", "4"},
+		},
+		{
+			name:      "legacy_start_sentinel",
+			next:      "start",
+			wantPaths: []string{"file0", "file1", "file2", "file3", "file4"},
+			wantNexts: []string{"", "2", "4"},
+		},
+		{
+			name:      "caller_cursor",
+			next:      "3",
+			wantPaths: []string{"file3", "file4"},
+			wantNexts: []string{"3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			w.AddBitbucketDiff("ws", "repo", "main..dev", "", ds)
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			var got []bitbucket.Diffstat
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				req := bitbucket.CommitsDiffstatRequest{
+					CommitsRequest: bitbucket.CommitsRequest{Workspace: "ws", RepoSlug: "repo", Spec: "main..dev"},
+					PageLen:        "2",
+					Next:           tt.next,
+				}
+				var err error
+				got, err = bitbucket.CommitsDiffstat(ctx, req)
+				return err
+			})
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("CommitsDiffstat() error = %v", err)
+			}
+
+			var paths []string
+			for _, d := range got {
+				paths = append(paths, d.New.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("CommitsDiffstat() = %q, want %q", paths, tt.wantPaths)
+			}
+
+			var nexts []string
+			for _, c := range w.Calls(bitbucket.CommitsDiffstatActivityName) {
+				var req bitbucket.CommitsDiffstatRequest
+				if err := c.Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				nexts = append(nexts, req.Next)
+			}
+			if !reflect.DeepEqual(nexts, tt.wantNexts) {
+				t.Errorf("request cursors = %q, want %q", nexts, tt.wantNexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package events provides typed [Bitbucket event payloads], which the Timpani worker
+// sends to Temporal workflows as signals, and functions to receive them.
+//
+// [Bitbucket event payloads]: https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/
+package events
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+)
+
+// Signal is the name of a Temporal signal which carries Bitbucket events of type T.
+// Signals are named after the "X-Event-Key" header, with the "bitbucket.events." prefix.
+type Signal[T any] string
+
+//revive:disable:exported
+const (
+	PullRequestCreatedSignal               Signal[PullRequestEvent] = "bitbucket.events.pullrequest:created"
+	PullRequestUpdatedSignal               Signal[PullRequestEvent] = "bitbucket.events.pullrequest:updated"
+	PullRequestApprovedSignal              Signal[PullRequestEvent] = "bitbucket.events.pullrequest:approved"
+	PullRequestUnapprovedSignal            Signal[PullRequestEvent] = "bitbucket.events.pullrequest:unapproved"
+	PullRequestChangesRequestCreatedSignal Signal[PullRequestEvent] = "bitbucket.events.pullrequest:changes_request_created"
+	PullRequestChangesRequestRemovedSignal Signal[PullRequestEvent] = "bitbucket.events.pullrequest:changes_request_removed"
+	PullRequestFulfilledSignal             Signal[PullRequestEvent] = "bitbucket.events.pullrequest:fulfilled"
+	PullRequestRejectedSignal              Signal[PullRequestEvent] = "bitbucket.events.pullrequest:rejected"
+
+	PullRequestCommentCreatedSignal  Signal[PullRequestEvent] = "bitbucket.events.pullrequest:comment_created"
+	PullRequestCommentUpdatedSignal  Signal[PullRequestEvent] = "bitbucket.events.pullrequest:comment_updated"
+	PullRequestCommentDeletedSignal  Signal[PullRequestEvent] = "bitbucket.events.pullrequest:comment_deleted"
+	PullRequestCommentResolvedSignal Signal[PullRequestEvent] = "bitbucket.events.pullrequest:comment_resolved"
+	PullRequestCommentReopenedSignal Signal[PullRequestEvent] = "bitbucket.events.pullrequest:comment_reopened"
+
+	RepoPushSignal Signal[RepoPushEvent] = "bitbucket.events.repo:push"
+) //revive:enable:exported
+
+// Receive waits for the next Bitbucket event in a Temporal signal which matches a filter
+// (all events match if the filter is nil), and returns it. Non-matching events are
+// kept for other receivers of the same signal in the same workflow, as described in
+// [internal.ReceiveSignal]. If the timeout is positive and expires first, it returns
+// [errors.ErrTimeout].
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func Receive[T any](ctx workflow.Context, s Signal[T], timeout time.Duration, filter func(*T) bool) (*T, error) {
+	return internal.ReceiveSignal(ctx, string(s), timeout, filter)
+}
+
+// PullRequestEvent is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request-events
+type PullRequestEvent struct {
+	Actor       bitbucket.User `json:"actor"`
+	PullRequest PullRequest    `json:"pullrequest"`
+	Repository  Repository     `json:"repository"`
+
+	Approval       *Approval          `json:"approval,omitempty"`        // Only in approval events.
+	ChangesRequest *Approval          `json:"changes_request,omitempty"` // Only in changes request events.
+	Comment        *bitbucket.Comment `json:"comment,omitempty"`         // Only in comment events.
+}
+
+// RepoPushEvent is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+type RepoPushEvent struct {
+	Actor      bitbucket.User `json:"actor"`
+	Repository Repository     `json:"repository"`
+	Push       struct {
+		Changes []Change `json:"changes"`
+	} `json:"push"`
+}
+
+// Approval is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Approved
+type Approval struct {
+	Date time.Time      `json:"date"`
+	User bitbucket.User `json:"user"`
+}
+
+// Change is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+type Change struct {
+	New *Ref `json:"new,omitempty"` // Nil if the branch or tag was deleted.
+	Old *Ref `json:"old,omitempty"` // Nil if the branch or tag was created.
+
+	Created bool `json:"created"`
+	Closed  bool `json:"closed"`
+	Forced  bool `json:"forced"`
+
+	Commits   []bitbucket.Commit `json:"commits,omitempty"`
+	Truncated bool               `json:"truncated,omitempty"`
+
+	Links map[string]bitbucket.Link `json:"links,omitempty"`
+}
+
+// Endpoint is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request
+type Endpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Repository Repository `json:"repository"`
+}
+
+// Participant is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request
+type Participant struct {
+	User           bitbucket.User `json:"user"`
+	Role           string         `json:"role"` // "PARTICIPANT" or "REVIEWER".
+	Approved       bool           `json:"approved"`
+	State          string         `json:"state,omitempty"` // "approved", "changes_requested", or empty.
+	ParticipatedOn time.Time      `json:"participated_on,omitzero"`
+}
+
+// PullRequest is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request
+type PullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"` // "OPEN", "MERGED", "DECLINED", "SUPERSEDED".
+	Draft       bool   `json:"draft,omitempty"`
+
+	Author       bitbucket.User   `json:"author"`
+	Reviewers    []bitbucket.User `json:"reviewers,omitempty"`
+	Participants []Participant    `json:"participants,omitempty"`
+
+	Source            Endpoint `json:"source"`
+	Destination       Endpoint `json:"destination"`
+	CloseSourceBranch bool     `json:"close_source_branch"`
+	MergeCommit       *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit,omitempty"`
+
+	ClosedBy *bitbucket.User `json:"closed_by,omitempty"`
+	Reason   string          `json:"reason,omitempty"`
+
+	CommentCount int `json:"comment_count,omitempty"`
+	TaskCount    int `json:"task_count,omitempty"`
+
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on,omitzero"`
+
+	Links map[string]bitbucket.Link `json:"links"`
+}
+
+// Ref is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+type Ref struct {
+	Type   string           `json:"type"` // "branch", "named_branch", "bookmark", "tag".
+	Name   string           `json:"name"`
+	Target bitbucket.Commit `json:"target"`
+
+	Links map[string]bitbucket.Link `json:"links,omitempty"`
+}
+
+// Repository is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Repository
+type Repository struct {
+	Type      string `json:"type"` // Always "repository".
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	UUID      string `json:"uuid"`
+	IsPrivate bool   `json:"is_private"`
+
+	Owner     *bitbucket.User `json:"owner,omitempty"`
+	Workspace *Workspace      `json:"workspace,omitempty"`
+	Project   *Project        `json:"project,omitempty"`
+
+	Links map[string]bitbucket.Link `json:"links"`
+}
+
+// Project is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Repository
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+}
+
+// Workspace is based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Repository
+type Workspace struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+}
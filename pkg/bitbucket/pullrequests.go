@@ -1,11 +1,13 @@
 package bitbucket
 
 import (
+	"iter"
 	"time"
 
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -115,25 +117,20 @@ type PullRequestsDiffstatResponse = CommitsDiffstatResponse
 //
 // It retrieves the full list of diffstat entries by handling pagination internally.
 func PullRequestsDiffstat(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) ([]Diffstat, error) {
-	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
-	req := PullRequestsDiffstatRequest{PullRequestsRequest: pr, Next: "start"}
-
-	var ds []Diffstat
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[PullRequestsDiffstatResponse](ctx, PullRequestsDiffstatActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		ds = append(ds, resp.Values...)
-		req.Next = resp.Next
-	}
+	return pagination.Collect(PullRequestsDiffstatIter(ctx, thrippyLinkID, workspace, repo, prID), 0)
+}
 
-	return ds, nil
+// PullRequestsDiffstatIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-diffstat-get
+//
+// It returns an iterator over all the diffstat entries,
+// and handles pagination internally.
+func PullRequestsDiffstatIter(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) iter.Seq2[Diffstat, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
+	req := PullRequestsDiffstatRequest{PullRequestsRequest: pr}
+	setNext := func(r *PullRequestsDiffstatRequest, next string) { r.Next = next }
+	page := func(r *PullRequestsDiffstatResponse) ([]Diffstat, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, PullRequestsDiffstatActivityName, req, setNext, page)
 }
 
 // PullRequestsGetRequest is based on:
@@ -198,25 +195,20 @@ type PullRequestsListActivityLogResponse struct {
 //
 // It retrieves the full list of activity log entries by handling pagination internally.
 func PullRequestsListActivityLog(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) ([]map[string]any, error) {
-	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
-	req := PullRequestsListActivityLogRequest{PullRequestsRequest: pr, Next: "start"}
-
-	var activities []map[string]any
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[PullRequestsListActivityLogResponse](ctx, PullRequestsListActivityLogActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		activities = append(activities, resp.Values...)
-		req.Next = resp.Next
-	}
+	return pagination.Collect(PullRequestsListActivityLogIter(ctx, thrippyLinkID, workspace, repo, prID), 0)
+}
 
-	return activities, nil
+// PullRequestsListActivityLogIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-activity-get
+//
+// It returns an iterator over all the activity log entries,
+// and handles pagination internally.
+func PullRequestsListActivityLogIter(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) iter.Seq2[map[string]any, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
+	req := PullRequestsListActivityLogRequest{PullRequestsRequest: pr}
+	setNext := func(r *PullRequestsListActivityLogRequest, next string) { r.Next = next }
+	page := func(r *PullRequestsListActivityLogResponse) ([]map[string]any, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, PullRequestsListActivityLogActivityName, req, setNext, page)
 }
 
 // PullRequestsListCommitsRequest is based on:
@@ -247,25 +239,20 @@ type PullRequestsListCommitsResponse struct {
 //
 // It retrieves the full list of commits by handling pagination internally.
 func PullRequestsListCommits(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) ([]Commit, error) {
-	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
-	req := PullRequestsListCommitsRequest{PullRequestsRequest: pr, Next: "start"}
-
-	var cs []Commit
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[PullRequestsListCommitsResponse](ctx, PullRequestsListCommitsActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		cs = append(cs, resp.Values...)
-		req.Next = resp.Next
-	}
+	return pagination.Collect(PullRequestsListCommitsIter(ctx, thrippyLinkID, workspace, repo, prID), 0)
+}
 
-	return cs, nil
+// PullRequestsListCommitsIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-commits-get
+//
+// It returns an iterator over all the commits,
+// and handles pagination internally.
+func PullRequestsListCommitsIter(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) iter.Seq2[Commit, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
+	req := PullRequestsListCommitsRequest{PullRequestsRequest: pr}
+	setNext := func(r *PullRequestsListCommitsRequest, next string) { r.Next = next }
+	page := func(r *PullRequestsListCommitsResponse) ([]Commit, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, PullRequestsListCommitsActivityName, req, setNext, page)
 }
 
 // PullRequestsListForCommitRequest is based on:
@@ -300,24 +287,19 @@ type PullRequestsListForCommitResponse struct {
 //
 // It retrieves the full list of PRs by handling pagination internally.
 func PullRequestsListForCommit(ctx workflow.Context, thrippyLinkID, workspace, repo, commit string) ([]map[string]any, error) {
-	req := PullRequestsListForCommitRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, Commit: commit, Next: "start"}
-
-	var prs []map[string]any
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[PullRequestsListForCommitResponse](ctx, PullRequestsListForCommitActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		prs = append(prs, resp.Values...)
-		req.Next = resp.Next
-	}
+	return pagination.Collect(PullRequestsListForCommitIter(ctx, thrippyLinkID, workspace, repo, commit), 0)
+}
 
-	return prs, nil
+// PullRequestsListForCommitIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-commit-commit-pullrequests-get
+//
+// It returns an iterator over all the PRs,
+// and handles pagination internally.
+func PullRequestsListForCommitIter(ctx workflow.Context, thrippyLinkID, workspace, repo, commit string) iter.Seq2[map[string]any, error] {
+	req := PullRequestsListForCommitRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, Commit: commit}
+	setNext := func(r *PullRequestsListForCommitRequest, next string) { r.Next = next }
+	page := func(r *PullRequestsListForCommitResponse) ([]map[string]any, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, PullRequestsListForCommitActivityName, req, setNext, page)
 }
 
 // PullRequestsListTasksRequest is based on:
@@ -349,25 +331,20 @@ type PullRequestsListTasksResponse struct {
 //
 // It retrieves the full list of tasks by handling pagination internally.
 func PullRequestsListTasks(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) ([]Task, error) {
-	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
-	req := PullRequestsListTasksRequest{PullRequestsRequest: pr, Next: "start"}
-
-	var tasks []Task
-	for req.Next != "" {
-		if req.Next == "start" {
-			req.Next = ""
-		}
-
-		resp, err := internal.ExecuteTimpaniActivity[PullRequestsListTasksResponse](ctx, PullRequestsListTasksActivityName, req)
-		if err != nil {
-			return nil, err
-		}
-
-		tasks = append(tasks, resp.Values...)
-		req.Next = resp.Next
-	}
+	return pagination.Collect(PullRequestsListTasksIter(ctx, thrippyLinkID, workspace, repo, prID), 0)
+}
 
-	return tasks, nil
+// PullRequestsListTasksIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-get
+//
+// It returns an iterator over all the tasks,
+// and handles pagination internally.
+func PullRequestsListTasksIter(ctx workflow.Context, thrippyLinkID, workspace, repo, prID string) iter.Seq2[Task, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Workspace: workspace, RepoSlug: repo, PullRequestID: prID}
+	req := PullRequestsListTasksRequest{PullRequestsRequest: pr}
+	setNext := func(r *PullRequestsListTasksRequest, next string) { r.Next = next }
+	page := func(r *PullRequestsListTasksResponse) ([]Task, string) { return r.Values, r.Next }
+	return pagination.Activity(ctx, PullRequestsListTasksActivityName, req, setNext, page)
 }
 
 // PullRequestsMergeRequest is based on:
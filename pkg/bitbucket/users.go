@@ -15,6 +15,8 @@ const (
 //   - https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
 //   - https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-users-selected-user-get
 type UsersGetRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	AccountID string `json:"account_id,omitempty"`
 	UUID      string `json:"uuid,omitempty"`
 }
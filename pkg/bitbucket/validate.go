@@ -0,0 +1,154 @@
+package bitbucket
+
+import (
+	"strconv"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Client-side validation of Bitbucket requests, based on the "Request" section
+// of each endpoint in https://developer.atlassian.com/cloud/bitbucket/rest/.
+// Validation failures are non-retryable, and are reported before sending
+// requests to the Timpani worker, instead of by Bitbucket after several retries.
+
+// paginate checks the pagination fields which are common to multiple requests, based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/intro/#pagination
+func paginate(v *internal.Validator, pageLen, page string) *internal.Validator {
+	return v.Check(isPositive(pageLen), `field "pagelen" must be a positive integer`).
+		Check(isPositive(page), `field "page" must be a positive integer`)
+}
+
+// isPositive returns true if s is empty or a positive integer.
+func isPositive(s string) bool {
+	if s == "" {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r CommitsRequest) Validate() error {
+	return r.validate(internal.NewValidator(CommitsDiffActivityName)).Err()
+}
+
+func (r CommitsRequest) validate(v *internal.Validator) *internal.Validator {
+	return v.Require("workspace", r.Workspace).Require("repo_slug", r.RepoSlug).Require("spec", r.Spec)
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r CommitsDiffstatRequest) Validate() error {
+	v := r.validate(internal.NewValidator(CommitsDiffstatActivityName))
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+// This request type is shared by multiple activities.
+func (r PullRequestsRequest) Validate() error {
+	return r.validate(internal.NewValidator("")).Err()
+}
+
+func (r PullRequestsRequest) validate(v *internal.Validator) *internal.Validator {
+	return v.Require("workspace", r.Workspace).Require("repo_slug", r.RepoSlug).Require("pull_request_id", r.PullRequestID)
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsCreateCommentRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsCreateCommentActivityName)).
+		Require("text", r.Markdown).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsDeleteCommentRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsDeleteCommentActivityName)).
+		Require("comment_id", r.CommentID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsDiffstatRequest) Validate() error {
+	v := r.validate(internal.NewValidator(PullRequestsDiffstatActivityName))
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsGetCommentRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsGetCommentActivityName)).
+		Require("comment_id", r.CommentID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListActivityLogRequest) Validate() error {
+	v := r.validate(internal.NewValidator(PullRequestsListActivityLogActivityName))
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListCommitsRequest) Validate() error {
+	v := r.validate(internal.NewValidator(PullRequestsListCommitsActivityName))
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListForCommitRequest) Validate() error {
+	v := internal.NewValidator(PullRequestsListForCommitActivityName).
+		Require("workspace", r.Workspace).
+		Require("repo_slug", r.RepoSlug).
+		Require("commit", r.Commit)
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListTasksRequest) Validate() error {
+	v := r.validate(internal.NewValidator(PullRequestsListTasksActivityName))
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsMergeRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsMergeActivityName)).
+		Enum("merge_strategy", r.MergeStrategy, "merge_commit", "squash", "fast_forward",
+			"squash_fast_forward", "rebase_fast_forward", "rebase_merge").
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsUpdateRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsUpdateActivityName)).
+		Require("pullrequest", r.PullRequest).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsUpdateCommentRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsUpdateCommentActivityName)).
+		Require("comment_id", r.CommentID).
+		Require("text", r.Markdown).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r SourceGetRequest) Validate() error {
+	v := internal.NewValidator(SourceGetFileActivityName).
+		Require("workspace", r.Workspace).
+		Require("repo_slug", r.RepoSlug).
+		Require("commit", r.Commit)
+	return paginate(v, r.PageLen, r.Page).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersGetRequest) Validate() error {
+	return internal.NewValidator(UsersGetActivityName).
+		RequireAny(`"account_id" or "uuid"`, r.AccountID, r.UUID).
+		Exclusive(`"account_id" and "uuid"`, r.AccountID, r.UUID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r WorkspacesListMembersRequest) Validate() error {
+	return internal.NewValidator(WorkspacesListMembersActivityName).
+		Require("workspace", r.Workspace).
+		Err()
+}
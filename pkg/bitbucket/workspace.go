@@ -1,11 +1,11 @@
 package bitbucket
 
 import (
-	"errors"
+	"iter"
 
 	"go.temporal.io/sdk/workflow"
 
-	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -16,8 +16,12 @@ const (
 // WorkspacesListMembersRequest is based on:
 // https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
 type WorkspacesListMembersRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Workspace    string   `json:"workspace"`
 	EmailsFilter []string `json:"emails_filter"`
+
+	Next string `json:"next,omitempty"` // Populated and used only in Timpani, for pagination.
 }
 
 // WorkspacesListMembersResponse is based on:
@@ -34,22 +38,25 @@ type WorkspacesListMembersResponse struct {
 // WorkspacesListMembers is based on:
 // https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
 func WorkspacesListMembers(ctx workflow.Context, workspace string, emailsFilter []string) ([]User, error) {
-	req := WorkspacesListMembersRequest{Workspace: workspace, EmailsFilter: emailsFilter}
-	resp, err := internal.ExecuteTimpaniActivity[WorkspacesListMembersResponse](ctx, WorkspacesListMembersActivityName, req)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.Next != "" {
-		return nil, errors.New("pagination not implemented")
-	}
+	return pagination.Collect(WorkspacesListMembersIter(ctx, workspace, emailsFilter), 0)
+}
 
-	users := make([]User, len(resp.Values))
-	for i, membership := range resp.Values {
-		users[i] = membership.User
+// WorkspacesListMembersIter is based on:
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get
+//
+// It returns an iterator over all the workspace members,
+// and handles pagination internally.
+func WorkspacesListMembersIter(ctx workflow.Context, workspace string, emailsFilter []string) iter.Seq2[User, error] {
+	req := WorkspacesListMembersRequest{Workspace: workspace, EmailsFilter: emailsFilter}
+	setNext := func(r *WorkspacesListMembersRequest, next string) { r.Next = next }
+	page := func(r *WorkspacesListMembersResponse) ([]User, string) {
+		users := make([]User, len(r.Values))
+		for i, membership := range r.Values {
+			users[i] = membership.User
+		}
+		return users, r.Next
 	}
-
-	return users, nil
+	return pagination.Activity(ctx, WorkspacesListMembersActivityName, req, setNext, page)
 }
 
 // Membership is based on:
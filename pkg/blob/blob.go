@@ -0,0 +1,152 @@
+// Package blob offloads large Temporal payloads (e.g. file contents, diffs) to an
+// external store, so only small references travel through Temporal's event history.
+//
+// This is an implementation of the "claim check" pattern as a Temporal
+// [converter.PayloadCodec]: payloads which exceed a size threshold are replaced
+// with references when they are encoded, and restored when they are decoded.
+// It is transparent to all the wrapper functions in this module.
+//
+// Both the Temporal worker that uses Timpani and the Timpani worker itself must
+// use the same codec, with stores that share the same underlying storage:
+//
+//	store := blob.NewFileStore("/mnt/shared/blobs")
+//	c, err := client.Dial(client.Options{
+//		DataConverter: blob.NewDataConverter(store, blob.DefaultThreshold),
+//	})
+//
+// Blobs are content-addressed (by their SHA-256 hash), so identical payloads are
+// stored only once, and their integrity is verified when they are restored.
+// This package does not delete blobs, so stores should expire them (e.g.
+// with S3 lifecycle rules) after the retention period of the Temporal namespace.
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/proto"
+)
+
+// Encoding is the payload metadata encoding of blob references.
+const Encoding = "binary/timpani-blob"
+
+// DefaultThreshold is a reasonable size threshold for offloading payloads, well below
+// Temporal's default payload size limits (warning at 512 KiB, error at 2 MiB).
+const DefaultThreshold = 256 * 1024
+
+// ErrNotFound is returned by stores when a blob doesn't exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a pluggable storage for offloaded payloads. Implementations
+// must be safe for concurrent use. Keys are lowercase hex-encoded
+// SHA-256 hashes, so they are safe to use as file names and URL paths.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Ref is a reference to an offloaded payload, which replaces it in Temporal.
+type Ref struct {
+	Key  string `json:"key"`
+	Size int    `json:"size"`
+}
+
+type codec struct {
+	store     Store
+	threshold int
+	timeout   time.Duration
+}
+
+// NewCodec returns a Temporal payload codec which offloads payloads
+// that are larger than the threshold (in bytes) to the given store.
+func NewCodec(store Store, threshold int) converter.PayloadCodec {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &codec{store: store, threshold: threshold, timeout: 30 * time.Second}
+}
+
+// NewDataConverter returns Temporal's default data converter,
+// with a [NewCodec] codec for offloading large payloads.
+//
+// The codec accesses the store (with a timeout of 30 seconds per blob) when workflows
+// encode and decode payloads, so the converter is exempt from the Temporal SDK's deadlock
+// detection, which would otherwise fail workflow tasks that are blocked for over a second.
+func NewDataConverter(store Store, threshold int) converter.DataConverter {
+	dc := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), NewCodec(store, threshold))
+	return workflow.DataConverterWithoutDeadlockDetection(dc)
+}
+
+// Encode implements the [converter.PayloadCodec] interface.
+func (c *codec) Encode(payloads []*common.Payload) ([]*common.Payload, error) {
+	result := make([]*common.Payload, len(payloads))
+	for i, p := range payloads {
+		if proto.Size(p) <= c.threshold {
+			result[i] = p
+			continue
+		}
+
+		b, err := proto.Marshal(p)
+		if err != nil {
+			return payloads, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+
+		h := sha256.Sum256(b)
+		ref := Ref{Key: hex.EncodeToString(h[:]), Size: len(b)}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		err = c.store.Put(ctx, ref.Key, b)
+		cancel()
+		if err != nil {
+			return payloads, fmt.Errorf("failed to store blob %s: %w", ref.Key, err)
+		}
+
+		data, err := json.Marshal(ref)
+		if err != nil {
+			return payloads, fmt.Errorf("failed to marshal blob reference: %w", err)
+		}
+		result[i] = &common.Payload{Metadata: map[string][]byte{converter.MetadataEncoding: []byte(Encoding)}, Data: data}
+	}
+	return result, nil
+}
+
+// Decode implements the [converter.PayloadCodec] interface.
+func (c *codec) Decode(payloads []*common.Payload) ([]*common.Payload, error) {
+	result := make([]*common.Payload, len(payloads))
+	for i, p := range payloads {
+		if string(p.GetMetadata()[converter.MetadataEncoding]) != Encoding {
+			result[i] = p
+			continue
+		}
+
+		ref := Ref{}
+		if err := json.Unmarshal(p.GetData(), &ref); err != nil {
+			return payloads, fmt.Errorf("failed to unmarshal blob reference: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		b, err := c.store.Get(ctx, ref.Key)
+		cancel()
+		if err != nil {
+			return payloads, fmt.Errorf("failed to load blob %s: %w", ref.Key, err)
+		}
+
+		if h := sha256.Sum256(b); hex.EncodeToString(h[:]) != ref.Key {
+			return payloads, fmt.Errorf("blob %s is corrupted", ref.Key)
+		}
+
+		result[i] = &common.Payload{}
+		if err := proto.Unmarshal(b, result[i]); err != nil {
+			return payloads, fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+	}
+	return result, nil
+}
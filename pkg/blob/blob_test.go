@@ -0,0 +1,197 @@
+package blob_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/tzrikka/timpani-api/pkg/blob"
+)
+
+const threshold = 1024
+
+var errStore = errors.New("store error")
+
+// failingStore fails all the operations of a [blob.MemoryStore] after it's broken.
+type failingStore struct {
+	*blob.MemoryStore
+
+	broken bool
+}
+
+func (s *failingStore) Put(ctx context.Context, key string, data []byte) error {
+	if s.broken {
+		return errStore
+	}
+	return s.MemoryStore.Put(ctx, key, data)
+}
+
+func (s *failingStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s.broken {
+		return nil, errStore
+	}
+	return s.MemoryStore.Get(ctx, key)
+}
+
+func payload(t *testing.T, s string) *common.Payload {
+	t.Helper()
+
+	p, err := converter.GetDefaultDataConverter().ToPayload(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestCodec(t *testing.T) {
+	small, large := strings.Repeat("a", threshold/2), strings.Repeat("b", threshold*2)
+
+	tests := []struct {
+		name      string
+		payloads  []string
+		wantBlobs int
+		wantRefs  []bool
+	}{
+		{
+			name:     "below_threshold",
+			payloads: []string{small},
+			wantRefs: []bool{false},
+		},
+		{
+			name:      "above_threshold",
+			payloads:  []string{large},
+			wantBlobs: 1,
+			wantRefs:  []bool{true},
+		},
+		{
+			name:      "mixed_and_identical",
+			payloads:  []string{large, small, large},
+			wantBlobs: 1,
+			wantRefs:  []bool{true, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := blob.NewMemoryStore()
+			c := blob.NewCodec(store, threshold)
+
+			ps := make([]*common.Payload, len(tt.payloads))
+			for i, s := range tt.payloads {
+				ps[i] = payload(t, s)
+			}
+
+			encoded, err := c.Encode(ps)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			for i, p := range encoded {
+				isRef := string(p.GetMetadata()[converter.MetadataEncoding]) == blob.Encoding
+				if isRef != tt.wantRefs[i] {
+					t.Errorf("Encode()[%d] is a reference = %v, want %v", i, isRef, tt.wantRefs[i])
+				}
+				if isRef && proto.Size(p) > threshold {
+					t.Errorf("Encode()[%d] size = %d, want at most %d", i, proto.Size(p), threshold)
+				}
+			}
+			if n := store.Len(); n != tt.wantBlobs {
+				t.Errorf("store has %d blobs, want %d", n, tt.wantBlobs)
+			}
+
+			decoded, err := c.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			for i, p := range decoded {
+				if !proto.Equal(p, ps[i]) {
+					t.Errorf("Decode()[%d] = %v, want %v", i, p, ps[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	large := payload(t, strings.Repeat("c", threshold*2))
+
+	t.Run("put_error", func(t *testing.T) {
+		c := blob.NewCodec(&failingStore{MemoryStore: blob.NewMemoryStore(), broken: true}, threshold)
+		if _, err := c.Encode([]*common.Payload{large}); !errors.Is(err, errStore) {
+			t.Errorf("Encode() error = %v, want %v", err, errStore)
+		}
+	})
+
+	t.Run("get_error", func(t *testing.T) {
+		store := &failingStore{MemoryStore: blob.NewMemoryStore()}
+		c := blob.NewCodec(store, threshold)
+		encoded, err := c.Encode([]*common.Payload{large})
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		store.broken = true
+		if _, err := c.Decode(encoded); !errors.Is(err, errStore) {
+			t.Errorf("Decode() error = %v, want %v", err, errStore)
+		}
+	})
+
+	t.Run("missing_blob", func(t *testing.T) {
+		encoded, err := blob.NewCodec(blob.NewMemoryStore(), threshold).Encode([]*common.Payload{large})
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		c := blob.NewCodec(blob.NewMemoryStore(), threshold)
+		if _, err := c.Decode(encoded); !errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("Decode() error = %v, want %v", err, blob.ErrNotFound)
+		}
+	})
+
+	t.Run("corrupted_blob", func(t *testing.T) {
+		store := blob.NewMemoryStore()
+		c := blob.NewCodec(store, threshold)
+		encoded, err := c.Encode([]*common.Payload{large})
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		var ref blob.Ref
+		if err := json.Unmarshal(encoded[0].GetData(), &ref); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Put(t.Context(), ref.Key, []byte("corrupted")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Decode(encoded); err == nil || !strings.Contains(err.Error(), "corrupted") {
+			t.Errorf("Decode() error = %v, want a corrupted blob", err)
+		}
+	})
+}
+
+func TestDataConverter(t *testing.T) {
+	store := blob.NewMemoryStore()
+	dc := blob.NewDataConverter(store, threshold)
+
+	want := strings.Repeat("d", threshold*2)
+	p, err := dc.ToPayload(want)
+	if err != nil {
+		t.Fatalf("ToPayload() error = %v", err)
+	}
+	if store.Len() != 1 {
+		t.Errorf("store has %d blobs, want 1", store.Len())
+	}
+
+	var got string
+	if err := dc.FromPayload(p, &got); err != nil {
+		t.Fatalf("FromPayload() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("FromPayload() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileStore stores blobs as files in a local directory (which
+// may be a mount of a shared network filesystem).
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a [FileStore] which stores blobs in the given directory.
+// The directory is created when the first blob is stored, if it doesn't exist.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Put implements the [Store] interface.
+func (s *FileStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.Base(key))
+	if _, err := os.Stat(path); err == nil {
+		return nil // Blobs are content-addressed, so it already has the same data.
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	// Write atomically, to avoid partial reads by concurrent readers.
+	f, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write blob file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename blob file: %w", err)
+	}
+	return nil
+}
+
+// Get implements the [Store] interface.
+func (s *FileStore) Get(_ context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, filepath.Base(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob file: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HTTPStore stores blobs as objects in an S3-compatible HTTP service, with
+// "PUT" and "GET" requests to "<base URL>/<key>" (e.g. a path-style bucket
+// URL in MinIO, or an S3 bucket behind a signing proxy).
+//
+// Authentication, if needed, is the responsibility of the HTTP client
+// (e.g. a custom [http.RoundTripper] that signs requests).
+type HTTPStore struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPStore returns an [HTTPStore] with the given base URL and HTTP
+// client. If the client is nil, it uses [http.DefaultClient].
+func NewHTTPStore(baseURL string, client *http.Client) *HTTPStore {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPStore{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+// Put implements the [Store] interface.
+func (s *HTTPStore) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.baseURL+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected HTTP response status: %s", resp.Status)
+	}
+	return nil
+}
+
+// Get implements the [Store] interface.
+func (s *HTTPStore) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/"+key, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected HTTP response status: %s", resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HTTP response body: %w", err)
+	}
+	return b, nil
+}
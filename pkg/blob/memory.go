@@ -0,0 +1,45 @@
+package blob
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryStore stores blobs in memory. It is useful only for
+// tests, and when the Timpani worker runs in the same process.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryStore returns an empty [MemoryStore].
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+// Put implements the [Store] interface.
+func (s *MemoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = slices.Clone(data)
+	return nil
+}
+
+// Get implements the [Store] interface.
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return slices.Clone(b), nil
+}
+
+// Len returns the number of stored blobs.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.blobs)
+}
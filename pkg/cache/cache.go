@@ -0,0 +1,159 @@
+// Package cache provides an opt-in memoizing cache for read-only Timpani
+// activities (e.g. user lookups), which is bound to a workflow context.
+//
+// Identical requests (i.e. same activity name and request) which are executed
+// with the same cached context share a single activity execution, instead of
+// costing an activity round-trip and history events each time:
+//
+//	ctx = cache.Enable(ctx, cache.Options{TTL: time.Hour})
+//	u1, err := slack.UsersInfo(ctx, "U123") // Executes the activity.
+//	u2, err := slack.UsersInfo(ctx, "U123") // Returns the same result.
+//
+// The cache is kept only in the workflow's memory, and its expiration is based on
+// workflow time, so it is deterministic, and therefore safe for replays. Failed
+// executions are not cached. Mutating activities are never cached (see
+// [registry.IsMutating]), and they do not invalidate cached results.
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/registry"
+)
+
+// DefaultMaxEntries is the default size bound of workflow caches.
+const DefaultMaxEntries = 1000
+
+// Options configure a workflow cache. Zero values are replaced with reasonable defaults.
+type Options struct {
+	// TTL is the maximum age of cached results, in workflow time. 0 = no expiration.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached results. When it is
+	// exceeded, the least recently used results are evicted. 0 = [DefaultMaxEntries].
+	MaxEntries int
+	// Activities limits the cache to specific read-only activities,
+	// by their names (e.g. [slack.UsersInfoActivityName]). Empty = all.
+	//
+	// [slack.UsersInfoActivityName]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/slack#UsersInfoActivityName
+	Activities []string
+}
+
+// Stats are the usage statistics of a workflow cache.
+type Stats struct {
+	Hits      int // Requests which shared an earlier activity execution.
+	Misses    int // Requests which were executed.
+	Evictions int // Results which were evicted due to the size bound.
+	Entries   int // Current number of cached results.
+}
+
+type cache struct {
+	opts    Options
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   Stats
+}
+
+type entry struct {
+	key     string
+	future  workflow.Future
+	expires time.Time
+}
+
+type cacheKey struct{}
+
+// Enable returns a copy of the workflow context, with a new and empty cache
+// for all the read-only Timpani activities that are executed with it.
+func Enable(ctx workflow.Context, opts Options) workflow.Context {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+
+	c := &cache{opts: opts, entries: map[string]*list.Element{}, lru: list.New()}
+	ctx = workflow.WithValue(ctx, cacheKey{}, c)
+	return workflow.WithValue(ctx, internal.CacheKey{}, internal.CacheFunc(c.get))
+}
+
+// Disable returns a copy of the workflow context without a cache.
+func Disable(ctx workflow.Context) workflow.Context {
+	ctx = workflow.WithValue(ctx, cacheKey{}, nil)
+	return workflow.WithValue(ctx, internal.CacheKey{}, nil)
+}
+
+// GetStats returns the usage statistics of the cache in the workflow context,
+// or zero values if the workflow context doesn't have a cache.
+func GetStats(ctx workflow.Context) Stats {
+	c, ok := ctx.Value(cacheKey{}).(*cache)
+	if !ok {
+		return Stats{}
+	}
+
+	s := c.stats
+	s.Entries = c.lru.Len()
+	return s
+}
+
+// Clear removes all the cached results from the cache in the workflow
+// context (if there is one), e.g. after a mutating activity.
+func Clear(ctx workflow.Context) {
+	if c, ok := ctx.Value(cacheKey{}).(*cache); ok {
+		clear(c.entries)
+		c.lru.Init()
+	}
+}
+
+func (c *cache) get(ctx workflow.Context, name string, req any, execute func() workflow.Future) workflow.Future {
+	if registry.IsMutating(name) || (len(c.opts.Activities) > 0 && !slices.Contains(c.opts.Activities, name)) {
+		return execute()
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return execute()
+	}
+	key := name + " " + string(b)
+
+	now := workflow.Now(ctx)
+	if el, ok := c.entries[key]; ok {
+		if e, _ := el.Value.(*entry); c.valid(ctx, e, now) {
+			c.stats.Hits++
+			c.lru.MoveToFront(el)
+			return e.future
+		}
+		c.remove(el)
+	}
+
+	c.stats.Misses++
+	e := &entry{key: key, future: execute()}
+	if c.opts.TTL > 0 {
+		e.expires = now.Add(c.opts.TTL)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+
+	for c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+
+	return e.future
+}
+
+// valid reports whether a cached entry has not expired, and has not failed.
+// Pending entries are valid, so concurrent identical requests share them.
+func (c *cache) valid(ctx workflow.Context, e *entry, now time.Time) bool {
+	if !e.expires.IsZero() && !now.Before(e.expires) {
+		return false
+	}
+	return !e.future.IsReady() || e.future.Get(ctx, nil) == nil
+}
+
+func (c *cache) remove(el *list.Element) {
+	e, _ := el.Value.(*entry)
+	delete(c.entries, e.key)
+	c.lru.Remove(el)
+}
@@ -0,0 +1,198 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+	"github.com/tzrikka/timpani-api/pkg/cache"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+func usersInfo(ids ...string) func(workflow.Context) error {
+	return func(ctx workflow.Context) error {
+		for _, id := range ids {
+			if _, err := slack.UsersInfo(ctx, id); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func sleep(d time.Duration) func(workflow.Context) error {
+	return func(ctx workflow.Context) error {
+		return workflow.Sleep(ctx, d)
+	}
+}
+
+func TestCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      cache.Options
+		steps     []func(workflow.Context) error
+		wantCalls int
+		want      cache.Stats
+	}{
+		{
+			name:      "identical_requests",
+			steps:     []func(workflow.Context) error{usersInfo("U1", "U1", "U1")},
+			wantCalls: 1,
+			want:      cache.Stats{Hits: 2, Misses: 1, Entries: 1},
+		},
+		{
+			name:      "different_requests",
+			steps:     []func(workflow.Context) error{usersInfo("U1", "U2", "U1")},
+			wantCalls: 2,
+			want:      cache.Stats{Hits: 1, Misses: 2, Entries: 2},
+		},
+		{
+			name: "concurrent_identical_requests",
+			steps: []func(workflow.Context) error{
+				func(ctx workflow.Context) error {
+					f1 := slack.UsersInfoAsync(ctx, "U1")
+					f2 := slack.UsersInfoAsync(ctx, "U1")
+					if _, err := f1.Get(ctx); err != nil {
+						return err
+					}
+					_, err := f2.Get(ctx)
+					return err
+				},
+			},
+			wantCalls: 1,
+			want:      cache.Stats{Hits: 1, Misses: 1, Entries: 1},
+		},
+		{
+			name:      "within_ttl",
+			opts:      cache.Options{TTL: time.Hour},
+			steps:     []func(workflow.Context) error{usersInfo("U1"), sleep(time.Minute), usersInfo("U1")},
+			wantCalls: 1,
+			want:      cache.Stats{Hits: 1, Misses: 1, Entries: 1},
+		},
+		{
+			name:      "expired_ttl",
+			opts:      cache.Options{TTL: time.Hour},
+			steps:     []func(workflow.Context) error{usersInfo("U1"), sleep(time.Hour), usersInfo("U1")},
+			wantCalls: 2,
+			want:      cache.Stats{Misses: 2, Entries: 1},
+		},
+		{
+			name:      "lru_eviction",
+			opts:      cache.Options{MaxEntries: 2},
+			steps:     []func(workflow.Context) error{usersInfo("U1", "U2", "U1", "U3", "U1", "U2")},
+			wantCalls: 4,
+			want:      cache.Stats{Hits: 2, Misses: 4, Evictions: 2, Entries: 2},
+		},
+		{
+			name: "activities_filter",
+			opts: cache.Options{Activities: []string{slack.UsersLookupByEmailActivityName}},
+			steps: []func(workflow.Context) error{
+				usersInfo("U1", "U1"),
+				func(ctx workflow.Context) error {
+					_, _ = slack.UsersLookupByEmail(ctx, "u1@example.com")
+					_, _ = slack.UsersLookupByEmail(ctx, "u1@example.com")
+					return nil
+				},
+			},
+			wantCalls: 3,
+			want:      cache.Stats{Hits: 1, Misses: 1, Entries: 1},
+		},
+		{
+			name:      "failures_are_not_cached",
+			steps:     []func(workflow.Context) error{usersInfo("U0", "U0")},
+			wantCalls: 2,
+			want:      cache.Stats{Misses: 2, Entries: 1},
+		},
+		{
+			name: "mutating_activities_are_not_cached",
+			steps: []func(workflow.Context) error{
+				func(ctx workflow.Context) error {
+					req := slack.ChatPostMessageRequest{Channel: "C1", Text: "hi"}
+					fs := []async.Future[*slack.ChatPostMessageResponse]{
+						slack.ChatPostMessageAsync(ctx, req),
+						slack.ChatPostMessageAsync(ctx, req),
+					}
+					for _, f := range fs {
+						if _, err := f.Get(ctx); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "clear",
+			steps: []func(workflow.Context) error{
+				usersInfo("U1"),
+				func(ctx workflow.Context) error { cache.Clear(ctx); return nil },
+				usersInfo("U1"),
+			},
+			wantCalls: 2,
+			want:      cache.Stats{Misses: 2, Entries: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			w.AddUser(slack.User{ID: "U1", Profile: slack.Profile{Email: "u1@example.com"}})
+			w.AddUser(slack.User{ID: "U2"})
+			w.AddUser(slack.User{ID: "U3"})
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			var got cache.Stats
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				ctx = cache.Enable(ctx, tt.opts)
+				for _, step := range tt.steps {
+					if err := step(ctx); err != nil {
+						return err
+					}
+				}
+				got = cache.GetStats(ctx)
+				return nil
+			})
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			if n := len(w.Calls("")); n != tt.wantCalls {
+				t.Errorf("activity calls = %d, want %d", n, tt.wantCalls)
+			}
+			if got != tt.want {
+				t.Errorf("GetStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisable(t *testing.T) {
+	w := timpanitest.New()
+	w.AddUser(slack.User{ID: "U1"})
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	w.Register(env)
+
+	var got cache.Stats
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		ctx = cache.Disable(cache.Enable(ctx, cache.Options{}))
+		if err := usersInfo("U1", "U1")(ctx); err != nil {
+			return err
+		}
+		got = cache.GetStats(ctx)
+		return nil
+	})
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+
+	w.AssertCalled(t, slack.UsersInfoActivityName, 2)
+	if got != (cache.Stats{}) {
+		t.Errorf("GetStats() = %+v, want zero values", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Package dryrun provides a "shadow mode" for Temporal workflows that use Timpani.
+//
+// In dry-run mode, which is enabled per workflow context, Timpani activities and
+// child workflows that change the state of third-party services (e.g. posting Slack
+// messages, merging GitHub PRs, declining Bitbucket PRs) are not executed. Instead,
+// they return synthetic responses, with placeholder identifiers (e.g. the timestamps
+// of Slack messages) that subsequent calls can use, and are recorded in a "would have
+// done" report. Read-only activities are still executed by the Timpani worker as usual.
+//
+// This is useful for rolling out new automations, by running them
+// against production events without affecting anything:
+//
+//	ctx = dryrun.Enable(ctx)
+//	// ...
+//	for _, r := range dryrun.Report(ctx) {
+//		// ...
+//	}
+//
+// Whether an activity or workflow is mutating is determined by [registry.IsMutating].
+package dryrun
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/registry"
+)
+
+// Record describes a single mutating Timpani activity or
+// child workflow which was not executed in dry-run mode.
+type Record struct {
+	Name    string    // Name of the Timpani activity or workflow, e.g. "slack.chat.postMessage".
+	Service string    // Name of the third-party service, e.g. "slack".
+	Request any       // The request that would have been sent.
+	Time    time.Time // Workflow time of the call.
+}
+
+// state is the dry-run state of a workflow context, and all the contexts derived from it.
+type state struct {
+	records      []Record
+	placeholders int // Number of synthetic responses with placeholder identifiers.
+}
+
+type stateKey struct{}
+
+// Enable returns a copy of the workflow context, in which mutating Timpani
+// activities and child workflows return synthetic responses instead of being
+// executed. Every such call is recorded (see [Report]) and logged.
+// Enabling dry-run mode in a context where it's already enabled has no effect.
+func Enable(ctx workflow.Context) workflow.Context {
+	if Enabled(ctx) {
+		return ctx
+	}
+
+	s := &state{}
+	ctx = workflow.WithValue(ctx, stateKey{}, s)
+	return workflow.WithValue(ctx, internal.DryRunKey{}, internal.DryRunFunc(s.intercept))
+}
+
+// Enabled reports whether dry-run mode is enabled in the workflow context.
+func Enabled(ctx workflow.Context) bool {
+	_, ok := ctx.Value(stateKey{}).(*state)
+	return ok
+}
+
+// Report returns the records of all the mutating Timpani activities and child
+// workflows which were not executed in the workflow context, in call order.
+// It returns nil if dry-run mode isn't enabled in the workflow context.
+func Report(ctx workflow.Context) []Record {
+	s, ok := ctx.Value(stateKey{}).(*state)
+	if !ok {
+		return nil
+	}
+	return append([]Record(nil), s.records...)
+}
+
+// intercept records and logs mutating calls, and returns synthetic responses for
+// them, based on their response types in the [registry] (see [state.synthetic]).
+func (s *state) intercept(ctx workflow.Context, name string, req any) (any, bool) {
+	if !registry.IsMutating(name) {
+		return nil, false
+	}
+
+	e, _ := registry.Lookup(name)
+	service, _, _ := strings.Cut(name, ".")
+	r := Record{Name: name, Service: service, Request: req, Time: workflow.Now(ctx)}
+	s.records = append(s.records, r)
+
+	workflow.GetLogger(ctx).Info("dry run: would have executed Timpani "+e.Kind.String(),
+		"name", name, "service", r.Service, "request", req)
+
+	return s.synthetic(ctx, e, req), true
+}
+
+// Names of identifier fields in response types, which are set in synthetic
+// responses, so that workflows can use them in subsequent calls (e.g. update
+// a Slack message that they posted), without failing client-side validation.
+var (
+	tsFields = []string{"TS", "MessageTS"}
+	idFields = []string{"ID", "FileID", "NodeID", "ScheduledMessageID"}
+)
+
+// synthetic returns a new zero value of a registry entry's response type (or nil). If the
+// response has an "OK" boolean field (e.g. all Slack responses), it is set to true. String
+// fields that also exist in the request (e.g. a Slack channel ID) are copied from it, and
+// identifier fields (see [idFields] and [tsFields]) are set to deterministic placeholders,
+// which are unique in the workflow context: Slack timestamps based on the workflow time,
+// and "DRYRUN<n>" strings or <n> numbers. This applies to the fields of the response
+// and of the structs that it points to directly (e.g. the message in a Slack response).
+func (s *state) synthetic(ctx workflow.Context, e registry.Entry, req any) any {
+	resp := e.NewResponse()
+	if resp == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(resp).Elem()
+	if v.Kind() != reflect.Struct {
+		return resp
+	}
+
+	if ok := v.FieldByName("OK"); ok.IsValid() && ok.Kind() == reflect.Bool && ok.CanSet() {
+		ok.SetBool(true)
+	}
+
+	s.placeholders++
+	p := placeholders{
+		ts:  fmt.Sprintf("%d.%06d", workflow.Now(ctx).Unix(), s.placeholders),
+		id:  fmt.Sprintf("DRYRUN%d", s.placeholders),
+		n:   int64(s.placeholders),
+		req: reflect.Indirect(reflect.ValueOf(req)),
+	}
+	p.fill(v, true)
+
+	return resp
+}
+
+// placeholders are the values of the identifier fields in a single synthetic response.
+type placeholders struct {
+	ts  string
+	id  string
+	n   int64
+	req reflect.Value // Copied only to top-level string fields.
+}
+
+// fill sets the identifier fields of a struct, and reports whether it set any of them.
+func (p placeholders) fill(v reflect.Value, top bool) bool {
+	filled := false
+	for i := range v.NumField() {
+		f, sf := v.Field(i), v.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		switch {
+		case sf.Anonymous && f.Kind() == reflect.Struct:
+			filled = p.fill(f, top) || filled
+		case f.Kind() == reflect.String && top && p.copy(f, sf.Name):
+			filled = true
+		case f.Kind() == reflect.String && slices.Contains(tsFields, sf.Name):
+			f.SetString(p.ts)
+			filled = true
+		case f.Kind() == reflect.String && slices.Contains(idFields, sf.Name):
+			f.SetString(p.id)
+			filled = true
+		case f.CanInt() && sf.Name == "ID":
+			f.SetInt(p.n)
+			filled = true
+		case f.Kind() == reflect.Pointer && f.IsNil() && f.Type().Elem().Kind() == reflect.Struct && top:
+			if n := reflect.New(f.Type().Elem()); p.fill(n.Elem(), false) {
+				f.Set(n)
+				filled = true
+			}
+		}
+	}
+	return filled
+}
+
+// copy sets a string field to the value of the request's string field with the same name,
+// if there is one, and it isn't empty. It reports whether the field was set.
+func (p placeholders) copy(f reflect.Value, name string) bool {
+	if p.req.Kind() != reflect.Struct {
+		return false
+	}
+
+	r := p.req.FieldByName(name)
+	if !r.IsValid() || r.Kind() != reflect.String || r.String() == "" {
+		return false
+	}
+
+	f.SetString(r.String())
+	return true
+}
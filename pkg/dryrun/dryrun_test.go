@@ -0,0 +1,160 @@
+package dryrun_test
+
+import (
+	"regexp"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/dryrun"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const channelID = "C123"
+
+type result struct {
+	Enabled     bool
+	PostOK      bool
+	PostChannel string
+	PostTS      string
+	ChannelID   string
+	History     int
+	Records     []string
+	Requests    []string // Channel IDs (or names) in the recorded requests.
+}
+
+// tsPattern matches Slack message timestamps.
+var tsPattern = regexp.MustCompile(`^\d+\.\d{6}$`)
+
+// postWorkflow posts a message, reacts to it and updates it, creates a channel, and reads
+// the first channel's history, optionally in dry-run mode. It returns a summary of the results.
+func postWorkflow(ctx workflow.Context, dryRun bool) (*result, error) {
+	if dryRun {
+		ctx = dryrun.Enable(dryrun.Enable(ctx))
+	}
+
+	resp, err := slack.ChatPostMessage(ctx, slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"})
+	if err != nil {
+		return nil, err
+	}
+	if err := slack.ReactionsAdd(ctx, resp.Channel, resp.TS, "eyes"); err != nil {
+		return nil, err
+	}
+	if err := slack.ChatUpdate(ctx, slack.ChatUpdateRequest{Channel: resp.Channel, TS: resp.TS, Text: "bye"}); err != nil {
+		return nil, err
+	}
+	id, err := slack.ConversationsCreate(ctx, "dry-run", false)
+	if err != nil {
+		return nil, err
+	}
+	history, err := slack.ConversationsHistory(ctx, channelID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &result{
+		Enabled: dryrun.Enabled(ctx), PostOK: resp.OK, PostChannel: resp.Channel, PostTS: resp.TS,
+		ChannelID: id, History: len(history),
+	}
+	for _, rec := range dryrun.Report(ctx) {
+		r.Records = append(r.Records, rec.Service+" "+rec.Name)
+		switch req := rec.Request.(type) {
+		case slack.ChatPostMessageRequest:
+			r.Requests = append(r.Requests, req.Channel)
+		case slack.ReactionsAddRequest:
+			r.Requests = append(r.Requests, req.Channel)
+		case slack.ChatUpdateRequest:
+			r.Requests = append(r.Requests, req.Channel)
+		case slack.ConversationsCreateRequest:
+			r.Requests = append(r.Requests, req.Name)
+		}
+	}
+	return r, nil
+}
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		dryRun       bool
+		wantHistory  int
+		wantRecords  []string
+		wantRequests []string
+	}{
+		{
+			name:        "disabled",
+			wantHistory: 1,
+		},
+		{
+			name:   "enabled",
+			dryRun: true,
+			wantRecords: []string{
+				"slack " + slack.ChatPostMessageActivityName,
+				"slack " + slack.ReactionsAddActivityName,
+				"slack " + slack.ChatUpdateActivityName,
+				"slack " + slack.ConversationsCreateActivityName,
+			},
+			wantRequests: []string{channelID, channelID, channelID, "dry-run"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			env.ExecuteWorkflow(postWorkflow, tt.dryRun)
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			var got result
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatalf("failed to get workflow result: %v", err)
+			}
+
+			if got.Enabled != tt.dryRun {
+				t.Errorf("Enabled() = %v, want %v", got.Enabled, tt.dryRun)
+			}
+			if !got.PostOK {
+				t.Error("chat.postMessage response OK = false, want true")
+			}
+			if got.PostChannel != channelID {
+				t.Errorf("chat.postMessage response channel = %q, want %q", got.PostChannel, channelID)
+			}
+			if !tsPattern.MatchString(got.PostTS) {
+				t.Errorf("chat.postMessage response TS = %q, want a message timestamp", got.PostTS)
+			}
+			if got.History != tt.wantHistory {
+				t.Errorf("conversations.history = %d messages, want %d", got.History, tt.wantHistory)
+			}
+			if len(got.Records) != len(tt.wantRecords) {
+				t.Fatalf("Report() = %q, want %q", got.Records, tt.wantRecords)
+			}
+			for i, want := range tt.wantRecords {
+				if got.Records[i] != want {
+					t.Errorf("Report()[%d] = %q, want %q", i, got.Records[i], want)
+				}
+				if got.Requests[i] != tt.wantRequests[i] {
+					t.Errorf("Report()[%d] request channel = %q, want %q", i, got.Requests[i], tt.wantRequests[i])
+				}
+			}
+
+			w.AssertCalled(t, slack.ConversationsHistoryActivityName, 1)
+			if tt.dryRun {
+				w.AssertNotCalled(t, slack.ChatPostMessageActivityName)
+				w.AssertNotCalled(t, slack.ReactionsAddActivityName)
+				w.AssertNotCalled(t, slack.ChatUpdateActivityName)
+				w.AssertNotCalled(t, slack.ConversationsCreateActivityName)
+				if got.ChannelID != "DRYRUN4" {
+					t.Errorf("conversations.create response channel ID = %q, want a placeholder", got.ChannelID)
+				}
+			} else {
+				w.AssertCalled(t, slack.ChatPostMessageActivityName, 1)
+				w.AssertCalled(t, slack.ReactionsAddActivityName, 1)
+				w.AssertCalled(t, slack.ChatUpdateActivityName, 1)
+			}
+		})
+	}
+}
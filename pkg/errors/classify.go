@@ -0,0 +1,207 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// Details is the optional structured payload of Temporal application errors which
+// are returned by the Timpani worker. Its JSON keys are compatible with Slack's
+// error responses, so they may be decoded from those as well.
+type Details struct {
+	Code       string `json:"error,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Needed     string `json:"needed,omitempty"`
+	Provided   string `json:"provided,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"` // Seconds.
+}
+
+// slackCodes maps well-known Slack API error codes to error types, based on:
+// https://docs.slack.dev/reference/methods (see the "Errors" section of each method).
+var slackCodes = map[string]string{
+	"bookmark_not_found": TypeNotFound,
+	"bot_not_found":      TypeNotFound,
+	"channel_not_found":  TypeNotFound,
+	"file_not_found":     TypeNotFound,
+	"message_not_found":  TypeNotFound,
+	"no_item_specified":  TypeNotFound,
+	"no_reaction":        TypeNotFound,
+	"no_such_subteam":    TypeNotFound,
+	"thread_not_found":   TypeNotFound,
+	"user_not_found":     TypeNotFound,
+	"users_not_found":    TypeNotFound,
+
+	"access_denied":          TypePermissionDenied,
+	"cant_delete_message":    TypePermissionDenied,
+	"cant_update_message":    TypePermissionDenied,
+	"ekm_access_denied":      TypePermissionDenied,
+	"missing_scope":          TypePermissionDenied,
+	"no_permission":          TypePermissionDenied,
+	"not_allowed_token_type": TypePermissionDenied,
+	"not_in_channel":         TypePermissionDenied,
+	"restricted_action":      TypePermissionDenied,
+
+	"rate_limited": TypeRateLimited,
+	"ratelimited":  TypeRateLimited,
+
+	"already_archived":   TypeConflict,
+	"already_in_channel": TypeConflict,
+	"already_pinned":     TypeConflict,
+	"already_reacted":    TypeConflict,
+	"edit_window_closed": TypeConflict,
+	"is_archived":        TypeConflict,
+	"name_taken":         TypeConflict,
+
+	"invalid_arguments":       TypeValidationFailed,
+	"invalid_blocks":          TypeValidationFailed,
+	"invalid_blocks_format":   TypeValidationFailed,
+	"invalid_cursor":          TypeValidationFailed,
+	"invalid_metadata_format": TypeValidationFailed,
+	"invalid_name":            TypeValidationFailed,
+	"invalid_ts_latest":       TypeValidationFailed,
+	"invalid_ts_oldest":       TypeValidationFailed,
+	"metadata_too_large":      TypeValidationFailed,
+	"msg_too_long":            TypeValidationFailed,
+	"no_text":                 TypeValidationFailed,
+	"too_many_attachments":    TypeValidationFailed,
+	"too_many_users":          TypeValidationFailed,
+
+	"account_inactive":   TypeAuthRevoked,
+	"invalid_auth":       TypeAuthRevoked,
+	"not_authed":         TypeAuthRevoked,
+	"org_login_required": TypeAuthRevoked,
+	"token_expired":      TypeAuthRevoked,
+	"token_revoked":      TypeAuthRevoked,
+}
+
+// activityNotRegistered is the type of application errors which Temporal
+// workers return when they are requested to execute an unknown activity.
+const activityNotRegistered = "ActivityNotRegisteredError"
+
+var (
+	slackCodePattern  = regexp.MustCompile(`\b[a-z]+(?:_[a-z]+)+\b`)
+	statusCodePattern = regexp.MustCompile(`(?i)\b(?:http|status)(?: code)?[ :=]*([45]\d\d)\b`)
+)
+
+// Classify converts an error which was returned by a Timpani activity into one of
+// the typed errors in this package, which wraps the original error. If the error
+// does not contain a Temporal application error, or it cannot be classified,
+// Classify returns it as-is.
+//
+// Classification is based on the type of the application error, or on its
+// [Details] if the Timpani worker attached them to it, or on well-known Slack
+// error codes and HTTP status codes which are mentioned in the error message.
+func Classify(activity string, err error) error {
+	if err == nil || isTyped(err) {
+		return err
+	}
+
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+
+	d := Details{}
+	if appErr.HasDetails() {
+		_ = appErr.Details(&d)
+	}
+
+	msg := appErr.Message()
+	if d.Code == "" {
+		if _, ok := slackCodes[appErr.Type()]; ok {
+			d.Code = appErr.Type()
+		} else {
+			for _, code := range slackCodePattern.FindAllString(msg, -1) {
+				if _, ok := slackCodes[code]; ok {
+					d.Code = code
+					break
+				}
+			}
+		}
+	}
+	if d.StatusCode == 0 {
+		if m := statusCodePattern.FindStringSubmatch(msg); m != nil {
+			d.StatusCode, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	retryAfter := time.Duration(d.RetryAfter) * time.Second
+	if retryAfter == 0 {
+		retryAfter = appErr.NextRetryDelay()
+	}
+
+	info := Info{Activity: activity, Code: d.Code, StatusCode: d.StatusCode, Message: msg, err: err}
+	if appErr.Type() == activityNotRegistered {
+		return &UnsupportedError{Info: info, Missing: []string{activity}}
+	}
+
+	switch errorType(appErr.Type(), d, msg, retryAfter) {
+	case TypeNotFound:
+		return &NotFoundError{Info: info}
+	case TypePermissionDenied:
+		return &PermissionDeniedError{Info: info, Needed: splitScopes(d.Needed), Provided: splitScopes(d.Provided)}
+	case TypeRateLimited:
+		return &RateLimitedError{Info: info, RetryAfter: retryAfter}
+	case TypeConflict:
+		return &ConflictError{Info: info}
+	case TypeValidationFailed:
+		return &ValidationFailedError{Info: info}
+	case TypeAuthRevoked:
+		return &AuthRevokedError{Info: info}
+	default:
+		return err
+	}
+}
+
+// errorType determines the type of a Temporal application error. The
+// application error's own type takes precedence over Slack error codes,
+// which take precedence over HTTP status codes.
+func errorType(appErrType string, d Details, msg string, retryAfter time.Duration) string {
+	switch appErrType {
+	case TypeNotFound, TypePermissionDenied, TypeRateLimited, TypeConflict, TypeValidationFailed, TypeAuthRevoked:
+		return appErrType
+	}
+
+	if t, ok := slackCodes[d.Code]; ok {
+		return t
+	}
+
+	switch d.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return TypeNotFound
+	case http.StatusUnauthorized:
+		return TypeAuthRevoked
+	case http.StatusForbidden:
+		// GitHub's secondary rate limits: https://docs.github.com/en/rest/using-the-rest-api/troubleshooting-the-rest-api#rate-limit-errors
+		if retryAfter > 0 || strings.Contains(strings.ToLower(msg), "rate limit") {
+			return TypeRateLimited
+		}
+		return TypePermissionDenied
+	case http.StatusConflict:
+		return TypeConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return TypeValidationFailed
+	case http.StatusTooManyRequests:
+		return TypeRateLimited
+	}
+
+	return ""
+}
+
+func isTyped(err error) bool {
+	var rl *RateLimitedError
+	return !IsRetryable(err) || errors.As(err, &rl)
+}
+
+func splitScopes(scopes string) []string {
+	if scopes == "" {
+		return nil
+	}
+	return strings.Split(scopes, ",")
+}
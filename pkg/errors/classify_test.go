@@ -0,0 +1,238 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantType string // Empty if the original error should be returned as-is.
+		wantInfo Info
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "not_an_application_error",
+			err:  errors.New("channel_not_found"),
+		},
+		{
+			name:     "explicit_type",
+			err:      temporal.NewApplicationError("whatever", TypeConflict),
+			wantType: "*errors.ConflictError",
+			wantInfo: Info{Activity: "a", Message: "whatever"},
+		},
+		{
+			name:     "slack_code_as_type",
+			err:      temporal.NewApplicationError("Slack API error", "channel_not_found"),
+			wantType: "*errors.NotFoundError",
+			wantInfo: Info{Activity: "a", Code: "channel_not_found", Message: "Slack API error"},
+		},
+		{
+			name:     "slack_code_in_details",
+			err:      temporal.NewApplicationError("failed", "", Details{Code: "invalid_auth"}),
+			wantType: "*errors.AuthRevokedError",
+			wantInfo: Info{Activity: "a", Code: "invalid_auth", Message: "failed"},
+		},
+		{
+			name:     "slack_code_in_message",
+			err:      temporal.NewApplicationError("Slack API error: not_in_channel", ""),
+			wantType: "*errors.PermissionDeniedError",
+			wantInfo: Info{Activity: "a", Code: "not_in_channel", Message: "Slack API error: not_in_channel"},
+		},
+		{
+			name: "unknown_slack_code_in_message",
+			err:  temporal.NewApplicationError("Slack API error: some_new_error", ""),
+		},
+		{
+			name:     "status_code_in_details",
+			err:      temporal.NewApplicationError("failed", "", Details{StatusCode: 404}),
+			wantType: "*errors.NotFoundError",
+			wantInfo: Info{Activity: "a", StatusCode: 404, Message: "failed"},
+		},
+		{
+			name:     "status_code_in_message",
+			err:      temporal.NewApplicationError("HTTP status code 422: unprocessable", ""),
+			wantType: "*errors.ValidationFailedError",
+			wantInfo: Info{Activity: "a", StatusCode: 422, Message: "HTTP status code 422: unprocessable"},
+		},
+		{
+			name:     "status_code_409",
+			err:      temporal.NewApplicationError("status: 409", ""),
+			wantType: "*errors.ConflictError",
+			wantInfo: Info{Activity: "a", StatusCode: 409, Message: "status: 409"},
+		},
+		{
+			name:     "status_code_403",
+			err:      temporal.NewApplicationError("HTTP 403 forbidden", ""),
+			wantType: "*errors.PermissionDeniedError",
+			wantInfo: Info{Activity: "a", StatusCode: 403, Message: "HTTP 403 forbidden"},
+		},
+		{
+			name:     "status_code_403_rate_limit",
+			err:      temporal.NewApplicationError("HTTP 403: secondary rate limit exceeded", ""),
+			wantType: "*errors.RateLimitedError",
+			wantInfo: Info{Activity: "a", StatusCode: 403, Message: "HTTP 403: secondary rate limit exceeded"},
+		},
+		{
+			name: "status_code_500",
+			err:  temporal.NewApplicationError("HTTP 500 internal server error", ""),
+		},
+		{
+			name:     "type_precedes_slack_code",
+			err:      temporal.NewApplicationError("channel_not_found", TypeValidationFailed),
+			wantType: "*errors.ValidationFailedError",
+			wantInfo: Info{Activity: "a", Code: "channel_not_found", Message: "channel_not_found"},
+		},
+		{
+			name:     "slack_code_precedes_status_code",
+			err:      temporal.NewApplicationError("HTTP 400: already_reacted", ""),
+			wantType: "*errors.ConflictError",
+			wantInfo: Info{Activity: "a", Code: "already_reacted", StatusCode: 400, Message: "HTTP 400: already_reacted"},
+		},
+		{
+			name:     "activity_not_registered",
+			err:      temporal.NewApplicationError("unable to find activityType=a", activityNotRegistered),
+			wantType: "*errors.UnsupportedError",
+			wantInfo: Info{Activity: "a", Message: "unable to find activityType=a"},
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("activity error: %w", temporal.NewApplicationError("failed", "no_reaction")),
+			wantType: "*errors.NotFoundError",
+			wantInfo: Info{Activity: "a", Code: "no_reaction", Message: "failed"},
+		},
+		{
+			name:     "already_classified",
+			err:      &ConflictError{Info: Info{Activity: "b", Message: "conflict"}},
+			wantType: "*errors.ConflictError",
+			wantInfo: Info{Activity: "b", Message: "conflict"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify("a", tt.err)
+			if tt.wantType == "" {
+				if got != tt.err { //nolint:errorlint // Checking identity, not equivalence.
+					t.Fatalf("Classify() = %v, want the original error %v", got, tt.err)
+				}
+				return
+			}
+
+			if gotType := fmt.Sprintf("%T", got); gotType != tt.wantType {
+				t.Fatalf("Classify() type = %s, want %s", gotType, tt.wantType)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Classify() does not wrap the original error")
+			}
+
+			info, ok := InfoOf(got)
+			if tt.wantInfo.Activity == "" {
+				if ok {
+					t.Errorf("InfoOf() = %+v, want none", info)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("InfoOf() = none, want %+v", tt.wantInfo)
+			}
+			info.err = nil
+			if *info != tt.wantInfo {
+				t.Errorf("InfoOf() = %+v, want %+v", *info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestClassifyDetails(t *testing.T) {
+	t.Run("permission_denied_scopes", func(t *testing.T) {
+		d := Details{Code: "missing_scope", Needed: "chat:write,users:read", Provided: "channels:read"}
+		err := Classify("a", temporal.NewApplicationError("failed", "", d))
+
+		var pd *PermissionDeniedError
+		if !errors.As(err, &pd) {
+			t.Fatalf("Classify() = %T, want *PermissionDeniedError", err)
+		}
+		if want := []string{"chat:write", "users:read"}; !reflect.DeepEqual(pd.Needed, want) {
+			t.Errorf("Needed = %q, want %q", pd.Needed, want)
+		}
+		if want := []string{"channels:read"}; !reflect.DeepEqual(pd.Provided, want) {
+			t.Errorf("Provided = %q, want %q", pd.Provided, want)
+		}
+	})
+
+	t.Run("rate_limited_retry_after", func(t *testing.T) {
+		err := Classify("a", temporal.NewApplicationError("failed", "", Details{Code: "ratelimited", RetryAfter: 30}))
+
+		var rl *RateLimitedError
+		if !errors.As(err, &rl) {
+			t.Fatalf("Classify() = %T, want *RateLimitedError", err)
+		}
+		if rl.RetryAfter != 30*time.Second {
+			t.Errorf("RetryAfter = %v, want %v", rl.RetryAfter, 30*time.Second)
+		}
+		if !IsRetryable(err) {
+			t.Error("IsRetryable() = false, want true")
+		}
+	})
+
+	t.Run("rate_limited_next_retry_delay", func(t *testing.T) {
+		appErr := temporal.NewApplicationErrorWithOptions("HTTP 429", "", temporal.ApplicationErrorOptions{NextRetryDelay: time.Minute})
+		err := Classify("a", appErr)
+
+		var rl *RateLimitedError
+		if !errors.As(err, &rl) {
+			t.Fatalf("Classify() = %T, want *RateLimitedError", err)
+		}
+		if rl.RetryAfter != time.Minute {
+			t.Errorf("RetryAfter = %v, want %v", rl.RetryAfter, time.Minute)
+		}
+	})
+
+	t.Run("unsupported_activity", func(t *testing.T) {
+		err := Classify("a", temporal.NewApplicationError("unknown", activityNotRegistered))
+
+		var u *UnsupportedError
+		if !errors.As(err, &u) {
+			t.Fatalf("Classify() = %T, want *UnsupportedError", err)
+		}
+		if want := []string{"a"}; !reflect.DeepEqual(u.Missing, want) {
+			t.Errorf("Missing = %q, want %q", u.Missing, want)
+		}
+		if IsRetryable(err) {
+			t.Error("IsRetryable() = true, want false")
+		}
+	})
+}
+
+func TestNonRetryableTypes(t *testing.T) {
+	types := NonRetryableTypes()
+
+	want := []string{TypeNotFound, TypePermissionDenied, TypeConflict, TypeValidationFailed, TypeAuthRevoked, activityNotRegistered}
+	if !reflect.DeepEqual(types[:len(want)], want) {
+		t.Errorf("NonRetryableTypes()[:%d] = %q, want %q", len(want), types[:len(want)], want)
+	}
+
+	for _, typ := range types {
+		if typ == TypeRateLimited || slackCodes[typ] == TypeRateLimited {
+			t.Errorf("NonRetryableTypes() contains retryable type %q", typ)
+		}
+	}
+	for code, typ := range slackCodes {
+		if typ != TypeRateLimited && !slices.Contains(types, code) {
+			t.Errorf("NonRetryableTypes() does not contain Slack error code %q", code)
+		}
+	}
+	if codes := types[len(want):]; !slices.IsSorted(codes) {
+		t.Errorf("NonRetryableTypes() Slack error codes are not sorted: %q", codes)
+	}
+}
@@ -0,0 +1,13 @@
+// Package errors provides typed errors for failures of Timpani activities,
+// based on the Temporal application errors that the Timpani worker returns.
+//
+// All the wrapper functions in this module return these typed errors when
+// they can be identified, so they may be inspected with [errors.As]:
+//
+//	var nf *errors.NotFoundError
+//	if errors.As(err, &nf) {
+//		// ...
+//	}
+//
+// The original Temporal errors are still accessible with [errors.As] as well.
+package errors
@@ -0,0 +1,169 @@
+package errors
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+//revive:disable:exported
+const (
+	TypeNotFound         = "NotFound"
+	TypePermissionDenied = "PermissionDenied"
+	TypeRateLimited      = "RateLimited"
+	TypeConflict         = "Conflict"
+	TypeValidationFailed = "ValidationFailed"
+	TypeAuthRevoked      = "AuthRevoked"
+	TypeUnsupported      = "Unsupported"
+) //revive:enable:exported
+
+// Info contains the details that are common to all the typed errors in this package.
+type Info struct {
+	Activity   string // Name of the Timpani activity that failed.
+	Code       string // Upstream error code, if known (e.g. Slack's "channel_not_found").
+	StatusCode int    // Upstream HTTP status code, if known.
+	Message    string // Human-readable description.
+
+	err error // Original error.
+}
+
+// Error implements the [error] interface.
+func (i *Info) Error() string {
+	if i.err != nil {
+		return i.err.Error()
+	}
+	return i.Message
+}
+
+// Unwrap returns the original Temporal error, if there is one.
+func (i *Info) Unwrap() error {
+	return i.err
+}
+
+// InfoOf returns the common details of the first typed error
+// in this package that err is or wraps, if there is one.
+func InfoOf(err error) (*Info, bool) {
+	var t interface{ info() *Info }
+	if !errors.As(err, &t) {
+		return nil, false
+	}
+	return t.info(), true
+}
+
+func (i *Info) info() *Info {
+	return i
+}
+
+// NotFoundError indicates that an upstream resource (e.g. channel,
+// user, message, PR, or comment) does not exist or is not visible.
+type NotFoundError struct {
+	Info
+}
+
+// PermissionDeniedError indicates that the Timpani
+// worker's credentials are not allowed to perform an action.
+type PermissionDeniedError struct {
+	Info
+
+	Needed   []string // Missing scopes, if known.
+	Provided []string // Granted scopes, if known.
+}
+
+// RateLimitedError indicates that the upstream service throttled the request.
+type RateLimitedError struct {
+	Info
+
+	RetryAfter time.Duration // Upstream hint, if known.
+}
+
+// ConflictError indicates that the request conflicts with the current state of an
+// upstream resource (e.g. PR already merged, reaction already added, name taken).
+type ConflictError struct {
+	Info
+}
+
+// ValidationFailedError indicates that the request is invalid, either according to
+// client-side validation or according to the upstream service (e.g. HTTP 422).
+type ValidationFailedError struct {
+	Info
+
+	Problems []string // Descriptions of specific invalid fields, if known.
+}
+
+// AuthRevokedError indicates that the Timpani worker's credentials
+// are invalid, expired, or revoked (e.g. uninstalled app).
+type AuthRevokedError struct {
+	Info
+}
+
+// UnsupportedError indicates that the Timpani worker does not support one or more
+// activities or workflows, e.g. because it's older than this module's version.
+type UnsupportedError struct {
+	Info
+
+	Missing       []string // Names of the unsupported activities and workflows.
+	WorkerVersion string   // Version of the Timpani worker, if known.
+}
+
+// ErrTimeout is returned by functions that wait for asynchronous event
+// notifications from the Timpani worker, when their timeout expires first.
+var ErrTimeout = errors.New("timed out waiting for event")
+
+// IsRetryable reports whether executing the same Timpani activity again, as-is,
+// might succeed. This is false for all the typed errors in this package except
+// [RateLimitedError], and true for all other (e.g. network and timeout) errors.
+func IsRetryable(err error) bool {
+	var (
+		nf *NotFoundError
+		pd *PermissionDeniedError
+		c  *ConflictError
+		vf *ValidationFailedError
+		ar *AuthRevokedError
+		u  *UnsupportedError
+	)
+	return !errors.As(err, &nf) && !errors.As(err, &pd) && !errors.As(err, &c) &&
+		!errors.As(err, &vf) && !errors.As(err, &ar) && !errors.As(err, &u)
+}
+
+// IsNotFound reports whether err is or wraps a [NotFoundError].
+func IsNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+// IsUnsupported reports whether err is or wraps an [UnsupportedError].
+func IsUnsupported(err error) bool {
+	var u *UnsupportedError
+	return errors.As(err, &u)
+}
+
+// IsTimeout reports whether err is or wraps [ErrTimeout].
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
+
+// NonRetryableTypes returns the Temporal application error types which should not be
+// retried in Timpani activities. They are used in the default [temporal.RetryPolicy].
+//
+// Temporal matches them only against the types of application errors, so they prevent
+// retries only if the Timpani worker sets [temporal.ApplicationError.Type] to one of
+// them: an error type in this package, a well-known Slack error code, or Temporal's own
+// type for activities which the worker doesn't support (see [UnsupportedError]). Errors which
+// [Classify] recognizes only from their messages (e.g. HTTP status codes in the message
+// text) are still retried according to the retry policy before they are classified.
+//
+// [temporal.RetryPolicy]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/temporal#RetryPolicy
+// [temporal.ApplicationError.Type]: https://pkg.go.dev/go.temporal.io/sdk/temporal#ApplicationError.Type
+func NonRetryableTypes() []string {
+	types := []string{TypeNotFound, TypePermissionDenied, TypeConflict, TypeValidationFailed, TypeAuthRevoked, activityNotRegistered}
+
+	var codes []string
+	for code, typ := range slackCodes {
+		if typ != TypeRateLimited {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+
+	return append(types, codes...)
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+)
+
+// IssuesCommentsCreateAsync is an asynchronous version of [IssuesCommentsCreate].
+func IssuesCommentsCreateAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, issue int, body string) async.Future[*IssueComment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*IssueComment, error) {
+		return IssuesCommentsCreate(ctx, thrippyLinkID, owner, repo, issue, body)
+	})
+}
+
+// IssuesCommentsDeleteAsync is an asynchronous version of [IssuesCommentsDelete].
+func IssuesCommentsDeleteAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, commentID int) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return IssuesCommentsDelete(ctx, thrippyLinkID, owner, repo, commentID)
+	})
+}
+
+// IssuesCommentsUpdateAsync is an asynchronous version of [IssuesCommentsUpdate].
+func IssuesCommentsUpdateAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, commentID int, body string) async.Future[*IssueComment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*IssueComment, error) {
+		return IssuesCommentsUpdate(ctx, thrippyLinkID, owner, repo, commentID, body)
+	})
+}
+
+// PullRequestsGetAsync is an asynchronous version of [PullRequestsGet].
+func PullRequestsGetAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, prID int) async.Future[*PullRequest] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullRequest, error) {
+		return PullRequestsGet(ctx, thrippyLinkID, owner, repo, prID)
+	})
+}
+
+// PullRequestsListCommitsAsync is an asynchronous version of [PullRequestsListCommits].
+func PullRequestsListCommitsAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, prID int) async.Future[[]Commit] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Commit, error) {
+		return PullRequestsListCommits(ctx, thrippyLinkID, owner, repo, prID)
+	})
+}
+
+// PullRequestsListFilesAsync is an asynchronous version of [PullRequestsListFiles].
+func PullRequestsListFilesAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, prID int) async.Future[[]File] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]File, error) {
+		return PullRequestsListFiles(ctx, thrippyLinkID, owner, repo, prID)
+	})
+}
+
+// PullRequestsMergeAsync is an asynchronous version of [PullRequestsMerge].
+func PullRequestsMergeAsync(ctx workflow.Context, req PullRequestsMergeRequest) async.Future[*PullRequestsMergeResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullRequestsMergeResponse, error) {
+		return PullRequestsMerge(ctx, req)
+	})
+}
+
+// PullRequestsCommentsCreateAsync is an asynchronous version of [PullRequestsCommentsCreate].
+func PullRequestsCommentsCreateAsync(ctx workflow.Context, req PullRequestsCommentsCreateRequest) async.Future[*PullComment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullComment, error) {
+		return PullRequestsCommentsCreate(ctx, req)
+	})
+}
+
+// PullRequestsCommentsCreateReplyAsync is an asynchronous version of [PullRequestsCommentsCreateReply].
+func PullRequestsCommentsCreateReplyAsync(ctx workflow.Context, req PullRequestsCommentsCreateReplyRequest) async.Future[*PullComment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullComment, error) {
+		return PullRequestsCommentsCreateReply(ctx, req)
+	})
+}
+
+// PullRequestsCommentsDeleteAsync is an asynchronous version of [PullRequestsCommentsDelete].
+func PullRequestsCommentsDeleteAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, commentID int) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsCommentsDelete(ctx, thrippyLinkID, owner, repo, commentID)
+	})
+}
+
+// PullRequestsCommentsUpdateAsync is an asynchronous version of [PullRequestsCommentsUpdate].
+func PullRequestsCommentsUpdateAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, commentID int, body string) async.Future[*PullComment] {
+	return async.Go(ctx, func(ctx workflow.Context) (*PullComment, error) {
+		return PullRequestsCommentsUpdate(ctx, thrippyLinkID, owner, repo, commentID, body)
+	})
+}
+
+// PullRequestsReviewsCreateAsync is an asynchronous version of [PullRequestsReviewsCreate].
+func PullRequestsReviewsCreateAsync(ctx workflow.Context, req PullRequestsReviewsCreateRequest) async.Future[*Review] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Review, error) {
+		return PullRequestsReviewsCreate(ctx, req)
+	})
+}
+
+// PullRequestsReviewsDeleteAsync is an asynchronous version of [PullRequestsReviewsDelete].
+func PullRequestsReviewsDeleteAsync(ctx workflow.Context, thrippyLinkID, owner, repo string, prID, reviewID int) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return PullRequestsReviewsDelete(ctx, thrippyLinkID, owner, repo, prID, reviewID)
+	})
+}
+
+// PullRequestsReviewsDismissAsync is an asynchronous version of [PullRequestsReviewsDismiss].
+func PullRequestsReviewsDismissAsync(ctx workflow.Context, req PullRequestsReviewsDismissRequest) async.Future[*Review] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Review, error) {
+		return PullRequestsReviewsDismiss(ctx, req)
+	})
+}
+
+// PullRequestsReviewsSubmitAsync is an asynchronous version of [PullRequestsReviewsSubmit].
+func PullRequestsReviewsSubmitAsync(ctx workflow.Context, req PullRequestsReviewsSubmitRequest) async.Future[*Review] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Review, error) {
+		return PullRequestsReviewsSubmit(ctx, req)
+	})
+}
+
+// PullRequestsReviewsUpdateAsync is an asynchronous version of [PullRequestsReviewsUpdate].
+func PullRequestsReviewsUpdateAsync(ctx workflow.Context, req PullRequestsReviewsUpdateRequest) async.Future[*Review] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Review, error) {
+		return PullRequestsReviewsUpdate(ctx, req)
+	})
+}
+
+// UsersGetAuthenticatedAsync is an asynchronous version of [UsersGetAuthenticated].
+func UsersGetAuthenticatedAsync(ctx workflow.Context) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetAuthenticated(ctx)
+	})
+}
+
+// UsersGetByAccountIDAsync is an asynchronous version of [UsersGetByAccountID].
+func UsersGetByAccountIDAsync(ctx workflow.Context, accountID string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetByAccountID(ctx, accountID)
+	})
+}
+
+// UsersGetByUsernameAsync is an asynchronous version of [UsersGetByUsername].
+func UsersGetByUsernameAsync(ctx workflow.Context, username string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGetByUsername(ctx, username)
+	})
+}
+
+// UsersListAsync is an asynchronous version of [UsersList].
+func UsersListAsync(ctx workflow.Context, since, perPage int) async.Future[[]User] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]User, error) {
+		return UsersList(ctx, since, perPage)
+	})
+}
@@ -0,0 +1,183 @@
+// Package events provides typed payloads of [GitHub webhook events], which the
+// Timpani worker sends to Temporal workflows as signals, and functions to receive them.
+//
+// [GitHub webhook events]: https://docs.github.com/en/webhooks/webhook-events-and-payloads
+package events
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/github"
+)
+
+// Signal is the name of a Temporal signal which carries GitHub webhook events of type T.
+// Signals are named after the "X-GitHub-Event" header, with the "github.events." prefix.
+type Signal[T any] string
+
+//revive:disable:exported
+const (
+	IssueCommentSignal             Signal[IssueCommentEvent]             = "github.events.issue_comment"
+	IssuesSignal                   Signal[IssuesEvent]                   = "github.events.issues"
+	PullRequestSignal              Signal[PullRequestEvent]              = "github.events.pull_request"
+	PullRequestReviewSignal        Signal[PullRequestReviewEvent]        = "github.events.pull_request_review"
+	PullRequestReviewCommentSignal Signal[PullRequestReviewCommentEvent] = "github.events.pull_request_review_comment"
+	PushSignal                     Signal[PushEvent]                     = "github.events.push"
+) //revive:enable:exported
+
+// Receive waits for the next GitHub webhook event in a Temporal signal which matches a
+// filter (all events match if the filter is nil), and returns it. Non-matching events are
+// kept for other receivers of the same signal in the same workflow, as described in
+// [internal.ReceiveSignal]. If the timeout is positive and expires first, it returns
+// [errors.ErrTimeout].
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func Receive[T any](ctx workflow.Context, s Signal[T], timeout time.Duration, filter func(*T) bool) (*T, error) {
+	return internal.ReceiveSignal(ctx, string(s), timeout, filter)
+}
+
+// IssueCommentEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#issue_comment
+type IssueCommentEvent struct {
+	Action  string              `json:"action"` // "created", "edited", "deleted".
+	Issue   github.Issue        `json:"issue"`
+	Comment github.IssueComment `json:"comment"`
+	Changes map[string]any      `json:"changes,omitempty"`
+
+	Common
+}
+
+// IssuesEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#issues
+type IssuesEvent struct {
+	Action   string         `json:"action"` // E.g. "opened", "edited", "closed", "reopened", "assigned".
+	Issue    github.Issue   `json:"issue"`
+	Assignee *github.User   `json:"assignee,omitempty"`
+	Changes  map[string]any `json:"changes,omitempty"`
+
+	Common
+}
+
+// PullRequestEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request
+type PullRequestEvent struct {
+	Action      string             `json:"action"` // E.g. "opened", "synchronize", "closed", "review_requested".
+	Number      int                `json:"number"`
+	PullRequest github.PullRequest `json:"pull_request"`
+
+	Before            string         `json:"before,omitempty"` // Only in "synchronize" events.
+	After             string         `json:"after,omitempty"`  // Only in "synchronize" events.
+	Assignee          *github.User   `json:"assignee,omitempty"`
+	RequestedReviewer *github.User   `json:"requested_reviewer,omitempty"`
+	RequestedTeam     *github.Team   `json:"requested_team,omitempty"`
+	Changes           map[string]any `json:"changes,omitempty"`
+
+	Common
+}
+
+// PullRequestReviewEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request_review
+type PullRequestReviewEvent struct {
+	Action      string             `json:"action"` // "submitted", "edited", "dismissed".
+	Review      github.Review      `json:"review"`
+	PullRequest github.PullRequest `json:"pull_request"`
+	Changes     map[string]any     `json:"changes,omitempty"`
+
+	Common
+}
+
+// PullRequestReviewCommentEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request_review_comment
+type PullRequestReviewCommentEvent struct {
+	Action      string             `json:"action"` // "created", "edited", "deleted".
+	Comment     github.PullComment `json:"comment"`
+	PullRequest github.PullRequest `json:"pull_request"`
+	Changes     map[string]any     `json:"changes,omitempty"`
+
+	Common
+}
+
+// PushEvent is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type PushEvent struct {
+	Ref     string `json:"ref"`
+	BaseRef string `json:"base_ref,omitempty"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Compare string `json:"compare"`
+
+	Created bool `json:"created"`
+	Deleted bool `json:"deleted"`
+	Forced  bool `json:"forced"`
+
+	Commits    []PushCommit   `json:"commits"`
+	HeadCommit *PushCommit    `json:"head_commit,omitempty"`
+	Pusher     CommitUser     `json:"pusher"`
+	Repository PushRepository `json:"repository"`
+
+	Sender       github.User   `json:"sender"`
+	Installation *Installation `json:"installation,omitempty"`
+}
+
+// Common contains the fields which are common to most webhook events, based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#webhook-payload-object-common-properties
+type Common struct {
+	Repository   *github.Repository `json:"repository,omitempty"`
+	Organization *github.User       `json:"organization,omitempty"`
+	Sender       github.User        `json:"sender"`
+	Installation *Installation      `json:"installation,omitempty"`
+}
+
+// CommitUser is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type CommitUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Installation is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#webhook-payload-object-common-properties
+type Installation struct {
+	ID     int64  `json:"id"`
+	NodeID string `json:"node_id,omitempty"`
+}
+
+// PushCommit is based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type PushCommit struct {
+	ID        string     `json:"id"`
+	TreeID    string     `json:"tree_id"`
+	Distinct  bool       `json:"distinct"`
+	Message   string     `json:"message"`
+	Timestamp time.Time  `json:"timestamp"`
+	URL       string     `json:"url"`
+	Author    CommitUser `json:"author"`
+	Committer CommitUser `json:"committer"`
+
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// PushRepository is similar to [github.Repository], but in push events
+// some of its timestamps are Unix epoch numbers rather than strings, based on:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type PushRepository struct {
+	ID       int64  `json:"id"`
+	NodeID   string `json:"node_id"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url,omitempty"`
+
+	Name     string       `json:"name"`
+	FullName string       `json:"full_name"`
+	Owner    *github.User `json:"owner,omitempty"`
+
+	DefaultBranch string `json:"default_branch,omitempty"`
+	Private       bool   `json:"private"`
+	Fork          bool   `json:"fork"`
+
+	PushedAt int64 `json:"pushed_at,omitempty"`
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"cmp"
+	"iter"
+	"strconv"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
+)
+
+// defaultPerPage is GitHub's default page size.
+const defaultPerPage = 30
+
+// pages returns an iterator over the items of a list-style activity with page-based pagination:
+// https://docs.github.com/rest/using-the-rest-api/using-pagination-in-the-rest-api
+//
+// It requests one page at a time, starting with page 1, and stops after the first page
+// which isn't full. The req function returns the activity's request for a specific page.
+func pages[T any](ctx workflow.Context, name string, perPage int, req func(page, perPage int) any) iter.Seq2[T, error] {
+	perPage = cmp.Or(perPage, defaultPerPage)
+	return pagination.Seq(func(token string) ([]T, string, error) {
+		page, _ := strconv.Atoi(cmp.Or(token, "1"))
+		resp, err := internal.ExecuteTimpaniActivity[[]T](ctx, name, req(page, perPage))
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(*resp) < perPage {
+			return *resp, "", nil
+		}
+		return *resp, strconv.Itoa(page + 1), nil
+	})
+}
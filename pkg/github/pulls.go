@@ -1,6 +1,7 @@
 package github
 
 import (
+	"iter"
 	"time"
 
 	"go.temporal.io/sdk/workflow"
@@ -91,6 +92,19 @@ func PullRequestsListCommits(ctx workflow.Context, thrippyLinkID, owner, repo st
 	return *resp, nil
 }
 
+// PullRequestsListCommitsIter is based on:
+//   - https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-commits-on-a-pull-request
+//   - https://docs.github.com/rest/using-the-rest-api/using-pagination-in-the-rest-api
+//
+// It returns an iterator over the commits, and requests them one page at a time (perPage
+// may be 0 for the default page size). The results are still limited to a maximum of 250 commits.
+func PullRequestsListCommitsIter(ctx workflow.Context, thrippyLinkID, owner, repo string, prID, perPage int) iter.Seq2[Commit, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Owner: owner, Repo: repo, PullNumber: prID}
+	return pages[Commit](ctx, PullRequestsListCommitsActivityName, perPage, func(page, perPage int) any {
+		return PullRequestsListCommitsRequest{PullRequestsRequest: pr, PerPage: perPage, Page: page}
+	})
+}
+
 // PullRequestsListFilesRequest is based on:
 // https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-pull-requests-files
 type PullRequestsListFilesRequest struct {
@@ -114,6 +128,19 @@ func PullRequestsListFiles(ctx workflow.Context, thrippyLinkID, owner, repo stri
 	return *resp, nil
 }
 
+// PullRequestsListFilesIter is based on:
+//   - https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-pull-requests-files
+//   - https://docs.github.com/rest/using-the-rest-api/using-pagination-in-the-rest-api
+//
+// It returns an iterator over the files, and requests them one page at a time (perPage
+// may be 0 for the default page size). The results are still limited to a maximum of 3000 files.
+func PullRequestsListFilesIter(ctx workflow.Context, thrippyLinkID, owner, repo string, prID, perPage int) iter.Seq2[File, error] {
+	pr := PullRequestsRequest{ThrippyLinkID: thrippyLinkID, Owner: owner, Repo: repo, PullNumber: prID}
+	return pages[File](ctx, PullRequestsListFilesActivityName, perPage, func(page, perPage int) any {
+		return PullRequestsListFilesRequest{PullRequestsRequest: pr, PerPage: perPage, Page: page}
+	})
+}
+
 // PullRequestsMergeRequest is based on:
 // https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#merge-a-pull-request
 type PullRequestsMergeRequest struct {
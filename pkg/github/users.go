@@ -1,11 +1,14 @@
 package github
 
 import (
+	"iter"
+	"strconv"
 	"time"
 
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -17,6 +20,8 @@ const (
 // UsersGetRequest is based on:
 // https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28
 type UsersGetRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	AccountID string `json:"account_id,omitempty"`
 	Username  string `json:"username,omitempty"`
 }
@@ -45,6 +50,8 @@ func UsersGetByUsername(ctx workflow.Context, username string) (*User, error) {
 //   - https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28#list-users
 //   - https://docs.github.com/rest/using-the-rest-api/using-pagination-in-the-rest-api
 type UsersListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Since   int `json:"since,omitempty"`
 	PerPage int `json:"per_page,omitempty"`
 }
@@ -61,6 +68,28 @@ func UsersList(ctx workflow.Context, since, perPage int) ([]User, error) {
 	return *resp, nil
 }
 
+// UsersListIter is based on:
+//   - https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28#list-users
+//   - https://docs.github.com/rest/using-the-rest-api/using-pagination-in-the-rest-api
+//
+// It returns an iterator over all the users, starting after the user ID
+// "since" (0 for all users), and requests them one page at a time (perPage
+// may be 0 for the default page size). This API uses the ID of the last user
+// in each page, instead of page numbers, to request the next page.
+func UsersListIter(ctx workflow.Context, since, perPage int) iter.Seq2[User, error] {
+	return pagination.Seq(func(token string) ([]User, string, error) {
+		if token != "" {
+			since, _ = strconv.Atoi(token)
+		}
+
+		users, err := UsersList(ctx, since, perPage)
+		if err != nil || len(users) == 0 {
+			return users, "", err
+		}
+		return users, strconv.FormatInt(users[len(users)-1].ID, 10), nil
+	})
+}
+
 // User is based on:
 // https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28
 type User struct {
@@ -0,0 +1,193 @@
+package github
+
+import (
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Client-side validation of GitHub requests, based on the "Parameters" section
+// of each endpoint in https://docs.github.com/en/rest. Validation failures are
+// non-retryable, and are reported before sending requests to the Timpani
+// worker, instead of by GitHub after several retries.
+
+var (
+	diffSides    = []string{"LEFT", "RIGHT"}
+	reviewEvents = []string{"APPROVE", "REQUEST_CHANGES", "COMMENT"}
+)
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r IssuesCommentsCreateRequest) Validate() error {
+	return internal.NewValidator(IssuesCommentsCreateActivityName).
+		Require("owner", r.Owner).
+		Require("repo", r.Repo).
+		Require("issue_number", r.IssueNumber).
+		Require("body", r.Body).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r IssuesCommentsDeleteRequest) Validate() error {
+	return internal.NewValidator(IssuesCommentsDeleteActivityName).
+		Require("owner", r.Owner).
+		Require("repo", r.Repo).
+		Require("comment_id", r.CommentID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r IssuesCommentsUpdateRequest) Validate() error {
+	return internal.NewValidator(IssuesCommentsUpdateActivityName).
+		Require("owner", r.Owner).
+		Require("repo", r.Repo).
+		Require("comment_id", r.CommentID).
+		Require("body", r.Body).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+// This request type is shared by multiple activities.
+func (r PullRequestsRequest) Validate() error {
+	return r.validate(internal.NewValidator("")).Err()
+}
+
+func (r PullRequestsRequest) validate(v *internal.Validator) *internal.Validator {
+	return v.Require("owner", r.Owner).Require("repo", r.Repo).Require("pull_number", r.PullNumber)
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsCommentsRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsCommentsDeleteActivityName)).Err()
+}
+
+func (r PullRequestsCommentsRequest) validate(v *internal.Validator) *internal.Validator {
+	return v.Require("owner", r.Owner).Require("repo", r.Repo).Require("comment_id", r.CommentID)
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsReviewsRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsReviewsDeleteActivityName)).Err()
+}
+
+func (r PullRequestsReviewsRequest) validate(v *internal.Validator) *internal.Validator {
+	return v.Require("owner", r.Owner).Require("repo", r.Repo).
+		Require("pull_number", r.PullNumber).Require("review_id", r.ReviewID)
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListCommitsRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsListCommitsActivityName)).
+		Check(r.PerPage >= 0 && r.PerPage <= 100, `field "per_page" must be between 0 and 100`).
+		Check(r.Page >= 0, `field "page" must not be negative`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsListFilesRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsListFilesActivityName)).
+		Check(r.PerPage >= 0 && r.PerPage <= 100, `field "per_page" must be between 0 and 100`).
+		Check(r.Page >= 0, `field "page" must not be negative`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsMergeRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsMergeActivityName)).
+		Enum("merge_method", r.MergeMethod, "merge", "squash", "rebase").
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsUpdateRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsUpdateActivityName)).
+		Enum("state", r.State, "open", "closed").
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsCommentsCreateRequest) Validate() error {
+	v := r.validate(internal.NewValidator(PullRequestsCommentsCreateActivityName)).
+		Require("body", r.Body).
+		Enum("subject_type", r.SubjectType, "line", "file").
+		Enum("side", r.Side, diffSides...).
+		Enum("start_side", r.StartSide, diffSides...)
+
+	// Replies ignore all the other parameters.
+	if r.InReplyTo != 0 {
+		return v.Err()
+	}
+
+	v.Require("commit_id", r.CommitID).Require("path", r.Path)
+	if r.SubjectType == "file" {
+		return v.Exclusive(`"subject_type" = "file" and "line"`, r.SubjectType, r.Line).Err()
+	}
+
+	return v.Require("line", r.Line).
+		Check(r.Line == 0 || r.Side != "", `"line" requires "side"`).
+		Check(r.StartLine == 0 || r.StartSide != "", `"start_line" requires "start_side"`).
+		Check(r.StartLine == 0 || r.StartLine < r.Line, `"start_line" must precede "line"`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsCommentsCreateReplyRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsCommentsCreateReplyActivityName)).
+		Require("comment_id", r.CommentID).
+		Require("body", r.Body).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsCommentsUpdateRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsCommentsUpdateActivityName)).
+		Require("body", r.Body).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsReviewsCreateRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsReviewsCreateActivityName)).
+		Enum("event", r.Event, reviewEvents...).
+		Check(r.Event != "REQUEST_CHANGES" && r.Event != "COMMENT" || r.Body != "",
+			`events "REQUEST_CHANGES" and "COMMENT" require "body"`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsReviewsDismissRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsReviewsDismissActivityName)).
+		Require("message", r.Message).
+		Enum("event", r.Event, "DISMISS").
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsReviewsSubmitRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsReviewsSubmitActivityName)).
+		Require("event", r.Event).
+		Enum("event", r.Event, reviewEvents...).
+		Check(r.Event != "REQUEST_CHANGES" && r.Event != "COMMENT" || r.Body != "",
+			`events "REQUEST_CHANGES" and "COMMENT" require "body"`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r PullRequestsReviewsUpdateRequest) Validate() error {
+	return r.validate(internal.NewValidator(PullRequestsReviewsUpdateActivityName)).
+		Require("body", r.Body).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersGetRequest) Validate() error {
+	return internal.NewValidator(UsersGetActivityName).
+		RequireAny(`"account_id" or "username"`, r.AccountID, r.Username).
+		Exclusive(`"account_id" and "username"`, r.AccountID, r.Username).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersListRequest) Validate() error {
+	return internal.NewValidator(UsersListActivityName).
+		Check(r.PerPage >= 0 && r.PerPage <= 100, `field "per_page" must be between 0 and 100`).
+		Check(r.Since >= 0, `field "since" must not be negative`).
+		Err()
+}
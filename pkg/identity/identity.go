@@ -0,0 +1,318 @@
+// Package identity resolves the accounts of the same person across third-party
+// services (Slack, GitHub, Bitbucket, Jira), based on their email addresses
+// and explicit mappings, and reports missing and ambiguous matches.
+package identity
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/github"
+	"github.com/tzrikka/timpani-api/pkg/jira"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+)
+
+// Kind is the kind of an identifier of a person.
+type Kind string
+
+// Kinds of identifiers.
+const (
+	Email     Kind = "email"
+	Slack     Kind = "slack"     // User ID.
+	GitHub    Kind = "github"    // Login (username).
+	Bitbucket Kind = "bitbucket" // Account ID.
+	Jira      Kind = "jira"      // Account ID.
+)
+
+// services are the kinds of identifiers which belong to third-party services, in resolution order.
+var services = []Kind{Slack, GitHub, Bitbucket, Jira}
+
+// Person holds the identifiers of the same person in all the third-party services.
+// Empty fields are unknown.
+type Person struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+
+	SlackUserID        string `json:"slack_user_id,omitempty"`
+	GitHubLogin        string `json:"github_login,omitempty"`
+	BitbucketAccountID string `json:"bitbucket_account_id,omitempty"`
+	JiraAccountID      string `json:"jira_account_id,omitempty"`
+}
+
+// ID returns one of the person's identifiers, or an empty string if it's unknown.
+func (p Person) ID(k Kind) string {
+	switch k {
+	case Email:
+		return p.Email
+	case Slack:
+		return p.SlackUserID
+	case GitHub:
+		return p.GitHubLogin
+	case Bitbucket:
+		return p.BitbucketAccountID
+	case Jira:
+		return p.JiraAccountID
+	default:
+		return ""
+	}
+}
+
+func (p *Person) set(k Kind, id string) {
+	switch k {
+	case Email:
+		p.Email = id
+	case Slack:
+		p.SlackUserID = id
+	case GitHub:
+		p.GitHubLogin = id
+	case Bitbucket:
+		p.BitbucketAccountID = id
+	case Jira:
+		p.JiraAccountID = id
+	}
+}
+
+// merge fills the unknown fields of p with the known fields of other.
+func (p *Person) merge(other Person) {
+	if p.Name == "" {
+		p.Name = other.Name
+	}
+	for _, k := range append([]Kind{Email}, services...) {
+		if p.ID(k) == "" {
+			p.set(k, other.ID(k))
+		}
+	}
+}
+
+// Result is the outcome of resolving a single person's identifiers.
+type Result struct {
+	Person
+
+	// Missing are the services in which no account matched the person.
+	Missing []Kind `json:"missing,omitempty"`
+	// Ambiguous are the services in which multiple accounts matched the person, or
+	// accounts might match it but this can't be verified (e.g. Jira users whose email
+	// addresses are hidden), with their identifiers. The person's identifier in these
+	// services is unknown.
+	Ambiguous map[Kind][]string `json:"ambiguous,omitempty"`
+}
+
+// Options configure a [Resolver].
+type Options struct {
+	// Overrides are explicit mappings, which take precedence over email-based
+	// matches. Each override applies to a person if any of its identifiers
+	// (including the email address) matches any of the person's identifiers.
+	Overrides []Person
+	// BitbucketWorkspace is required for email-based matches of Bitbucket accounts.
+	// If it's empty, Bitbucket accounts are resolved only with overrides.
+	BitbucketWorkspace string
+	// Services limits resolution to specific services. The default is all of them.
+	Services []Kind
+}
+
+// Resolver resolves the identifiers of people across third-party services. It
+// caches results by all their known identifiers, so each person is resolved only
+// once. Resolvers are not safe to share between workflows: they should be created
+// and used inside a single workflow, to keep their activity executions deterministic.
+type Resolver struct {
+	opts  Options
+	cache map[string]*Result
+}
+
+// NewResolver returns a new [Resolver], for use inside a single workflow.
+func NewResolver(opts Options) *Resolver {
+	if len(opts.Services) == 0 {
+		opts.Services = services
+	}
+	return &Resolver{opts: opts, cache: map[string]*Result{}}
+}
+
+func key(k Kind, id string) string {
+	if k == Email {
+		id = strings.ToLower(id)
+	}
+	return string(k) + ":" + id
+}
+
+// Resolve returns the identifiers of a person in all the third-party services,
+// given any one of them (or an email address). The result also reports the
+// services without any matching account, and those with multiple matches.
+//
+// Upstream API errors, except "not found" errors, are wrapped and returned.
+func (r *Resolver) Resolve(ctx workflow.Context, k Kind, id string) (*Result, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty %s identifier", k)
+	}
+	if res, ok := r.cache[key(k, id)]; ok {
+		return res, nil
+	}
+
+	res := &Result{}
+	res.set(k, id)
+	r.override(&res.Person)
+
+	// Find the email address, if it's not already known.
+	if res.Email == "" {
+		if err := r.email(ctx, res, k); err != nil {
+			return nil, err
+		}
+		r.override(&res.Person)
+	}
+
+	// Find the identifiers in all the other services, by email address.
+	for _, s := range services {
+		if !slices.Contains(r.opts.Services, s) || res.ID(s) != "" || slices.Contains(res.Missing, s) {
+			continue
+		}
+		if res.Email == "" {
+			res.Missing = append(res.Missing, s)
+			continue
+		}
+		matches, unverified, err := r.lookup(ctx, s, res)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(matches) == 1:
+			res.set(s, matches[0])
+		case len(matches) > 1:
+			res.ambiguous(s, matches)
+		case len(unverified) > 0:
+			res.ambiguous(s, unverified)
+		default:
+			res.Missing = append(res.Missing, s)
+		}
+	}
+
+	r.cache[key(k, id)] = res
+	for _, k := range append([]Kind{Email}, services...) {
+		if id := res.ID(k); id != "" {
+			r.cache[key(k, id)] = res
+		}
+	}
+
+	return res, nil
+}
+
+func (res *Result) ambiguous(s Kind, ids []string) {
+	if res.Ambiguous == nil {
+		res.Ambiguous = map[Kind][]string{}
+	}
+	res.Ambiguous[s] = ids
+}
+
+// override applies all the matching overrides to a person.
+func (r *Resolver) override(p *Person) {
+	for _, o := range r.opts.Overrides {
+		for _, k := range append([]Kind{Email}, services...) {
+			if id := p.ID(k); id != "" && key(k, id) == key(k, o.ID(k)) {
+				merged := o
+				merged.merge(*p)
+				*p = merged
+				break
+			}
+		}
+	}
+}
+
+// email finds the email address (and name) of a person, based on a
+// single identifier in a third-party service, if the service exposes it.
+func (r *Resolver) email(ctx workflow.Context, res *Result, k Kind) error {
+	id := res.ID(k)
+	var err error
+
+	switch k {
+	case Slack:
+		var u *slack.User
+		if u, err = slack.UsersInfo(ctx, id); err == nil && u != nil {
+			res.Email, res.Name = u.Profile.Email, u.RealName
+		}
+	case GitHub:
+		var u *github.User
+		if u, err = github.UsersGetByUsername(ctx, id); err == nil {
+			res.Email, res.Name = u.Email, u.Name
+		}
+	case Bitbucket:
+		var u *bitbucket.User
+		if u, err = bitbucket.UsersGetByAccountID(ctx, id); err == nil {
+			res.Name = u.DisplayName // Bitbucket doesn't expose email addresses.
+		}
+	case Jira:
+		var u *jira.User
+		if u, err = jira.UsersGet(ctx, id); err == nil {
+			res.Email, res.Name = u.Email, u.DisplayName
+		}
+	}
+
+	if errors.IsNotFound(err) {
+		res.Missing = append(res.Missing, k)
+		res.set(k, "")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s user %q: %w", k, id, err)
+	}
+	return nil
+}
+
+// lookup returns the identifiers of all the accounts in a third-party service which
+// match a person's email address, and of those which might match it but can't be verified.
+func (r *Resolver) lookup(ctx workflow.Context, s Kind, res *Result) (ids, unverified []string, err error) {
+	switch s {
+	case Slack:
+		var u *slack.User
+		if u, err = slack.UsersLookupByEmail(ctx, res.Email); err == nil && u != nil {
+			ids = append(ids, u.ID)
+			res.Name = cmp.Or(res.Name, u.RealName)
+		}
+	case Bitbucket:
+		if r.opts.BitbucketWorkspace == "" {
+			return nil, nil, nil
+		}
+		var us []bitbucket.User
+		if us, err = bitbucket.WorkspacesListMembers(ctx, r.opts.BitbucketWorkspace, []string{res.Email}); err == nil {
+			for _, u := range us {
+				ids = append(ids, u.AccountID)
+			}
+		}
+	case Jira:
+		var us []jira.User
+		if us, err = jira.UsersSearchActivity(ctx, res.Email); err == nil {
+			ids, unverified = jiraMatches(us, res.Email)
+		}
+	case GitHub:
+		// GitHub doesn't support looking up users by private email addresses.
+	}
+
+	if errors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to look up %s users by email: %w", s, err)
+	}
+	return ids, unverified, nil
+}
+
+// jiraMatches returns the account IDs of active Jira users with a specific email address.
+// Jira may hide the email addresses of users, and its search also matches names and partial
+// email addresses, so active users with hidden email addresses are returned separately.
+func jiraMatches(users []jira.User, email string) (exact, hidden []string) {
+	for _, u := range users {
+		if !u.Active || u.AccountType != "atlassian" {
+			continue
+		}
+		switch {
+		case strings.EqualFold(u.Email, email):
+			exact = append(exact, u.AccountID)
+		case u.Email == "":
+			hidden = append(hidden, u.AccountID)
+		}
+	}
+	return exact, hidden
+}
@@ -0,0 +1,232 @@
+package identity_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+	"github.com/tzrikka/timpani-api/pkg/github"
+	"github.com/tzrikka/timpani-api/pkg/identity"
+	"github.com/tzrikka/timpani-api/pkg/jira"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const email = "alice@example.com"
+
+type query struct {
+	kind identity.Kind
+	id   string
+}
+
+func worker() *timpanitest.Worker {
+	w := timpanitest.New()
+	w.AddUser(slack.User{ID: "U1", RealName: "Alice", Profile: slack.Profile{Email: email}})
+	w.AddGitHubUser(github.User{Login: "alice", Name: "Alice Liddell", Email: email})
+	w.AddBitbucketUser(bitbucket.User{AccountID: "bb-1", DisplayName: "Alice"}, "ws", email)
+	w.AddJiraUser(jira.User{AccountID: "j-1", AccountType: "atlassian", Active: true, DisplayName: "Alice", Email: email})
+	w.AddJiraUser(jira.User{AccountID: "j-2", AccountType: "atlassian", Active: false, DisplayName: "Alice (old)", Email: email})
+	return w
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     identity.Options
+		handlers map[string]timpanitest.Handler
+		queries  []query
+		want     []identity.Result
+		// Number of upstream user lookups in all the queries.
+		wantCalls int
+	}{
+		{
+			name:    "slack_user_id",
+			queries: []query{{identity.Slack, "U1"}},
+			want: []identity.Result{{
+				Person:  identity.Person{Name: "Alice", Email: email, SlackUserID: "U1", JiraAccountID: "j-1"},
+				Missing: []identity.Kind{identity.GitHub, identity.Bitbucket},
+			}},
+			wantCalls: 2, // slack.users.info, jira.users.search.
+		},
+		{
+			name:    "github_login",
+			opts:    identity.Options{BitbucketWorkspace: "ws"},
+			queries: []query{{identity.GitHub, "alice"}},
+			want: []identity.Result{{
+				Person: identity.Person{
+					Name: "Alice Liddell", Email: email, SlackUserID: "U1", GitHubLogin: "alice",
+					BitbucketAccountID: "bb-1", JiraAccountID: "j-1",
+				},
+			}},
+			wantCalls: 4,
+		},
+		{
+			name:    "email_with_limited_services",
+			opts:    identity.Options{Services: []identity.Kind{identity.Slack}},
+			queries: []query{{identity.Email, "ALICE@example.com"}},
+			want: []identity.Result{{
+				Person: identity.Person{Name: "Alice", Email: "ALICE@example.com", SlackUserID: "U1"},
+			}},
+			wantCalls: 1,
+		},
+		{
+			name: "overrides",
+			opts: identity.Options{Overrides: []identity.Person{
+				{Email: email, GitHubLogin: "alice-gh", JiraAccountID: "j-9"},
+			}},
+			queries: []query{{identity.Slack, "U1"}},
+			want: []identity.Result{{
+				Person:  identity.Person{Name: "Alice", Email: email, SlackUserID: "U1", GitHubLogin: "alice-gh", JiraAccountID: "j-9"},
+				Missing: []identity.Kind{identity.Bitbucket}, // No workspace to search in.
+			}},
+			wantCalls: 1,
+		},
+		{
+			name:    "unknown_user",
+			queries: []query{{identity.Slack, "U404"}},
+			want: []identity.Result{{
+				Missing: []identity.Kind{identity.Slack, identity.GitHub, identity.Bitbucket, identity.Jira},
+			}},
+			wantCalls: 1,
+		},
+		{
+			name:    "unknown_email",
+			opts:    identity.Options{BitbucketWorkspace: "ws"},
+			queries: []query{{identity.Email, "bob@example.com"}},
+			want: []identity.Result{{
+				Person:  identity.Person{Email: "bob@example.com"},
+				Missing: []identity.Kind{identity.Slack, identity.GitHub, identity.Bitbucket, identity.Jira},
+			}},
+			wantCalls: 3,
+		},
+		{
+			name: "jira_multiple_matches",
+			handlers: map[string]timpanitest.Handler{
+				jira.UsersSearchActivityName: jiraUsers(
+					jira.User{AccountID: "j-1", AccountType: "atlassian", Active: true, Email: email},
+					jira.User{AccountID: "j-3", AccountType: "atlassian", Active: true, Email: email},
+				),
+			},
+			opts:    identity.Options{Services: []identity.Kind{identity.Jira}},
+			queries: []query{{identity.Email, email}},
+			want: []identity.Result{{
+				Person:    identity.Person{Email: email},
+				Ambiguous: map[identity.Kind][]string{identity.Jira: {"j-1", "j-3"}},
+			}},
+			wantCalls: 1,
+		},
+		{
+			name: "jira_hidden_emails",
+			handlers: map[string]timpanitest.Handler{
+				jira.UsersSearchActivityName: jiraUsers(
+					jira.User{AccountID: "j-4", AccountType: "atlassian", Active: true},
+					jira.User{AccountID: "j-5", AccountType: "atlassian", Active: true},
+					jira.User{AccountID: "j-6", AccountType: "atlassian", Active: false},
+					jira.User{AccountID: "j-7", AccountType: "app", Active: true},
+					jira.User{AccountID: "j-8", AccountType: "atlassian", Active: true, Email: "alice@example.org"},
+				),
+			},
+			opts:    identity.Options{Services: []identity.Kind{identity.Jira}},
+			queries: []query{{identity.Email, email}},
+			want: []identity.Result{{
+				Person:    identity.Person{Email: email},
+				Ambiguous: map[identity.Kind][]string{identity.Jira: {"j-4", "j-5"}},
+			}},
+			wantCalls: 1,
+		},
+		{
+			name: "jira_exact_match_and_hidden_emails",
+			handlers: map[string]timpanitest.Handler{
+				jira.UsersSearchActivityName: jiraUsers(
+					jira.User{AccountID: "j-1", AccountType: "atlassian", Active: true, Email: email},
+					jira.User{AccountID: "j-4", AccountType: "atlassian", Active: true},
+				),
+			},
+			opts:    identity.Options{Services: []identity.Kind{identity.Jira}},
+			queries: []query{{identity.Email, email}},
+			want: []identity.Result{{
+				Person: identity.Person{Email: email, JiraAccountID: "j-1"},
+			}},
+			wantCalls: 1,
+		},
+		{
+			name:    "cache",
+			queries: []query{{identity.Slack, "U1"}, {identity.Email, email}, {identity.Jira, "j-1"}},
+			want: []identity.Result{
+				{
+					Person:  identity.Person{Name: "Alice", Email: email, SlackUserID: "U1", JiraAccountID: "j-1"},
+					Missing: []identity.Kind{identity.GitHub, identity.Bitbucket},
+				},
+				{
+					Person:  identity.Person{Name: "Alice", Email: email, SlackUserID: "U1", JiraAccountID: "j-1"},
+					Missing: []identity.Kind{identity.GitHub, identity.Bitbucket},
+				},
+				{
+					Person:  identity.Person{Name: "Alice", Email: email, SlackUserID: "U1", JiraAccountID: "j-1"},
+					Missing: []identity.Kind{identity.GitHub, identity.Bitbucket},
+				},
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := worker()
+			for name, h := range tt.handlers {
+				w.Handle(name, h)
+			}
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			var got []identity.Result
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				r := identity.NewResolver(tt.opts)
+				for _, q := range tt.queries {
+					res, err := r.Resolve(ctx, q.kind, q.id)
+					if err != nil {
+						return err
+					}
+					got = append(got, *res)
+				}
+				return nil
+			})
+
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+			if n := len(w.Calls("")); n != tt.wantCalls {
+				t.Errorf("upstream calls = %d, want %d", n, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestResolveEmptyID(t *testing.T) {
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	timpanitest.New().Register(env)
+
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		_, err := identity.NewResolver(identity.Options{}).Resolve(ctx, identity.Slack, "")
+		return err
+	})
+
+	if env.GetWorkflowError() == nil {
+		t.Error("Resolve() error = nil, want an error")
+	}
+}
+
+// jiraUsers returns a fake handler for [jira.UsersSearchActivity], which returns
+// a fixed list of users regardless of the query (like Jira with hidden emails).
+func jiraUsers(users ...jira.User) timpanitest.Handler {
+	return func(_ *timpanitest.Worker, _ json.RawMessage) (any, error) {
+		return users, nil
+	}
+}
@@ -0,0 +1,122 @@
+// Package interceptor provides a hook around every execution of a Timpani activity,
+// e.g. for logging, metrics and tracing, without wrapping each function separately.
+//
+// Temporal workers that use Timpani register interceptors when they start:
+//
+//	interceptor.Register(interceptor.Logging(), interceptor.Metrics())
+//
+// Interceptors are called in registration order, and each one of them calls
+// the next one, so the first interceptor is the outermost one. Interceptors
+// run in workflow code, so they must be deterministic, just like workflows.
+package interceptor
+
+import (
+	"sync"
+	"time"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+// Call describes a single execution of a Timpani activity.
+// Interceptors may modify it before calling the next invoker.
+type Call struct {
+	Name    string // Name of the Timpani activity, e.g. "slack.chat.postMessage".
+	Service string // Name of the third-party service, e.g. "slack".
+	Request any
+
+	Options workflow.ActivityOptions
+}
+
+// Result describes the outcome of a single execution of a Timpani activity.
+type Result struct {
+	// Duration is the workflow time between scheduling the activity and its completion.
+	Duration time.Duration
+	// RetryState is the reason that Temporal stopped retrying the activity, if it failed
+	// (e.g. its retry policy's maximum attempts were exhausted, a non-retryable error,
+	// or a timeout), or [enums.RETRY_STATE_UNSPECIFIED] if it succeeded. Temporal doesn't
+	// report the number of attempts to workflows, so this is the only retry information.
+	RetryState enums.RetryState
+
+	// Err is the activity's error, converted into a typed error when possible.
+	Err error
+	// Code is the upstream error code, if known (e.g. Slack's "channel_not_found").
+	Code string
+	// StatusCode is the upstream HTTP status code, if known.
+	StatusCode int
+}
+
+// Invoker executes a Timpani activity, and returns its future.
+type Invoker func(ctx workflow.Context, call *Call) workflow.Future
+
+// Interceptor is called instead of an [Invoker], and is expected to call
+// next with the same (or a modified) workflow context and call. Use
+// [Observe] to receive the [Result] of the activity's execution.
+type Interceptor func(ctx workflow.Context, call *Call, next Invoker) workflow.Future
+
+var (
+	interceptorsMu sync.RWMutex
+	interceptors   []Interceptor
+)
+
+// Register adds interceptors to all Timpani activity executions. Temporal workers
+// that use Timpani should call this function only when they start, before running
+// any workflows. Interceptors are called in registration order.
+func Register(is ...Interceptor) {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	interceptors = append(interceptors, is...)
+}
+
+// Reset removes all the registered interceptors.
+func Reset() {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	interceptors = nil
+}
+
+// Wrap returns an invoker which calls all the registered interceptors,
+// in registration order, and then the given invoker.
+func Wrap(final Invoker) Invoker {
+	interceptorsMu.RLock()
+	is := interceptors
+	interceptorsMu.RUnlock()
+
+	invoker := final
+	for i := len(is) - 1; i >= 0; i-- {
+		interceptor, next := is[i], invoker
+		invoker = func(ctx workflow.Context, call *Call) workflow.Future {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// Observe calls the next invoker, and then calls fn with the [Result] when the
+// activity completes, in a separate workflow coroutine (see [workflow.Go]).
+// This is a convenient building block for interceptors which only observe
+// activity executions, without modifying them.
+func Observe(ctx workflow.Context, call *Call, next Invoker, fn func(workflow.Context, *Call, Result)) workflow.Future {
+	start := workflow.Now(ctx)
+	f := next(ctx, call)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		err := f.Get(ctx, nil)
+		fn(ctx, call, result(call, err, workflow.Now(ctx).Sub(start)))
+	})
+
+	return f
+}
+
+func result(call *Call, err error, d time.Duration) Result {
+	r := Result{Duration: d, RetryState: retryState(err), Err: errors.Classify(call.Name, err)}
+
+	if info, ok := errors.InfoOf(r.Err); ok {
+		r.Code = info.Code
+		r.StatusCode = info.StatusCode
+	}
+
+	return r
+}
@@ -0,0 +1,188 @@
+package interceptor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/failure/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/interceptor"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const channelID = "C123"
+
+// register registers interceptors for the duration of a single test.
+func register(t *testing.T, is ...interceptor.Interceptor) {
+	t.Helper()
+
+	interceptor.Register(is...)
+	t.Cleanup(interceptor.Reset)
+}
+
+// runWorkflow runs a test workflow (with a fake Timpani worker), and checks its error.
+func runWorkflow(t *testing.T, s *testsuite.WorkflowTestSuite, wf func(ctx workflow.Context) error) {
+	t.Helper()
+
+	if s == nil {
+		s = new(testsuite.WorkflowTestSuite)
+	}
+	env := s.NewTestWorkflowEnvironment()
+	timpanitest.New().Register(env)
+
+	env.ExecuteWorkflow(wf)
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+}
+
+// invoker returns a fake [interceptor.Invoker], which completes after
+// the given workflow time, with the given error (nil = success).
+func invoker(d time.Duration, err error) interceptor.Invoker {
+	return func(ctx workflow.Context, _ *interceptor.Call) workflow.Future {
+		f, s := workflow.NewFuture(ctx)
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			_ = workflow.Sleep(ctx, d)
+			s.SetError(err)
+		})
+		return f
+	}
+}
+
+// activityError returns an activity failure, as Temporal reports it to workflows:
+// unlike the test workflow environment, it includes the activity's retry state.
+func activityError(t *testing.T, name string, rs enums.RetryState, cause error) error {
+	t.Helper()
+
+	fc := temporal.GetDefaultFailureConverter()
+	return fc.FailureToError(&failure.Failure{
+		Message: "activity error",
+		FailureInfo: &failure.Failure_ActivityFailureInfo{ActivityFailureInfo: &failure.ActivityFailureInfo{
+			ActivityType: &common.ActivityType{Name: name},
+			RetryState:   rs,
+		}},
+		Cause: fc.ErrorToFailure(cause),
+	})
+}
+
+func notFound() error {
+	opts := temporal.ApplicationErrorOptions{NonRetryable: true, Details: []any{errors.Details{Code: "message_not_found"}}}
+	return temporal.NewApplicationErrorWithOptions("message_not_found", errors.TypeNotFound, opts)
+}
+
+func rateLimited() error {
+	opts := temporal.ApplicationErrorOptions{Details: []any{errors.Details{StatusCode: 429}}}
+	return temporal.NewApplicationErrorWithOptions("HTTP 429", errors.TypeRateLimited, opts)
+}
+
+func TestWrapOrder(t *testing.T) {
+	var got []string
+	record := func(name string) interceptor.Interceptor {
+		return func(ctx workflow.Context, call *interceptor.Call, next interceptor.Invoker) workflow.Future {
+			got = append(got, name+" "+call.Options.Summary)
+			call.Options.Summary += name
+			f := next(ctx, call)
+			got = append(got, "/"+name)
+			return f
+		}
+	}
+	register(t, record("a"), record("b"))
+
+	runWorkflow(t, nil, func(ctx workflow.Context) error {
+		_, err := slack.ChatPostMessage(ctx, slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"})
+		return err
+	})
+
+	// The first interceptor is the outermost one, and the next one sees its modifications.
+	if want := []string{"a ", "b a", "/b", "/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("interceptor calls = %q, want %q", got, want)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	tests := []struct {
+		name           string
+		duration       time.Duration
+		retryState     enums.RetryState
+		cause          error
+		wantCode       string
+		wantStatusCode int
+	}{
+		{
+			name:     "slack.chat.postMessage",
+			duration: time.Second,
+		},
+		{
+			name:       "slack.reactions.add",
+			duration:   2 * time.Second,
+			retryState: enums.RETRY_STATE_NON_RETRYABLE_FAILURE,
+			cause:      notFound(),
+			wantCode:   "message_not_found",
+		},
+		{
+			name:           "slack.users.info",
+			duration:       3 * time.Second,
+			retryState:     enums.RETRY_STATE_MAXIMUM_ATTEMPTS_REACHED,
+			cause:          rateLimited(),
+			wantStatusCode: 429,
+		},
+		{
+			name:       "slack.users.list",
+			duration:   4 * time.Second,
+			retryState: enums.RETRY_STATE_TIMEOUT,
+			cause:      temporal.NewTimeoutError(enums.TIMEOUT_TYPE_START_TO_CLOSE, nil),
+		},
+	}
+
+	got := map[string]interceptor.Result{}
+	runWorkflow(t, nil, func(ctx workflow.Context) error {
+		// Concurrent executions, each of them with its own observer coroutine.
+		for _, tt := range tests {
+			var err error
+			if tt.cause != nil {
+				err = activityError(t, tt.name, tt.retryState, tt.cause)
+			}
+			call := &interceptor.Call{Name: tt.name, Service: "slack"}
+			interceptor.Observe(ctx, call, invoker(tt.duration, err), func(_ workflow.Context, call *interceptor.Call, r interceptor.Result) {
+				got[call.Name] = r
+			})
+		}
+
+		// Observers run in separate coroutines, after the activities complete.
+		return workflow.Await(ctx, func() bool { return len(got) == len(tests) })
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := got[tt.name]
+			if r.Duration != tt.duration {
+				t.Errorf("Result.Duration = %v, want %v", r.Duration, tt.duration)
+			}
+			if (r.Err != nil) != (tt.cause != nil) {
+				t.Errorf("Result.Err = %v, want %v", r.Err, tt.cause)
+			}
+			if r.RetryState != tt.retryState {
+				t.Errorf("Result.RetryState = %v, want %v", r.RetryState, tt.retryState)
+			}
+			if r.Code != tt.wantCode {
+				t.Errorf("Result.Code = %q, want %q", r.Code, tt.wantCode)
+			}
+			if r.StatusCode != tt.wantStatusCode {
+				t.Errorf("Result.StatusCode = %d, want %d", r.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+
+	// Errors are converted into typed errors when possible.
+	if err := got["slack.reactions.add"].Err; !errors.IsNotFound(err) {
+		t.Errorf("Result.Err = %v, want a not-found error", err)
+	}
+}
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"go.temporal.io/sdk/workflow"
+)
+
+// Logging returns an interceptor which writes a structured log entry for every
+// Timpani activity execution, with [workflow.GetLogger]: at the debug level
+// for successful executions, and at the warning level for failures.
+func Logging() Interceptor {
+	return func(ctx workflow.Context, call *Call, next Invoker) workflow.Future {
+		return Observe(ctx, call, next, func(ctx workflow.Context, call *Call, r Result) {
+			kv := []any{"activity", call.Name, "service", call.Service, "duration", r.Duration}
+
+			if r.Err == nil {
+				workflow.GetLogger(ctx).Debug("Timpani activity completed", kv...)
+				return
+			}
+
+			if r.Code != "" {
+				kv = append(kv, "error_code", r.Code)
+			}
+			if r.StatusCode != 0 {
+				kv = append(kv, "status_code", r.StatusCode)
+			}
+			kv = append(kv, "retry_state", r.RetryState.String(), "error", r.Err)
+			workflow.GetLogger(ctx).Warn("Timpani activity failed", kv...)
+		})
+	}
+}
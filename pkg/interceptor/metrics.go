@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"strconv"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+//revive:disable:exported
+const (
+	MetricCalls   = "timpani_activity_calls"
+	MetricErrors  = "timpani_activity_errors"
+	MetricLatency = "timpani_activity_latency"
+) //revive:enable:exported
+
+// Metrics returns an interceptor which records counters and timers for every
+// Timpani activity execution, with [workflow.GetMetricsHandler]. All of them
+// are tagged with the activity's name and service, and errors are also
+// tagged with the upstream error code or HTTP status code, if known, and
+// with the reason that Temporal stopped retrying (see [Result.RetryState]),
+// e.g. to alert on activities that exhaust their maximum attempts.
+func Metrics() Interceptor {
+	return func(ctx workflow.Context, call *Call, next Invoker) workflow.Future {
+		return Observe(ctx, call, next, func(ctx workflow.Context, call *Call, r Result) {
+			h := workflow.GetMetricsHandler(ctx).WithTags(map[string]string{
+				"activity": call.Name,
+				"service":  call.Service,
+			})
+
+			h.Counter(MetricCalls).Inc(1)
+			h.Timer(MetricLatency).Record(r.Duration)
+
+			if r.Err != nil {
+				code := r.Code
+				if code == "" && r.StatusCode != 0 {
+					code = strconv.Itoa(r.StatusCode)
+				}
+				if code == "" {
+					code = "unknown"
+				}
+				tags := map[string]string{"error_code": code, "retry_state": r.RetryState.String()}
+				h.WithTags(tags).Counter(MetricErrors).Inc(1)
+			}
+		})
+	}
+}
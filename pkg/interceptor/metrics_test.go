@@ -0,0 +1,128 @@
+package interceptor_test
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/interceptor"
+)
+
+func TestMetrics(t *testing.T) {
+	h := &metricsHandler{records: &records{}}
+	s := new(testsuite.WorkflowTestSuite)
+	s.SetMetricsHandler(h)
+
+	calls := []struct {
+		name string
+		err  error
+	}{
+		{name: "slack.chat.postMessage"},
+		{name: "slack.reactions.add", err: activityError(t, "slack.reactions.add", enums.RETRY_STATE_NON_RETRYABLE_FAILURE, notFound())},
+		{name: "slack.users.info", err: activityError(t, "slack.users.info", enums.RETRY_STATE_MAXIMUM_ATTEMPTS_REACHED, rateLimited())},
+	}
+
+	runWorkflow(t, s, func(ctx workflow.Context) error {
+		metrics := interceptor.Metrics()
+		for _, c := range calls {
+			call := &interceptor.Call{Name: c.name, Service: "slack"}
+			_ = metrics(ctx, call, invoker(time.Second, c.err)).Get(ctx, nil)
+		}
+
+		// Metrics are recorded in separate coroutines, after the activities complete.
+		return workflow.Await(ctx, func() bool { return len(h.records.get(interceptor.MetricCalls)) == len(calls) })
+	})
+
+	post := map[string]string{"activity": "slack.chat.postMessage", "service": "slack"}
+	react := map[string]string{"activity": "slack.reactions.add", "service": "slack"}
+	users := map[string]string{"activity": "slack.users.info", "service": "slack"}
+
+	if got, want := h.records.get(interceptor.MetricCalls), []record{{post, 1}, {react, 1}, {users, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", interceptor.MetricCalls, got, want)
+	}
+
+	sec := int64(time.Second)
+	if got, want := h.records.get(interceptor.MetricLatency), []record{{post, sec}, {react, sec}, {users, sec}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", interceptor.MetricLatency, got, want)
+	}
+
+	react = with(react, map[string]string{"error_code": "message_not_found", "retry_state": "NonRetryableFailure"})
+	users = with(users, map[string]string{"error_code": "429", "retry_state": "MaximumAttemptsReached"})
+	if got, want := h.records.get(interceptor.MetricErrors), []record{{react, 1}, {users, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("%s = %v, want %v", interceptor.MetricErrors, got, want)
+	}
+}
+
+// with returns a copy of tags, with more tags added to it.
+func with(tags, more map[string]string) map[string]string {
+	merged := make(map[string]string, len(tags)+len(more))
+	maps.Copy(merged, tags)
+	maps.Copy(merged, more)
+	return merged
+}
+
+type record struct {
+	tags  map[string]string
+	value int64
+}
+
+// records of Timpani metrics, by metric name.
+type records struct {
+	mu sync.Mutex
+	m  map[string][]record
+}
+
+func (r *records) add(name string, tags map[string]string, value int64) {
+	if !strings.HasPrefix(name, "timpani_") {
+		return // Ignore the Temporal SDK's own metrics.
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.m == nil {
+		r.m = map[string][]record{}
+	}
+	r.m[name] = append(r.m[name], record{tags: tags, value: value})
+}
+
+func (r *records) get(name string) []record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.m[name]
+}
+
+// metricsHandler is a [client.MetricsHandler] which records all metrics, with their tags.
+type metricsHandler struct {
+	tags    map[string]string
+	records *records
+}
+
+func (h *metricsHandler) WithTags(tags map[string]string) client.MetricsHandler {
+	return &metricsHandler{tags: with(h.tags, tags), records: h.records}
+}
+
+func (h *metricsHandler) Counter(name string) client.MetricsCounter {
+	return metric(func(v int64) { h.records.add(name, h.tags, v) })
+}
+
+func (*metricsHandler) Gauge(string) client.MetricsGauge {
+	return metric(func(int64) {})
+}
+
+func (h *metricsHandler) Timer(name string) client.MetricsTimer {
+	return metric(func(v int64) { h.records.add(name, h.tags, v) })
+}
+
+type metric func(int64)
+
+func (m metric) Inc(v int64)            { m(v) }
+func (m metric) Update(v float64)       { m(int64(v)) }
+func (m metric) Record(d time.Duration) { m(int64(d)) }
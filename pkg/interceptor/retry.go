@@ -0,0 +1,18 @@
+package interceptor
+
+import (
+	"errors"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+// retryState returns the reason that Temporal stopped retrying a failed activity. Unlike
+// the number of attempts, Temporal reports it to workflows in every activity failure.
+func retryState(err error) enums.RetryState {
+	var actErr *temporal.ActivityError
+	if !errors.As(err, &actErr) {
+		return enums.RETRY_STATE_UNSPECIFIED
+	}
+	return actErr.RetryState()
+}
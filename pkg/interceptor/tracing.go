@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TraceHeaderKey is the Temporal header which carries the trace context of Timpani
+// activities. It is the same as the default in Temporal's OpenTelemetry tracing
+// interceptor, so the Timpani worker can use the caller's span as a parent.
+const TraceHeaderKey = "_tracer-data"
+
+type traceKey struct{}
+
+// Tracing returns an interceptor which propagates the trace context of the calling
+// workflow to every Timpani activity execution, in the [TraceHeaderKey] header.
+//
+// The inject function adds the current span context to the carrier, e.g. with
+// OpenTelemetry's "propagation.TextMapPropagator.Inject" and "propagation.MapCarrier".
+//
+// This requires registering [TracePropagator] in the Temporal client's options. It is
+// not needed if the Temporal worker already uses Temporal's own tracing interceptor.
+func Tracing(inject func(ctx workflow.Context, call *Call, carrier map[string]string)) Interceptor {
+	return func(ctx workflow.Context, call *Call, next Invoker) workflow.Future {
+		carrier := map[string]string{}
+		inject(ctx, call, carrier)
+		if len(carrier) > 0 {
+			ctx = workflow.WithValue(ctx, traceKey{}, carrier)
+		}
+		return next(ctx, call)
+	}
+}
+
+// TracePropagator returns a Temporal context propagator which writes the
+// trace context that [Tracing] adds to workflow contexts in activity headers.
+// Register it in the "ContextPropagators" field of the Temporal client's options.
+func TracePropagator() workflow.ContextPropagator {
+	return tracePropagator{}
+}
+
+type tracePropagator struct{}
+
+func (tracePropagator) Inject(context.Context, workflow.HeaderWriter) error {
+	return nil
+}
+
+func (tracePropagator) Extract(ctx context.Context, _ workflow.HeaderReader) (context.Context, error) {
+	return ctx, nil
+}
+
+func (tracePropagator) InjectFromWorkflow(ctx workflow.Context, hw workflow.HeaderWriter) error {
+	carrier, ok := ctx.Value(traceKey{}).(map[string]string)
+	if !ok {
+		return nil
+	}
+
+	payload, err := converter.GetDefaultDataConverter().ToPayload(carrier)
+	if err != nil {
+		return err
+	}
+
+	hw.Set(TraceHeaderKey, payload)
+	return nil
+}
+
+func (tracePropagator) ExtractToWorkflow(ctx workflow.Context, _ workflow.HeaderReader) (workflow.Context, error) {
+	return ctx, nil
+}
@@ -0,0 +1,77 @@
+package interceptor_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/interceptor"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+)
+
+func TestTracing(t *testing.T) {
+	register(t, interceptor.Tracing(func(_ workflow.Context, call *interceptor.Call, carrier map[string]string) {
+		if call.Name == slack.ChatPostMessageActivityName {
+			carrier["traceparent"] = "00-trace-span-01"
+		}
+	}))
+
+	p := &headerCapture{}
+	s := new(testsuite.WorkflowTestSuite)
+	s.SetContextPropagators([]workflow.ContextPropagator{interceptor.TracePropagator(), p})
+
+	runWorkflow(t, s, func(ctx workflow.Context) error {
+		if _, err := slack.ChatPostMessage(ctx, slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"}); err != nil {
+			return err
+		}
+		_, err := slack.ConversationsHistory(ctx, channelID, "", "")
+		return err
+	})
+
+	// Only the first activity has a trace context.
+	want := []map[string]string{{"traceparent": "00-trace-span-01"}}
+	if !reflect.DeepEqual(p.headers, want) {
+		t.Errorf("activity trace headers = %v, want %v", p.headers, want)
+	}
+}
+
+// headerCapture is a Temporal context propagator which
+// records the [interceptor.TraceHeaderKey] header of activities.
+type headerCapture struct {
+	mu      sync.Mutex
+	headers []map[string]string
+}
+
+func (*headerCapture) Inject(context.Context, workflow.HeaderWriter) error {
+	return nil
+}
+
+func (p *headerCapture) Extract(ctx context.Context, hr workflow.HeaderReader) (context.Context, error) {
+	payload, ok := hr.Get(interceptor.TraceHeaderKey)
+	if !ok {
+		return ctx, nil
+	}
+
+	var carrier map[string]string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &carrier); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.headers = append(p.headers, carrier)
+	return ctx, nil
+}
+
+func (*headerCapture) InjectFromWorkflow(workflow.Context, workflow.HeaderWriter) error {
+	return nil
+}
+
+func (*headerCapture) ExtractToWorkflow(ctx workflow.Context, _ workflow.HeaderReader) (workflow.Context, error) {
+	return ctx, nil
+}
@@ -0,0 +1,21 @@
+package jira
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+)
+
+// UsersGetAsync is an asynchronous version of [UsersGet].
+func UsersGetAsync(ctx workflow.Context, accountID string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersGet(ctx, accountID)
+	})
+}
+
+// UsersSearchActivityAsync is an asynchronous version of [UsersSearchActivity].
+func UsersSearchActivityAsync(ctx workflow.Context, query string) async.Future[[]User] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]User, error) {
+		return UsersSearchActivity(ctx, query)
+	})
+}
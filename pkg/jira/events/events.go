@@ -0,0 +1,130 @@
+// Package events provides typed payloads of [Jira webhooks], which the Timpani
+// worker sends to Temporal workflows as signals, and functions to receive them.
+//
+// [Jira webhooks]: https://developer.atlassian.com/cloud/jira/platform/webhooks/
+package events
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/jira"
+)
+
+// Signal is the name of a Temporal signal which carries Jira webhook events of type T.
+// Signals are named after the "webhookEvent" field, with the "jira.events." prefix.
+type Signal[T any] string
+
+//revive:disable:exported
+const (
+	IssueCreatedSignal Signal[IssueEvent] = "jira.events.jira:issue_created"
+	IssueUpdatedSignal Signal[IssueEvent] = "jira.events.jira:issue_updated"
+	IssueDeletedSignal Signal[IssueEvent] = "jira.events.jira:issue_deleted"
+
+	CommentCreatedSignal Signal[CommentEvent] = "jira.events.comment_created"
+	CommentUpdatedSignal Signal[CommentEvent] = "jira.events.comment_updated"
+	CommentDeletedSignal Signal[CommentEvent] = "jira.events.comment_deleted"
+) //revive:enable:exported
+
+// Receive waits for the next Jira webhook event in a Temporal signal which matches a
+// filter (all events match if the filter is nil), and returns it. Non-matching events are
+// kept for other receivers of the same signal in the same workflow, as described in
+// [internal.ReceiveSignal]. If the timeout is positive and expires first, it returns
+// [errors.ErrTimeout].
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func Receive[T any](ctx workflow.Context, s Signal[T], timeout time.Duration, filter func(*T) bool) (*T, error) {
+	return internal.ReceiveSignal(ctx, string(s), timeout, filter)
+}
+
+// IssueEvent is based on:
+// https://developer.atlassian.com/cloud/jira/platform/webhooks/#example-callback-for-an-issue-related-event
+type IssueEvent struct {
+	Timestamp          int64  `json:"timestamp"` // Unix epoch milliseconds.
+	WebhookEvent       string `json:"webhookEvent"`
+	IssueEventTypeName string `json:"issue_event_type_name,omitempty"` // E.g. "issue_created", "issue_assigned".
+
+	User      *jira.User `json:"user,omitempty"`
+	Issue     Issue      `json:"issue"`
+	Changelog *Changelog `json:"changelog,omitempty"` // Only in "jira:issue_updated" events.
+}
+
+// CommentEvent is based on:
+// https://developer.atlassian.com/cloud/jira/platform/webhooks/#example-callback-for-a-comment-related-event
+type CommentEvent struct {
+	Timestamp    int64  `json:"timestamp"` // Unix epoch milliseconds.
+	WebhookEvent string `json:"webhookEvent"`
+
+	Comment Comment `json:"comment"`
+	Issue   Issue   `json:"issue"`
+}
+
+// Changelog is based on:
+// https://developer.atlassian.com/cloud/jira/platform/webhooks/#example-callback-for-an-issue-related-event
+type Changelog struct {
+	ID    string `json:"id"`
+	Items []struct {
+		Field      string `json:"field"`
+		FieldType  string `json:"fieldtype"`
+		FieldID    string `json:"fieldId,omitempty"`
+		From       string `json:"from"`
+		FromString string `json:"fromString"`
+		To         string `json:"to"`
+		ToString   string `json:"toString"`
+	} `json:"items"`
+}
+
+// Comment is based on:
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issue-comments/#api-rest-api-3-comment-list-post
+type Comment struct {
+	ID   string `json:"id"`
+	Self string `json:"self"`
+	Body any    `json:"body"` // Plain text, or an Atlassian Document Format (ADF) object.
+
+	Author       *jira.User `json:"author,omitempty"`
+	UpdateAuthor *jira.User `json:"updateAuthor,omitempty"`
+	Created      string     `json:"created"`
+	Updated      string     `json:"updated"`
+}
+
+// Issue is based on:
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issues/#api-rest-api-3-issue-issueidorkey-get
+type Issue struct {
+	ID     string `json:"id"`
+	Self   string `json:"self"`
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description any    `json:"description,omitempty"` // Plain text, or an Atlassian Document Format (ADF) object.
+
+		IssueType *NamedObject `json:"issuetype,omitempty"`
+		Project   *Project     `json:"project,omitempty"`
+		Status    *NamedObject `json:"status,omitempty"`
+		Priority  *NamedObject `json:"priority,omitempty"`
+		Labels    []string     `json:"labels,omitempty"`
+
+		Assignee *jira.User `json:"assignee,omitempty"`
+		Reporter *jira.User `json:"reporter,omitempty"`
+		Creator  *jira.User `json:"creator,omitempty"`
+
+		Created string `json:"created"`
+		Updated string `json:"updated"`
+	} `json:"fields"`
+}
+
+// NamedObject is the common subset of issue types, statuses and priorities, based on:
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issues/#api-rest-api-3-issue-issueidorkey-get
+type NamedObject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Project is based on:
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-projects/#api-rest-api-3-project-projectidorkey-get
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
@@ -15,6 +15,8 @@ const (
 // UsersGetRequest is based on:
 // https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-users/#api-rest-api-3-user-get
 type UsersGetRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	AccountID string `json:"account_id"`
 }
 
@@ -32,6 +34,8 @@ func UsersGet(ctx workflow.Context, accountID string) (*User, error) {
 // UsersSearchRequest is based on:
 // https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-user-search/#api-rest-api-3-user-search-get
 type UsersSearchRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Query string `json:"query"`
 }
 
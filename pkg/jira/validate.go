@@ -0,0 +1,24 @@
+package jira
+
+import (
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Client-side validation of Jira requests, based on the "Request" section of each
+// endpoint in https://developer.atlassian.com/cloud/jira/platform/rest/v3/.
+// Validation failures are non-retryable, and are reported before sending
+// requests to the Timpani worker, instead of by Jira after several retries.
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersGetRequest) Validate() error {
+	return internal.NewValidator(UsersGetActivityName).
+		Require("account_id", r.AccountID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersSearchRequest) Validate() error {
+	return internal.NewValidator(UsersSearchActivityName).
+		Require("query", r.Query).
+		Err()
+}
@@ -0,0 +1,282 @@
+package markup
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// adfNode is based on:
+// https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/
+type adfNode struct {
+	Type    string         `json:"type"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []*adfNode     `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []*adfMark     `json:"marks,omitempty"`
+}
+
+// adfMark is based on:
+// https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/#marks
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// adfDocument is the root node of ADF documents, which always has a version and content.
+type adfDocument struct {
+	Version int        `json:"version"`
+	Type    string     `json:"type"`
+	Content []*adfNode `json:"content"`
+}
+
+// parseADF parses a JSON-encoded ADF document, or a single ADF node.
+// Unsupported nodes (e.g. panels and tables) are replaced by their contents,
+// and nodes without text contents (e.g. media) are ignored.
+//
+// Some Jira fields may contain plain text instead of ADF documents,
+// so JSON strings are also accepted, and parsed as plain text.
+func parseADF(s string) (*Node, error) {
+	var text string
+	if err := json.Unmarshal([]byte(s), &text); err == nil {
+		return parsePlain(text), nil
+	}
+
+	n := new(adfNode)
+	if err := json.Unmarshal([]byte(s), n); err != nil {
+		return nil, fmt.Errorf("invalid ADF document: %w", err)
+	}
+
+	nodes := []*adfNode{n}
+	if n.Type == "doc" {
+		nodes = n.Content
+	}
+
+	var children []*Node
+	for _, c := range nodes {
+		children = append(children, fromADF(c)...)
+	}
+	return &Node{Kind: Document, Children: blocks(children)}, nil
+}
+
+func fromADF(n *adfNode) []*Node {
+	var children []*Node
+	for _, c := range n.Content {
+		children = append(children, fromADF(c)...)
+	}
+
+	switch n.Type {
+	case "paragraph":
+		return []*Node{{Kind: Paragraph, Children: children}}
+	case "heading":
+		return []*Node{{Kind: Heading, Level: min(max(adfInt(n.Attrs, "level"), 1), 6), Children: children}}
+	case "blockquote":
+		return []*Node{{Kind: Quote, Children: blocks(children)}}
+	case "bulletList", "orderedList":
+		return []*Node{{Kind: List, Ordered: n.Type == "orderedList", Children: children}}
+	case "listItem":
+		return []*Node{{Kind: ListItem, Children: blocks(children)}}
+	case "codeBlock":
+		return []*Node{{Kind: CodeBlock, Lang: adfString(n.Attrs, "language"), Text: PlainText(&Node{Kind: Paragraph, Children: children})}}
+	case "rule":
+		return []*Node{{Kind: Rule}}
+	case "text":
+		return []*Node{adfText(n)}
+	case "hardBreak":
+		return []*Node{{Kind: LineBreak}}
+	case "mention":
+		return []*Node{{Kind: Mention, ID: adfString(n.Attrs, "id"), Text: strings.TrimPrefix(adfString(n.Attrs, "text"), "@")}}
+	case "emoji":
+		return []*Node{{Kind: Text, Text: cmp.Or(adfString(n.Attrs, "text"), adfString(n.Attrs, "shortName"))}}
+	case "status":
+		return []*Node{{Kind: Text, Text: adfString(n.Attrs, "text")}}
+	case "date":
+		ms, err := strconv.ParseInt(adfString(n.Attrs, "timestamp"), 10, 64)
+		if err != nil {
+			return nil
+		}
+		return []*Node{{Kind: Text, Text: time.UnixMilli(ms).UTC().Format(time.DateOnly)}}
+	case "inlineCard", "blockCard", "embedCard":
+		u := adfString(n.Attrs, "url")
+		if u == "" {
+			return nil
+		}
+		return []*Node{{Kind: Link, URL: u, Children: []*Node{{Kind: Text, Text: u}}}}
+	default:
+		return children
+	}
+}
+
+// adfText converts an ADF text node into a [Text] or [Code] node,
+// which is nested in nodes that represent the text's marks.
+func adfText(n *adfNode) *Node {
+	t := &Node{Kind: Text, Text: n.Text}
+	if slices.ContainsFunc(n.Marks, func(m *adfMark) bool { return m.Type == "code" }) {
+		t.Kind = Code
+	}
+
+	for _, m := range slices.Backward(n.Marks) {
+		switch m.Type {
+		case "strong":
+			t = &Node{Kind: Bold, Children: []*Node{t}}
+		case "em":
+			t = &Node{Kind: Italic, Children: []*Node{t}}
+		case "strike":
+			t = &Node{Kind: Strike, Children: []*Node{t}}
+		case "link":
+			t = &Node{Kind: Link, URL: adfString(m.Attrs, "href"), Children: []*Node{t}}
+		}
+	}
+	return t
+}
+
+func adfString(attrs map[string]any, key string) string {
+	s, _ := attrs[key].(string)
+	return s
+}
+
+func adfInt(attrs map[string]any, key string) int {
+	f, _ := attrs[key].(float64)
+	return int(f)
+}
+
+// adfRenderer renders document trees in ADF.
+type adfRenderer struct {
+	opts *Options
+}
+
+func (r *adfRenderer) document(doc *Node) *adfDocument {
+	return &adfDocument{Version: 1, Type: "doc", Content: r.blocks(doc.Children)}
+}
+
+func (r *adfRenderer) blocks(nodes []*Node) []*adfNode {
+	content := []*adfNode{}
+	for _, n := range blocks(nodes) {
+		if b := r.block(n); b != nil {
+			content = append(content, b)
+		}
+	}
+	return content
+}
+
+func (r *adfRenderer) block(n *Node) *adfNode {
+	switch n.Kind {
+	case Paragraph:
+		return &adfNode{Type: "paragraph", Content: r.inline(n.Children, nil)}
+	case Heading:
+		attrs := map[string]any{"level": min(max(n.Level, 1), 6)}
+		return &adfNode{Type: "heading", Attrs: attrs, Content: r.inline(n.Children, nil)}
+	case Quote:
+		return &adfNode{Type: "blockquote", Content: r.nonEmpty(r.blocks(unquote(n.Children)))}
+	case List:
+		list := &adfNode{Type: "bulletList"}
+		if n.Ordered {
+			list.Type = "orderedList"
+		}
+		for _, item := range items(n) {
+			list.Content = append(list.Content, &adfNode{Type: "listItem", Content: r.nonEmpty(r.blocks(item.Children))})
+		}
+		if len(list.Content) == 0 {
+			return nil
+		}
+		return list
+	case CodeBlock:
+		code := &adfNode{Type: "codeBlock"}
+		if n.Lang != "" {
+			code.Attrs = map[string]any{"language": n.Lang}
+		}
+		if n.Text != "" {
+			code.Content = []*adfNode{{Type: "text", Text: n.Text}}
+		}
+		return code
+	case Rule:
+		return &adfNode{Type: "rule"}
+	default:
+		return nil
+	}
+}
+
+// unquote replaces nested quotes with their contents, because ADF doesn't allow them.
+func unquote(nodes []*Node) []*Node {
+	var out []*Node
+	for _, n := range nodes {
+		if n.Kind == Quote {
+			out = append(out, unquote(n.Children)...)
+		} else {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// nonEmpty ensures that ADF nodes which must contain blocks (e.g.
+// list items) are valid, by adding an empty paragraph if necessary.
+func (r *adfRenderer) nonEmpty(content []*adfNode) []*adfNode {
+	if len(content) == 0 {
+		return []*adfNode{{Type: "paragraph"}}
+	}
+	return content
+}
+
+// inline renders inline nodes as ADF text nodes with the given marks. ADF doesn't
+// have nested inline nodes, so the marks of nested nodes are accumulated instead.
+func (r *adfRenderer) inline(nodes []*Node, marks []*adfMark) []*adfNode {
+	var content []*adfNode
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text:
+			if n.Text != "" {
+				content = append(content, &adfNode{Type: "text", Text: n.Text, Marks: marks})
+			}
+		case Code:
+			// Code marks can be combined only with link marks.
+			if n.Text != "" {
+				ms := slices.DeleteFunc(slices.Clone(marks), func(m *adfMark) bool { return m.Type != "link" })
+				content = append(content, &adfNode{Type: "text", Text: n.Text, Marks: append(ms, &adfMark{Type: "code"})})
+			}
+		case Bold:
+			content = append(content, r.inline(n.Children, withMark(marks, &adfMark{Type: "strong"}))...)
+		case Italic:
+			content = append(content, r.inline(n.Children, withMark(marks, &adfMark{Type: "em"}))...)
+		case Strike:
+			content = append(content, r.inline(n.Children, withMark(marks, &adfMark{Type: "strike"}))...)
+		case Link:
+			if !safeURL(n.URL) {
+				content = append(content, r.inline(n.Children, marks)...)
+				continue
+			}
+			link := &adfMark{Type: "link", Attrs: map[string]any{"href": n.URL}}
+			children := n.Children
+			if len(children) == 0 {
+				children = []*Node{{Kind: Text, Text: linkText(n.URL)}}
+			}
+			content = append(content, r.inline(children, withMark(marks, link))...)
+		case Mention:
+			id, ok := r.opts.mention(n)
+			if !ok {
+				content = append(content, &adfNode{Type: "text", Text: mentionText(n), Marks: marks})
+				continue
+			}
+			attrs := map[string]any{"id": id}
+			if n.Text != "" {
+				attrs["text"] = mentionText(n)
+			}
+			content = append(content, &adfNode{Type: "mention", Attrs: attrs})
+		case LineBreak:
+			content = append(content, &adfNode{Type: "hardBreak"})
+		default:
+			content = append(content, r.inline(n.Children, marks)...)
+		}
+	}
+	return content
+}
+
+// withMark returns a new list of marks, without duplicates of the new mark's type.
+func withMark(marks []*adfMark, m *adfMark) []*adfMark {
+	ms := slices.DeleteFunc(slices.Clone(marks), func(old *adfMark) bool { return old.Type == m.Type })
+	return append(ms, m)
+}
@@ -0,0 +1,569 @@
+package markup
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mdFence   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^ \t`]*)")
+	mdHeading = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdRule    = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdQuote   = regexp.MustCompile(`^ {0,3}> ?`)
+	mdItem    = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+
+	githubMention    = regexp.MustCompile(`^@([A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38}(?:/[A-Za-z0-9][A-Za-z0-9_.-]*)?)`)
+	bitbucketMention = regexp.MustCompile(`^@\{([^{}\s]+)\}`)
+)
+
+// parseMarkdown parses the subset of GitHub and Bitbucket Markdown which is described in
+// the package documentation. Unsupported syntax (e.g. tables and HTML) is parsed as text.
+func parseMarkdown(s string, f Format) *Node {
+	return &Node{Kind: Document, Children: mdBlocks(splitLines(s), f)}
+}
+
+func mdBlocks(lines []string, f Format) []*Node {
+	var nodes []*Node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case mdFence.MatchString(line):
+			var n *Node
+			n, i = mdCodeBlock(lines, i)
+			nodes = append(nodes, n)
+		case mdHeading.MatchString(line):
+			m := mdHeading.FindStringSubmatch(line)
+			nodes = append(nodes, &Node{Kind: Heading, Level: len(m[1]), Children: mdInline(m[2], f)})
+			i++
+		case mdRule.MatchString(line):
+			nodes = append(nodes, &Node{Kind: Rule})
+			i++
+		case mdQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, lines[i][len(mdQuote.FindString(lines[i])):])
+			}
+			nodes = append(nodes, &Node{Kind: Quote, Children: mdBlocks(quoted, f)})
+		case mdItem.MatchString(line):
+			var n *Node
+			n, i = mdList(lines, i, f)
+			nodes = append(nodes, n)
+		default:
+			var para []string
+			for ; i < len(lines) && !isBlank(lines[i]) && (len(para) == 0 || !mdBlockStart(lines[i])); i++ {
+				para = append(para, lines[i])
+			}
+			nodes = append(nodes, paragraph(para, func(l string) []*Node {
+				return mdInline(strings.TrimSuffix(strings.TrimSpace(l), "\\"), f)
+			}))
+		}
+	}
+	return nodes
+}
+
+func mdBlockStart(line string) bool {
+	return mdFence.MatchString(line) || mdHeading.MatchString(line) || mdRule.MatchString(line) ||
+		mdQuote.MatchString(line) || mdItem.MatchString(line)
+}
+
+func mdCodeBlock(lines []string, i int) (*Node, int) {
+	m := mdFence.FindStringSubmatch(lines[i])
+	indent, fence := len(m[1]), m[2]
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		if t := strings.TrimSpace(lines[i]); strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, trimIndent(lines[i], indent))
+	}
+
+	return &Node{Kind: CodeBlock, Lang: m[3], Text: strings.Join(code, "\n")}, i
+}
+
+// mdList parses consecutive list items of the same type (ordered or unordered). Each item
+// contains its first line, all the following lines which are indented to the item's content
+// (including nested lists), and "lazy" paragraph continuation lines.
+func mdList(lines []string, i int, f Format) (*Node, int) {
+	ordered := isDigit(mdItem.FindStringSubmatch(lines[i])[2][0])
+	list := &Node{Kind: List, Ordered: ordered}
+
+	for i < len(lines) {
+		j := i
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j == len(lines) {
+			break
+		}
+		m := mdItem.FindStringSubmatch(lines[j])
+		if m == nil || isDigit(m[2][0]) != ordered {
+			break
+		}
+
+		width := len(m[0])
+		if m[3] == "" {
+			width++
+		}
+		item := []string{lines[j][len(m[0]):]}
+
+		for i = j + 1; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				// Blank lines end the item, unless the next non-blank line is indented into it.
+				k := i
+				for k < len(lines) && isBlank(lines[k]) {
+					k++
+				}
+				if k == len(lines) || indentOf(lines[k]) < width {
+					break
+				}
+				item = append(item, "")
+				continue
+			}
+			if indentOf(line) >= width {
+				item = append(item, trimIndent(line, width))
+				continue
+			}
+			if mdBlockStart(line) {
+				break
+			}
+			item = append(item, strings.TrimSpace(line))
+		}
+
+		list.Children = append(list.Children, &Node{Kind: ListItem, Children: mdBlocks(item, f)})
+	}
+
+	return list, i
+}
+
+// mdInline parses inline Markdown: code spans, emphasis, links,
+// autolinks, bare URLs, user mentions, and backslash escapes.
+func mdInline(s string, f Format) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &Node{Kind: Text, Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+
+		if n, end := mdToken(s, i, f); n != nil {
+			flush()
+			nodes = append(nodes, n)
+			i = end
+			continue
+		}
+
+		n := 1
+		if strings.IndexByte("`*_~", c) >= 0 {
+			n = runLen(s, i, c) // Unmatched delimiters are text.
+		}
+		text.WriteString(s[i : i+n])
+		i += n
+	}
+
+	flush()
+	return nodes
+}
+
+// mdToken parses a single inline Markdown element, if there is one at index i.
+// It returns the element's node, and the index of the text after it.
+func mdToken(s string, i int, f Format) (*Node, int) {
+	switch s[i] {
+	case '`':
+		return mdCode(s, i)
+	case '*', '_', '~':
+		return mdEmphasis(s, i, f)
+	case '[':
+		return mdLink(s, i, f)
+	case '<':
+		return mdAutolink(s, i)
+	case '@':
+		return mdMention(s, i, f)
+	case 'h', 'H':
+		return bareURL(s, i)
+	default:
+		return nil, 0
+	}
+}
+
+// mdCode parses a code span, which is delimited by backtick runs of the same length.
+func mdCode(s string, i int) (*Node, int) {
+	n := runLen(s, i, '`')
+	for j := i + n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		run := runLen(s, j, '`')
+		if run != n {
+			j += run
+			continue
+		}
+
+		code := s[i+n : j]
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && !isBlank(code) {
+			code = code[1 : len(code)-1]
+		}
+		return &Node{Kind: Code, Text: code}, j + n
+	}
+	return nil, 0
+}
+
+// mdEmphasis parses bold ("**" or "__"), italic ("*" or "_") and strikethrough ("~~" or "~") text.
+// A triple delimiter ("***") is parsed as bold text which starts and ends with italic text.
+func mdEmphasis(s string, i int, f Format) (*Node, int) {
+	c := s[i]
+	n := runLen(s, i, c)
+	switch {
+	case c == '~' && n > 2, c != '~' && n > 3:
+		return nil, 0
+	case c != '~' && n == 3:
+		n = 2
+	}
+
+	delim := s[i : i+n]
+	if !mdCanOpen(s, i, n) {
+		return nil, 0
+	}
+	end := mdFindClose(s, i+n, delim)
+	if end < 0 {
+		return nil, 0
+	}
+
+	kind := Italic
+	switch {
+	case c == '~':
+		kind = Strike
+	case n == 2:
+		kind = Bold
+	}
+	return &Node{Kind: kind, Children: mdInline(s[i+n:end], f)}, end + n
+}
+
+func mdCanOpen(s string, i, n int) bool {
+	if i+n >= len(s) || isSpace(s[i+n]) {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isAlnum(s[i-1])
+}
+
+func mdCanClose(s string, j, n int) bool {
+	if isSpace(s[j-1]) {
+		return false
+	}
+	return s[j] != '_' || j+n == len(s) || !isAlnum(s[j+n])
+}
+
+// mdFindClose returns the index of the delimiter which closes an emphasis span
+// that starts at index i, or -1 if there isn't one. It skips escaped characters
+// and code spans, and in case of a longer run of delimiters it uses its end.
+func mdFindClose(s string, i int, delim string) int {
+	start, c := i, delim[0]
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '`':
+			if _, end := mdCode(s, i); end > 0 {
+				i = end
+				continue
+			}
+		}
+		if s[i] != c {
+			i++
+			continue
+		}
+
+		n := runLen(s, i, c)
+		j := i + n - len(delim)
+		if (n == len(delim) || len(delim) == 2 && n == 3) && j > start && mdCanClose(s, j, len(delim)) {
+			return j
+		}
+		i += n
+	}
+	return -1
+}
+
+// mdLink parses an inline link: "[text](url)" or "[text](url "title")".
+func mdLink(s string, i int, f Format) (*Node, int) {
+	depth, j := 0, i
+loop:
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				break loop
+			}
+		}
+	}
+	if j+1 >= len(s) || s[j+1] != '(' {
+		return nil, 0
+	}
+	label := s[i+1 : j]
+
+	k := j + 2
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+	var u string
+	if k < len(s) && s[k] == '<' {
+		end := strings.IndexByte(s[k:], '>')
+		if end < 0 {
+			return nil, 0
+		}
+		u, k = s[k+1:k+end], k+end+1
+	} else {
+		start, parens := k, 0
+		for ; k < len(s) && !isSpace(s[k]); k++ {
+			if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		u = s[start:k]
+	}
+
+	// Skip the optional title.
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+	if k < len(s) && (s[k] == '"' || s[k] == '\'') {
+		end := strings.IndexByte(s[k+1:], s[k])
+		if end < 0 {
+			return nil, 0
+		}
+		k += end + 2
+		for k < len(s) && isSpace(s[k]) {
+			k++
+		}
+	}
+	if k >= len(s) || s[k] != ')' {
+		return nil, 0
+	}
+
+	return &Node{Kind: Link, URL: u, Children: mdInline(label, f)}, k + 1
+}
+
+// mdAutolink parses a URL or an email address in angle brackets.
+func mdAutolink(s string, i int) (*Node, int) {
+	end := strings.IndexByte(s[i:], '>')
+	if end < 0 {
+		return nil, 0
+	}
+
+	u := s[i+1 : i+end]
+	if strings.ContainsAny(u, " \t<") {
+		return nil, 0
+	}
+	if !safeURL(u) {
+		if !strings.Contains(u, "@") || strings.Contains(u, ":") {
+			return nil, 0
+		}
+		u = "mailto:" + u
+	}
+
+	return &Node{Kind: Link, URL: u, Children: []*Node{{Kind: Text, Text: linkText(u)}}}, i + end + 1
+}
+
+// mdMention parses a user mention: "@login" or "@org/team" in
+// GitHub, and "@{account-id}" in Bitbucket. Email addresses
+// and other words which contain "@" are not mentions.
+func mdMention(s string, i int, f Format) (*Node, int) {
+	if i > 0 && (isWordByte(s[i-1]) || s[i-1] == '`') {
+		return nil, 0
+	}
+
+	re := githubMention
+	if f == Bitbucket {
+		re = bitbucketMention
+	}
+	m := re.FindStringSubmatch(s[i:])
+	if m == nil {
+		return nil, 0
+	}
+
+	return &Node{Kind: Mention, ID: m[1]}, i + len(m[0])
+}
+
+// mdRenderer renders document trees in GitHub or Bitbucket Markdown.
+type mdRenderer struct {
+	format    Format
+	opts      *Options
+	lineStart bool // Whether the next inline text is at the start of a line.
+}
+
+func (r *mdRenderer) blocks(nodes []*Node, sep string) string {
+	var out []string
+	for _, n := range blocks(nodes) {
+		if s := r.block(n); s != "" {
+			out = append(out, s)
+		}
+	}
+	return strings.Join(out, sep)
+}
+
+func (r *mdRenderer) block(n *Node) string {
+	switch n.Kind {
+	case Paragraph:
+		return r.inline(n.Children)
+	case Heading:
+		return strings.Repeat("#", min(max(n.Level, 1), 6)) + " " + r.inline(n.Children)
+	case Quote:
+		return prefixLines(r.blocks(n.Children, "\n\n"), "> ", "> ")
+	case List:
+		var out []string
+		for i, item := range items(n) {
+			marker := "- "
+			if n.Ordered {
+				marker = strconv.Itoa(i+1) + ". "
+			}
+			// Blocks in list items are separated by single line breaks, to keep lists tight.
+			s := r.blocks(item.Children, "\n")
+			out = append(out, prefixLines(s, marker, strings.Repeat(" ", len(marker))))
+		}
+		return strings.Join(out, "\n")
+	case CodeBlock:
+		fence := backtickFence(n.Text, 3)
+		return fence + n.Lang + "\n" + n.Text + "\n" + fence
+	case Rule:
+		return "---"
+	default:
+		return ""
+	}
+}
+
+func (r *mdRenderer) inline(nodes []*Node) string {
+	var b strings.Builder
+	r.lineStart = true
+	r.write(&b, nodes, 0)
+	return b.String()
+}
+
+// write renders inline nodes. Marks are the emphasis kinds of the
+// enclosing nodes, which are not repeated in nested nodes.
+func (r *mdRenderer) write(b *strings.Builder, nodes []*Node, marks uint) {
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text:
+			if n.Text != "" {
+				b.WriteString(mdEscape(n.Text, r.lineStart))
+				r.lineStart = false
+			}
+		case Bold, Italic, Strike:
+			delim := map[Kind]string{Bold: "**", Italic: "_", Strike: "~~"}[n.Kind]
+			if marks&(1<<n.Kind) != 0 {
+				delim = ""
+			}
+			var inner strings.Builder
+			r.write(&inner, n.Children, marks|1<<n.Kind)
+			b.WriteString(emphasize(inner.String(), delim, delim))
+		case Code:
+			fence := backtickFence(n.Text, 1)
+			code := n.Text
+			if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+				code = " " + code + " "
+			}
+			b.WriteString(fence + code + fence)
+			r.lineStart = false
+		case Link:
+			if !safeURL(n.URL) {
+				r.write(b, n.Children, marks)
+				continue
+			}
+			u := strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(n.URL)
+			if len(n.Children) == 0 || PlainText(&Node{Kind: Paragraph, Children: n.Children}) == linkText(n.URL) {
+				b.WriteString("<" + u + ">")
+				r.lineStart = false
+				continue
+			}
+			var label strings.Builder
+			r.write(&label, n.Children, marks)
+			b.WriteString(emphasize(label.String(), "[", "]("+u+")"))
+		case Mention:
+			id, ok := r.opts.mention(n)
+			r.lineStart = false
+			switch {
+			case !ok:
+				b.WriteString(mdEscape(mentionText(n), false))
+			case r.format == Bitbucket:
+				b.WriteString("@{" + id + "}")
+			default:
+				b.WriteString("@" + id)
+			}
+		case LineBreak:
+			if r.format == Bitbucket {
+				b.WriteString("  ") // Bitbucket ignores single line breaks without trailing spaces.
+			}
+			b.WriteString("\n")
+			r.lineStart = true
+		default:
+			r.write(b, n.Children, marks)
+		}
+	}
+}
+
+// mdEscape escapes all the characters in plain text which may be parsed as Markdown syntax.
+// Characters which are significant only at the start of a line (e.g. "#" and "-") are escaped
+// only there, and characters which are significant only at word boundaries (e.g. "_" and "@")
+// are escaped only there, to keep the escaped text readable.
+func mdEscape(s string, lineStart bool) string {
+	if lineStart {
+		s = strings.TrimLeft(s, " \t") // Leading spaces may start a code block.
+	}
+
+	var b strings.Builder
+	digits := lineStart
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		prev, next := byte(' '), byte(' ')
+		if i > 0 {
+			prev = s[i-1]
+		}
+		if i+1 < len(s) {
+			next = s[i+1]
+		}
+
+		switch {
+		case strings.IndexByte("\\`*[]<>|~", c) >= 0:
+			b.WriteByte('\\')
+		case c == '_' && (!isAlnum(prev) || !isAlnum(next)):
+			b.WriteByte('\\')
+		case c == '@' && !isWordByte(prev) && (isAlnum(next) || next == '{'):
+			b.WriteByte('\\')
+		case c == '&' && (isAlnum(next) || next == '#'):
+			b.WriteByte('\\')
+		case lineStart && i == 0 && strings.IndexByte("#-+=", c) >= 0:
+			b.WriteByte('\\')
+		case digits && i > 0 && (c == '.' || c == ')'):
+			b.WriteByte('\\')
+		}
+
+		digits = digits && isDigit(c)
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,372 @@
+// Package markup converts rich text between the markup formats of third-party
+// services: Slack mrkdwn, GitHub Markdown, Bitbucket Markdown, and Jira's
+// Atlassian Document Format (ADF). It doesn't execute any Timpani activities,
+// so it can be used anywhere, not only in Temporal workflows.
+//
+// Conversions parse the source format into a tree of [Node]s, which represents
+// the common subset of all the formats (paragraphs, headings, quotes, lists, code
+// blocks, emphasis, links and user mentions), and render it in the target format.
+// Text which isn't markup in the source format is escaped in the target format,
+// so user content can't inject markup (e.g. Slack broadcast mentions). The only
+// exception is emphasis characters in Slack mrkdwn, which can't be escaped:
+//
+//	text, err := markup.Convert(comment.Body, markup.GitHub, markup.Slack, &markup.Options{
+//		Mentions: func(login string) (string, bool) {
+//			// E.g. with an identity.Resolver, to map GitHub logins to Slack user IDs.
+//		},
+//	})
+package markup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format is a markup format of a third-party service.
+type Format string
+
+// Supported markup formats.
+const (
+	Slack     Format = "slack"     // Slack mrkdwn, e.g. in the "text" field of Slack messages.
+	GitHub    Format = "github"    // GitHub Flavored Markdown, e.g. in issue and PR comments.
+	Bitbucket Format = "bitbucket" // Bitbucket Markdown, e.g. in the "raw" field of [bitbucket.Rendered].
+	Jira      Format = "jira"      // Atlassian Document Format (ADF), as a JSON document.
+	Plain     Format = "plain"     // Plain text, without any markup.
+)
+
+// Kind is the kind of a [Node]. Block kinds contain other blocks (except paragraphs
+// and headings, which contain inline nodes), and inline kinds contain inline nodes.
+type Kind int
+
+//revive:disable:exported
+const (
+	// Blocks.
+	Document Kind = iota
+	Paragraph
+	Heading
+	Quote
+	List
+	ListItem
+	CodeBlock
+	Rule
+
+	// Inline nodes.
+	Text
+	Bold
+	Italic
+	Strike
+	Code
+	Link
+	Mention
+	LineBreak
+) //revive:enable:exported
+
+// Node is a single element in a format-independent document tree.
+type Node struct {
+	Kind     Kind
+	Children []*Node
+
+	Text    string // Of [Text], [Code] and [CodeBlock] nodes, and the display name (if known) of [Mention] nodes.
+	ID      string // Of [Mention] nodes, e.g. Slack user ID, GitHub login, or Bitbucket/Jira account ID.
+	URL     string // Of [Link] nodes.
+	Lang    string // Of [CodeBlock] nodes, if known.
+	Level   int    // Of [Heading] nodes, between 1 and 6.
+	Ordered bool   // Of [List] nodes.
+}
+
+// Options configure the rendering of documents.
+type Options struct {
+	// Mentions maps the ID of a user mention in the source format to the ID of the
+	// same user in the target format. If it returns false, the mention is rendered as
+	// plain text, which doesn't notify anyone. If it's nil, mention IDs are kept as-is
+	// in [Render], and mentions are rendered as plain text in cross-format [Convert] calls.
+	Mentions func(id string) (string, bool)
+}
+
+// Convert parses text in one markup format, and renders it in another.
+func Convert(s string, from, to Format, opts *Options) (string, error) {
+	doc, err := Parse(s, from)
+	if err != nil {
+		return "", err
+	}
+
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Mentions == nil && from != to {
+		o.Mentions = func(string) (string, bool) { return "", false }
+	}
+
+	return Render(doc, to, &o)
+}
+
+// Parse parses text in a specific markup format into a [Document] node.
+func Parse(s string, f Format) (*Node, error) {
+	switch f {
+	case Slack:
+		return parseSlack(s), nil
+	case GitHub, Bitbucket:
+		return parseMarkdown(s, f), nil
+	case Jira:
+		return parseADF(s)
+	case Plain:
+		return parsePlain(s), nil
+	default:
+		return nil, fmt.Errorf("unsupported markup format %q", f)
+	}
+}
+
+// Render renders a document tree in a specific markup format.
+func Render(doc *Node, f Format, opts *Options) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if doc.Kind != Document {
+		doc = &Node{Kind: Document, Children: []*Node{doc}}
+	}
+
+	switch f {
+	case Slack:
+		return (&slackRenderer{opts: opts}).blocks(doc.Children), nil
+	case GitHub, Bitbucket:
+		return (&mdRenderer{format: f, opts: opts}).blocks(doc.Children, "\n\n"), nil
+	case Jira:
+		b, err := json.Marshal((&adfRenderer{opts: opts}).document(doc))
+		if err != nil {
+			return "", fmt.Errorf("failed to encode ADF document: %w", err)
+		}
+		return string(b), nil
+	case Plain:
+		return (&plainRenderer{opts: opts}).blocks(doc.Children), nil
+	default:
+		return "", fmt.Errorf("unsupported markup format %q", f)
+	}
+}
+
+// PlainText returns the text of a node and all its descendants, without any markup.
+func PlainText(n *Node) string {
+	s, _ := Render(n, Plain, nil)
+	return s
+}
+
+// mention returns the target ID of a mention node, if it's mapped.
+func (o *Options) mention(n *Node) (string, bool) {
+	if o.Mentions == nil {
+		return n.ID, n.ID != ""
+	}
+	return o.Mentions(n.ID)
+}
+
+// mentionText is the plain-text representation of an unmapped user mention.
+func mentionText(n *Node) string {
+	if n.Text != "" {
+		return "@" + strings.TrimPrefix(n.Text, "@")
+	}
+	return "@" + n.ID
+}
+
+func isInline(k Kind) bool {
+	return k >= Text
+}
+
+// blocks returns the given nodes as blocks, wrapping runs of inline nodes in paragraphs
+// and list items in lists, so renderers can handle trees which aren't well-formed.
+func blocks(nodes []*Node) []*Node {
+	var bs []*Node
+	var para *Node
+	for _, n := range nodes {
+		if isInline(n.Kind) {
+			if para == nil {
+				para = &Node{Kind: Paragraph}
+				bs = append(bs, para)
+			}
+			para.Children = append(para.Children, n)
+			continue
+		}
+
+		para = nil
+		switch n.Kind {
+		case ListItem:
+			bs = append(bs, &Node{Kind: List, Children: []*Node{n}})
+		case Document:
+			bs = append(bs, blocks(n.Children)...)
+		default:
+			bs = append(bs, n)
+		}
+	}
+	return bs
+}
+
+// items returns the children of a list node as list items.
+func items(list *Node) []*Node {
+	is := make([]*Node, 0, len(list.Children))
+	for _, n := range list.Children {
+		if n.Kind != ListItem {
+			n = &Node{Kind: ListItem, Children: []*Node{n}}
+		}
+		is = append(is, n)
+	}
+	return is
+}
+
+// safeURL reports whether a link's URL is safe to render as a link.
+func safeURL(u string) bool {
+	u = strings.ToLower(u)
+	return strings.HasPrefix(u, "https://") || strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "mailto:")
+}
+
+// linkText returns the default text of a link, based on its URL.
+func linkText(u string) string {
+	return strings.TrimPrefix(u, "mailto:")
+}
+
+// bareURL parses an HTTP(S) URL in plain text, without trailing punctuation.
+func bareURL(s string, i int) (*Node, int) {
+	if i > 0 && isWordByte(s[i-1]) {
+		return nil, 0
+	}
+	rest := strings.ToLower(s[i:])
+	if !strings.HasPrefix(rest, "https://") && !strings.HasPrefix(rest, "http://") {
+		return nil, 0
+	}
+
+	end := i
+	for end < len(s) && !isSpace(s[end]) && s[end] != '<' {
+		end++
+	}
+	for end > i && (strings.IndexByte(".,:;!?'\"*_~", s[end-1]) >= 0 ||
+		s[end-1] == ')' && strings.Count(s[i:end], "(") < strings.Count(s[i:end], ")")) {
+		end--
+	}
+	if strings.HasSuffix(s[i:end], "//") {
+		return nil, 0
+	}
+
+	u := s[i:end]
+	return &Node{Kind: Link, URL: u, Children: []*Node{{Kind: Text, Text: u}}}, end
+}
+
+// emphasize encloses text with opening and closing delimiters, but keeps leading and trailing
+// whitespace outside of them, because delimiters which are adjacent to whitespace aren't parsed.
+func emphasize(s, opening, closing string) string {
+	t := strings.TrimSpace(s)
+	if t == "" || opening == "" {
+		return s
+	}
+	i := strings.Index(s, t)
+	return s[:i] + opening + t + closing + s[i+len(t):]
+}
+
+// paragraph returns a paragraph node, with line breaks between the given lines of text.
+func paragraph(lines []string, inline func(string) []*Node) *Node {
+	p := &Node{Kind: Paragraph}
+	for i, l := range lines {
+		if i > 0 {
+			p.Children = append(p.Children, &Node{Kind: LineBreak})
+		}
+		p.Children = append(p.Children, inline(l)...)
+	}
+	return p
+}
+
+// prefixLines adds a prefix to the first line of a block of text, and another
+// prefix to all the other lines (without trailing whitespace in empty lines).
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		p := rest
+		if i == 0 {
+			p = first
+		}
+		if l == "" {
+			p = strings.TrimRight(p, " ")
+		}
+		lines[i] = p + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isWordByte(c byte) bool {
+	return isAlnum(c) || c == '_'
+}
+
+func isPunct(c byte) bool {
+	return c > ' ' && c < 0x7f && !isAlnum(c)
+}
+
+// runLen returns the length of a run of the same character, starting at index i.
+func runLen(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// indentOf returns the width of a line's leading whitespace (tab = 4).
+func indentOf(s string) int {
+	n := 0
+	for _, c := range []byte(s) {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// trimIndent removes up to n columns of leading whitespace from a line (tab = 4).
+func trimIndent(s string, n int) string {
+	for n > 0 && s != "" {
+		switch s[0] {
+		case ' ':
+			n--
+		case '\t':
+			n -= 4
+		default:
+			return s
+		}
+		s = s[1:]
+	}
+	return s
+}
+
+// backtickFence returns the shortest run of backticks which is longer than any run in s.
+func backtickFence(s string, minLen int) string {
+	n, longest := 0, 0
+	for _, c := range []byte(s) {
+		if c == '`' {
+			n++
+			longest = max(longest, n)
+		} else {
+			n = 0
+		}
+	}
+	return strings.Repeat("`", max(longest+1, minLen))
+}
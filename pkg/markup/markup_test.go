@@ -0,0 +1,112 @@
+package markup_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tzrikka/timpani-api/pkg/markup"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+var formats = []markup.Format{markup.Slack, markup.GitHub, markup.Bitbucket, markup.Jira, markup.Plain}
+
+// section is the header of a section in a golden file: "-- input --"
+// for the source text, and "-- <format> --" for each conversion of it.
+var section = regexp.MustCompile(`(?m)^-- (\w+) --\n`)
+
+// mentions maps all user IDs in all the formats, except those of "ghost" users.
+var mentions = &markup.Options{
+	Mentions: func(id string) (string, bool) {
+		return "mapped-" + id, !strings.Contains(strings.ToLower(id), "ghost")
+	},
+}
+
+// TestConvert converts the input section of each golden file in testdata, which
+// is named "<case>.<source format>.golden", to all the formats, and compares the
+// results to the other sections in the same file. Run "go test -update" to
+// regenerate the outputs from the input sections after intentional changes.
+func TestConvert(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no golden files in testdata")
+	}
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".golden")
+		from := markup.Format(filepath.Ext(name)[1:])
+		if !slices.Contains(formats, from) {
+			t.Fatalf("%s: unsupported source format %q", path, from)
+		}
+
+		b, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sections := parseGolden(string(b))
+		input, ok := sections["input"]
+		if !ok {
+			t.Fatalf("%s: missing input section", path)
+		}
+
+		got := map[string]string{"input": input}
+		for _, to := range formats {
+			t.Run(name+"_to_"+string(to), func(t *testing.T) {
+				s, err := markup.Convert(input, from, to, mentions)
+				if err != nil {
+					t.Fatalf("Convert() error = %v", err)
+				}
+				got[string(to)] = s
+
+				if want, ok := sections[string(to)]; (ok || !*update) && s != want {
+					t.Errorf("Convert() = %q, want %q", s, want)
+				}
+			})
+		}
+
+		if *update {
+			if err := os.WriteFile(path, []byte(formatGolden(got)), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// parseGolden splits the contents of a golden file into named sections. The
+// newline at the end of each section is a separator, not part of its contents.
+func parseGolden(s string) map[string]string {
+	sections := map[string]string{}
+	headers := section.FindAllStringSubmatchIndex(s, -1)
+	for i, h := range headers {
+		end := len(s)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+		sections[s[h[2]:h[3]]] = strings.TrimSuffix(s[h[1]:end], "\n")
+	}
+	return sections
+}
+
+func formatGolden(sections map[string]string) string {
+	var b strings.Builder
+	for _, name := range append([]string{"input"}, formatNames()...) {
+		b.WriteString("-- " + name + " --\n" + sections[name] + "\n")
+	}
+	return b.String()
+}
+
+func formatNames() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package markup
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parsePlain parses plain text into paragraphs, which are separated by blank lines.
+func parsePlain(s string) *Node {
+	doc := &Node{Kind: Document}
+	var para []string
+	for _, line := range append(splitLines(s), "") {
+		if !isBlank(line) {
+			para = append(para, line)
+			continue
+		}
+		if len(para) > 0 {
+			doc.Children = append(doc.Children, paragraph(para, func(l string) []*Node {
+				return []*Node{{Kind: Text, Text: l}}
+			}))
+			para = nil
+		}
+	}
+	return doc
+}
+
+// plainRenderer renders document trees as plain text, e.g. for
+// notifications and other fallbacks of rich-text messages.
+type plainRenderer struct {
+	opts *Options
+}
+
+func (r *plainRenderer) blocks(nodes []*Node) string {
+	var out []string
+	for _, n := range blocks(nodes) {
+		if s := r.block(n); s != "" {
+			out = append(out, s)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+func (r *plainRenderer) block(n *Node) string {
+	switch n.Kind {
+	case Paragraph, Heading:
+		return r.inline(n.Children)
+	case Quote:
+		return prefixLines(r.blocks(n.Children), "> ", "> ")
+	case List:
+		var out []string
+		for i, item := range items(n) {
+			marker := "- "
+			if n.Ordered {
+				marker = strconv.Itoa(i+1) + ". "
+			}
+			s := strings.ReplaceAll(r.blocks(item.Children), "\n\n", "\n")
+			out = append(out, prefixLines(s, marker, strings.Repeat(" ", len(marker))))
+		}
+		return strings.Join(out, "\n")
+	case CodeBlock:
+		return n.Text
+	default:
+		return ""
+	}
+}
+
+func (r *plainRenderer) inline(nodes []*Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text, Code:
+			b.WriteString(n.Text)
+		case Link:
+			label := r.inline(n.Children)
+			if label == "" {
+				label = linkText(n.URL)
+			}
+			b.WriteString(label)
+			if label != linkText(n.URL) && safeURL(n.URL) {
+				b.WriteString(" (" + n.URL + ")")
+			}
+		case Mention:
+			b.WriteString(mentionText(n))
+		case LineBreak:
+			b.WriteString("\n")
+		default:
+			b.WriteString(r.inline(n.Children))
+		}
+	}
+	return b.String()
+}
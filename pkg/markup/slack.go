@@ -0,0 +1,331 @@
+package markup
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	slackItem  = regexp.MustCompile(`^([ \t]*)([•◦▪▫‣-]|\d{1,9}\.)[ \t]+`)
+	slackQuote = regexp.MustCompile(`^(?:>|&gt;) ?`)
+
+	slackUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+	slackEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// parseSlack parses Slack mrkdwn, based on:
+// https://docs.slack.dev/messaging/formatting-message-text/
+//
+// Slack doesn't have lists in mrkdwn, but lines which start with bullets
+// or numbers (e.g. in rich-text messages) are parsed as list items.
+func parseSlack(s string) *Node {
+	return &Node{Kind: Document, Children: slackBlocks(splitLines(s))}
+}
+
+func slackBlocks(lines []string) []*Node {
+	var nodes []*Node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case strings.HasPrefix(strings.TrimSpace(line), "```"):
+			// Code blocks may start and end in the middle of lines, and the
+			// text after the end is parsed as a separate block of text.
+			code, rest := slackCodeBlock(strings.Join(lines[i:], "\n"))
+			return append(append(nodes, code), slackBlocks(splitLines(rest))...)
+		case strings.HasPrefix(line, ">>>") || strings.HasPrefix(line, "&gt;&gt;&gt;"):
+			// The rest of the message is quoted.
+			first := strings.TrimPrefix(strings.TrimPrefix(line, ">>>"), "&gt;&gt;&gt;")
+			quoted := append([]string{strings.TrimPrefix(first, " ")}, lines[i+1:]...)
+			return append(nodes, &Node{Kind: Quote, Children: slackBlocks(quoted)})
+		case slackQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && slackQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, lines[i][len(slackQuote.FindString(lines[i])):])
+			}
+			nodes = append(nodes, &Node{Kind: Quote, Children: slackBlocks(quoted)})
+		case slackItem.MatchString(line):
+			var n *Node
+			n, i = slackList(lines, i)
+			nodes = append(nodes, n)
+		default:
+			var para []string
+			for ; i < len(lines) && !isBlank(lines[i]) && (len(para) == 0 || !slackBlockStart(lines[i])); i++ {
+				para = append(para, lines[i])
+			}
+			nodes = append(nodes, paragraph(para, slackInline))
+		}
+	}
+	return nodes
+}
+
+func slackBlockStart(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "```") || slackQuote.MatchString(line) || slackItem.MatchString(line)
+}
+
+// slackCodeBlock parses a code block at the beginning of the given text,
+// and returns it along with the rest of the text after the code block.
+func slackCodeBlock(s string) (*Node, string) {
+	s = strings.TrimLeft(s, " \t")[3:]
+	code, rest, _ := strings.Cut(s, "```")
+
+	code = strings.TrimPrefix(strings.TrimSuffix(code, "\n"), "\n")
+	return &Node{Kind: CodeBlock, Text: slackUnescaper.Replace(code)}, rest
+}
+
+// slackList parses consecutive list items of the same type (bulleted or
+// numbered) at the same indentation, including more-indented nested lists.
+func slackList(lines []string, i int) (*Node, int) {
+	m := slackItem.FindStringSubmatch(lines[i])
+	indent, ordered := indentOf(m[1]), isDigit(m[2][0])
+	list := &Node{Kind: List, Ordered: ordered}
+
+	for i < len(lines) {
+		m := slackItem.FindStringSubmatch(lines[i])
+		if m == nil || indentOf(m[1]) != indent || isDigit(m[2][0]) != ordered {
+			break
+		}
+
+		p := &Node{Kind: Paragraph, Children: slackInline(lines[i][len(m[0]):])}
+		item := &Node{Kind: ListItem, Children: []*Node{p}}
+		for i++; i < len(lines); {
+			m := slackItem.FindStringSubmatch(lines[i])
+			if m == nil || indentOf(m[1]) <= indent {
+				break
+			}
+			var nested *Node
+			nested, i = slackList(lines, i)
+			item.Children = append(item.Children, nested)
+		}
+
+		list.Children = append(list.Children, item)
+	}
+
+	return list, i
+}
+
+// slackInline parses inline Slack mrkdwn: emphasis, code, links, mentions, and HTML entities.
+func slackInline(s string) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &Node{Kind: Text, Text: slackUnescaper.Replace(text.String())})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		var n *Node
+		end := 0
+		switch s[i] {
+		case '<':
+			n, end = slackEntity(s, i)
+		case '`':
+			if n, end = mdCode(s, i); n != nil {
+				n.Text = slackUnescaper.Replace(n.Text)
+			}
+		case '*', '_', '~':
+			n, end = slackEmphasis(s, i)
+		}
+
+		if n == nil {
+			text.WriteByte(s[i])
+			i++
+			continue
+		}
+		flush()
+		nodes = append(nodes, n)
+		i = end
+	}
+
+	flush()
+	return nodes
+}
+
+// slackEntity parses links, user mentions, and other special
+// references, which are enclosed in angle brackets, based on:
+// https://docs.slack.dev/messaging/formatting-message-text/#advanced
+//
+// User mentions are parsed as [Mention] nodes. Channel mentions, user group
+// mentions, broadcasts (e.g. "@here") and dates are parsed as text.
+func slackEntity(s string, i int) (*Node, int) {
+	end := strings.IndexByte(s[i:], '>')
+	if end < 0 {
+		return nil, 0
+	}
+	target, label, _ := strings.Cut(s[i+1:i+end], "|")
+	label = slackUnescaper.Replace(label)
+	end += i + 1
+
+	text := func(s string) (*Node, int) {
+		return &Node{Kind: Text, Text: s}, end
+	}
+
+	switch {
+	case strings.HasPrefix(target, "@"):
+		return &Node{Kind: Mention, ID: target[1:], Text: label}, end
+	case strings.HasPrefix(target, "#"):
+		if label == "" {
+			label = target[1:]
+		}
+		return text("#" + label)
+	case strings.HasPrefix(target, "!subteam^"):
+		if label == "" {
+			label = "@" + strings.TrimPrefix(target, "!subteam^")
+		}
+		return text(label)
+	case strings.HasPrefix(target, "!"):
+		if label == "" {
+			label = "@" + target[1:]
+		}
+		return text(label)
+	case strings.Contains(target, ":") && !strings.ContainsAny(target, " \t"):
+		u := slackUnescaper.Replace(target)
+		if label == "" {
+			label = linkText(u)
+		}
+		return &Node{Kind: Link, URL: u, Children: []*Node{{Kind: Text, Text: label}}}, end
+	default:
+		return nil, 0
+	}
+}
+
+// slackEmphasis parses bold ("*"), italic ("_") and strikethrough ("~") text.
+// Delimiters must be at word boundaries, and can't be adjacent to whitespace
+// inside the enclosed text.
+func slackEmphasis(s string, i int) (*Node, int) {
+	c := s[i]
+	if i > 0 && isAlnum(s[i-1]) || i+1 >= len(s) || isSpace(s[i+1]) || s[i+1] == c {
+		return nil, 0
+	}
+
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '<', '`':
+			// Skip entities and code, which may contain delimiters.
+			closer := byte('>')
+			if s[j] == '`' {
+				closer = '`'
+			}
+			if k := strings.IndexByte(s[j+1:], closer); k >= 0 {
+				j += k + 1
+			}
+		case c:
+			if !isSpace(s[j-1]) && (j+1 == len(s) || !isAlnum(s[j+1])) {
+				kind := map[byte]Kind{'*': Bold, '_': Italic, '~': Strike}[c]
+				return &Node{Kind: kind, Children: slackInline(s[i+1 : j])}, j + 1
+			}
+		}
+	}
+	return nil, 0
+}
+
+// slackRenderer renders document trees in Slack mrkdwn.
+type slackRenderer struct {
+	opts *Options
+}
+
+func (r *slackRenderer) blocks(nodes []*Node) string {
+	var out []string
+	for _, n := range blocks(nodes) {
+		if s := r.block(n); s != "" {
+			out = append(out, s)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+func (r *slackRenderer) block(n *Node) string {
+	switch n.Kind {
+	case Paragraph:
+		return r.inline(n.Children, 0)
+	case Heading:
+		// Slack doesn't have headings in mrkdwn, so they're rendered as bold text.
+		return "*" + r.inline(n.Children, 1<<Bold) + "*"
+	case Quote:
+		return prefixLines(r.blocks(n.Children), "> ", "> ")
+	case List:
+		return r.list(n, 0)
+	case CodeBlock:
+		return "```\n" + slackEscaper.Replace(n.Text) + "\n```"
+	case Rule:
+		return "---"
+	default:
+		return ""
+	}
+}
+
+// list renders list items on separate lines, with nested lists indented by 4 spaces per level.
+func (r *slackRenderer) list(n *Node, depth int) string {
+	var out []string
+	for i, item := range items(n) {
+		marker := []string{"•", "◦", "▪"}[depth%3]
+		if n.Ordered {
+			marker = strconv.Itoa(i+1) + "."
+		}
+		indent := strings.Repeat("    ", depth)
+
+		var first string
+		var rest []string
+		for j, b := range blocks(item.Children) {
+			switch {
+			case b.Kind == List:
+				rest = append(rest, r.list(b, depth+1))
+			case j == 0:
+				first = prefixLines(r.block(b), "", indent+"  ")
+			default:
+				rest = append(rest, prefixLines(r.block(b), indent+"  ", indent+"  "))
+			}
+		}
+		out = append(out, indent+marker+" "+first)
+		out = append(out, rest...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// inline renders inline nodes. Marks are the emphasis kinds of the
+// enclosing nodes, which are not repeated in nested nodes.
+func (r *slackRenderer) inline(nodes []*Node, marks uint) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Kind {
+		case Text:
+			b.WriteString(slackEscaper.Replace(n.Text))
+		case Bold, Italic, Strike:
+			delim := map[Kind]string{Bold: "*", Italic: "_", Strike: "~"}[n.Kind]
+			if marks&(1<<n.Kind) != 0 {
+				delim = ""
+			}
+			b.WriteString(emphasize(r.inline(n.Children, marks|1<<n.Kind), delim, delim))
+		case Code:
+			b.WriteString("`" + slackEscaper.Replace(n.Text) + "`")
+		case Link:
+			// Slack doesn't support formatting in link labels.
+			label := slackEscaper.Replace(PlainText(&Node{Kind: Paragraph, Children: n.Children}))
+			if !safeURL(n.URL) {
+				b.WriteString(label)
+				continue
+			}
+			u := slackEscaper.Replace(strings.NewReplacer("|", "%7C", " ", "%20").Replace(n.URL))
+			if strings.TrimSpace(label) == "" || label == slackEscaper.Replace(linkText(n.URL)) {
+				b.WriteString("<" + u + ">")
+			} else {
+				b.WriteString(emphasize(label, "<"+u+"|", ">"))
+			}
+		case Mention:
+			if id, ok := r.opts.mention(n); ok {
+				b.WriteString("<@" + id + ">")
+			} else {
+				b.WriteString(slackEscaper.Replace(mentionText(n)))
+			}
+		case LineBreak:
+			b.WriteString("\n")
+		default:
+			b.WriteString(r.inline(n.Children, marks))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+// Package pagination provides generic iterators over the results of list-style
+// Timpani activities, which return their results in pages (e.g. based on Slack
+// cursors, Bitbucket "next" links, or GitHub page numbers).
+//
+// The list-style wrapper functions in this module are built on top of it, and
+// also expose their iterators, so workflows can stop retrieving pages early:
+//
+//	for msg, err := range slack.ConversationsHistoryIter(ctx, req) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// Or collect all the results (up to a maximum) with a single call:
+//
+//	msgs, err := pagination.Collect(slack.ConversationsHistoryIter(ctx, req), 1000)
+package pagination
+
+import (
+	"iter"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Page retrieves a single page of results, based on an opaque token (an empty string
+// for the first page). It returns the page's items, and the token of the next page
+// (an empty string if this is the last page).
+type Page[T any] func(token string) (items []T, next string, err error)
+
+// Seq returns an iterator over the items in all the pages that are returned by a
+// [Page] function. It stops after the last page, after the first error (which is
+// yielded with the zero value of T), or as soon as the caller stops iterating,
+// without retrieving any more pages.
+func Seq[T any](page Page[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		token := ""
+		for {
+			items, next, err := page(token)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// Guard against infinite loops, in case the token doesn't change.
+			if next == "" || next == token {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+// Activity returns an iterator over the items of a list-style Timpani activity.
+// setToken sets the token of the requested page in the activity's request, and
+// page extracts the page's items and the token of the next page from the response.
+//
+// The first page is requested with the caller's request as-is, so iteration
+// starts from the caller's token (e.g. a Slack cursor), if it's set.
+func Activity[Req, Resp, T any](
+	ctx workflow.Context,
+	name string,
+	req Req,
+	setToken func(*Req, string),
+	page func(*Resp) ([]T, string),
+) iter.Seq2[T, error] {
+	return Seq(func(token string) ([]T, string, error) {
+		if token != "" {
+			setToken(&req, token)
+		}
+		resp, err := internal.ExecuteTimpaniActivity[Resp](ctx, name, req)
+		if err != nil {
+			return nil, "", err
+		}
+
+		items, next := page(resp)
+		return items, next, nil
+	})
+}
+
+// Collect returns all the items of an iterator, or the first error. If
+// maxItems is positive, it stops after collecting that many items, without
+// retrieving any more pages. Otherwise, it collects all the items.
+func Collect[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+		if maxItems > 0 && len(items) >= maxItems {
+			break
+		}
+	}
+	return items, nil
+}
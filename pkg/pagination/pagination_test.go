@@ -0,0 +1,237 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/pagination"
+)
+
+var errPage = errors.New("page error")
+
+// pages returns a [pagination.Page] function over the given pages, with their
+// indices as tokens. If fail is positive, that page returns an error instead.
+// The tokens of all the requested pages are recorded in the returned slice.
+func pages(ps [][]int, fail int) (pagination.Page[int], *[]string) {
+	var tokens []string
+	return func(token string) ([]int, string, error) {
+		tokens = append(tokens, token)
+		i, _ := strconv.Atoi(token)
+		if fail > 0 && i == fail {
+			return nil, "", errPage
+		}
+
+		next := ""
+		if i+1 < len(ps) {
+			next = strconv.Itoa(i + 1)
+		}
+		return ps[i], next, nil
+	}, &tokens
+}
+
+func TestSeq(t *testing.T) {
+	tests := []struct {
+		name       string
+		pages      [][]int
+		fail       int
+		max        int
+		want       []int
+		wantErr    bool
+		wantTokens []string
+	}{
+		{
+			name:       "single_empty_page",
+			pages:      [][]int{nil},
+			wantTokens: []string{""},
+		},
+		{
+			name:       "single_page",
+			pages:      [][]int{{1, 2}},
+			want:       []int{1, 2},
+			wantTokens: []string{""},
+		},
+		{
+			name:       "multiple_pages",
+			pages:      [][]int{{1, 2}, {3}, {4, 5}},
+			want:       []int{1, 2, 3, 4, 5},
+			wantTokens: []string{"", "1", "2"},
+		},
+		{
+			name:       "empty_page_in_the_middle",
+			pages:      [][]int{{1}, nil, {2}},
+			want:       []int{1, 2},
+			wantTokens: []string{"", "1", "2"},
+		},
+		{
+			name:       "error",
+			pages:      [][]int{{1, 2}, {3}, {4, 5}},
+			fail:       1,
+			wantErr:    true,
+			wantTokens: []string{"", "1"},
+		},
+		{
+			name:       "max_items_in_first_page",
+			pages:      [][]int{{1, 2}, {3}, {4, 5}},
+			max:        2,
+			want:       []int{1, 2},
+			wantTokens: []string{""},
+		},
+		{
+			name:       "max_items_in_last_page",
+			pages:      [][]int{{1, 2}, {3}, {4, 5}},
+			max:        4,
+			want:       []int{1, 2, 3, 4},
+			wantTokens: []string{"", "1", "2"},
+		},
+		{
+			name:       "max_items_more_than_total",
+			pages:      [][]int{{1, 2}, {3}},
+			max:        10,
+			want:       []int{1, 2, 3},
+			wantTokens: []string{"", "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, tokens := pages(tt.pages, tt.fail)
+			got, err := pagination.Collect(pagination.Seq(page), tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Collect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(*tokens, tt.wantTokens) {
+				t.Errorf("requested tokens = %q, want %q", *tokens, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestSeqRepeatedToken(t *testing.T) {
+	n := 0
+	seq := pagination.Seq(func(_ string) ([]int, string, error) {
+		n++
+		return []int{n}, "same", nil
+	})
+
+	got, err := pagination.Collect(seq, 0)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Collect() = %v, want %v", got, want)
+	}
+}
+
+func TestSeqStopEarly(t *testing.T) {
+	page, tokens := pages([][]int{{1, 2}, {3}}, 0)
+	for item, err := range pagination.Seq(page) {
+		if err != nil {
+			t.Fatalf("Seq() error = %v", err)
+		}
+		if item == 1 {
+			break
+		}
+	}
+	if want := []string{""}; !reflect.DeepEqual(*tokens, want) {
+		t.Errorf("requested tokens = %q, want %q", *tokens, want)
+	}
+}
+
+type listRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type listResponse struct {
+	Items      []string `json:"items"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+const listActivityName = "test.list"
+
+// list is a fake list-style activity with 3 pages, whose cursors are "a", "b" and "c".
+func list(_ context.Context, req listRequest) (*listResponse, error) {
+	switch req.Cursor {
+	case "", "a":
+		return &listResponse{Items: []string{"1", "2"}, NextCursor: "b"}, nil
+	case "b":
+		return &listResponse{Items: []string{"3"}, NextCursor: "c"}, nil
+	case "c":
+		return &listResponse{Items: []string{"4"}}, nil
+	default:
+		return nil, temporal.NewNonRetryableApplicationError("invalid cursor", "invalid_cursor", nil)
+	}
+}
+
+func listWorkflow(ctx workflow.Context, req listRequest, maxItems int) ([]string, error) {
+	setCursor := func(r *listRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *listResponse) ([]string, string) { return r.Items, r.NextCursor }
+	return pagination.Collect(pagination.Activity(ctx, listActivityName, req, setCursor, page), maxItems)
+}
+
+func TestActivity(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  string
+		max     int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all_pages",
+			want: []string{"1", "2", "3", "4"},
+		},
+		{
+			name: "max_items",
+			max:  3,
+			want: []string{"1", "2", "3"},
+		},
+		{
+			name:   "caller_cursor",
+			cursor: "b",
+			want:   []string{"3", "4"},
+		},
+		{
+			name:   "caller_cursor_last_page",
+			cursor: "c",
+			want:   []string{"4"},
+		},
+		{
+			name:    "invalid_caller_cursor",
+			cursor:  "z",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			env.RegisterActivityWithOptions(list, activity.RegisterOptions{Name: listActivityName})
+
+			env.ExecuteWorkflow(listWorkflow, listRequest{Cursor: tt.cursor}, tt.max)
+			err := env.GetWorkflowError()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("workflow error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatalf("failed to get workflow result: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("workflow result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package registry
+
+import (
+	"reflect"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+)
+
+var bitbucketEntries = []Entry{
+	{
+		Name:     bitbucket.CommitsDiffActivityName,
+		Request:  reflect.TypeFor[bitbucket.CommitsDiffRequest](),
+		Response: reflect.TypeFor[string](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diff-spec-get",
+	},
+	{
+		Name:     bitbucket.CommitsDiffstatActivityName,
+		Request:  reflect.TypeFor[bitbucket.CommitsDiffstatRequest](),
+		Response: reflect.TypeFor[bitbucket.CommitsDiffstatResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsApproveActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsApproveRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-approve-post",
+	},
+	{
+		Name:     bitbucket.PullRequestsCreateCommentActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsCreateCommentRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsCreateCommentResponse](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-post",
+	},
+	{
+		Name:     bitbucket.PullRequestsDeclineActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsDeclineRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-decline-post",
+	},
+	{
+		Name:     bitbucket.PullRequestsDeleteCommentActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsDeleteCommentRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-comment-id-delete",
+	},
+	{
+		Name:     bitbucket.PullRequestsDiffstatActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsDiffstatRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsDiffstatResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-diffstat-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsGetActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsGetRequest](),
+		Response: reflect.TypeFor[map[string]any](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsGetCommentActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsGetCommentRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsGetCommentResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-comment-id-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsListActivityLogActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsListActivityLogRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsListActivityLogResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-activity-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsListCommitsActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsListCommitsRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsListCommitsResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-commits-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsListForCommitActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsListForCommitRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsListForCommitResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-commit-commit-pullrequests-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsListTasksActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsListTasksRequest](),
+		Response: reflect.TypeFor[bitbucket.PullRequestsListTasksResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-tasks-get",
+	},
+	{
+		Name:     bitbucket.PullRequestsMergeActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsMergeRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-merge-post",
+	},
+	{
+		Name:     bitbucket.PullRequestsUnapproveActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsUnapproveRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-approve-delete",
+	},
+	{
+		Name:     bitbucket.PullRequestsUpdateActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsUpdateRequest](),
+		Response: reflect.TypeFor[map[string]any](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-put",
+	},
+	{
+		Name:     bitbucket.PullRequestsUpdateCommentActivityName,
+		Request:  reflect.TypeFor[bitbucket.PullRequestsUpdateCommentRequest](),
+		Mutating: true,
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-comment-id-put",
+	},
+	{
+		Name:     bitbucket.SourceGetFileActivityName,
+		Request:  reflect.TypeFor[bitbucket.SourceGetRequest](),
+		Response: reflect.TypeFor[string](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-src-commit-path-get",
+	},
+	{
+		Name:     bitbucket.UsersGetActivityName,
+		Request:  reflect.TypeFor[bitbucket.UsersGetRequest](),
+		Response: reflect.TypeFor[bitbucket.UsersGetResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get",
+	},
+	{
+		Name:     bitbucket.WorkspacesListMembersActivityName,
+		Request:  reflect.TypeFor[bitbucket.WorkspacesListMembersRequest](),
+		Response: reflect.TypeFor[bitbucket.WorkspacesListMembersResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-members-get",
+	},
+}
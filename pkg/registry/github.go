@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"reflect"
+
+	"github.com/tzrikka/timpani-api/pkg/github"
+)
+
+var githubEntries = []Entry{
+	{
+		Name:     github.IssuesCommentsCreateActivityName,
+		Request:  reflect.TypeFor[github.IssuesCommentsCreateRequest](),
+		Response: reflect.TypeFor[github.IssueComment](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#create-an-issue-comment",
+	},
+	{
+		Name:     github.IssuesCommentsDeleteActivityName,
+		Request:  reflect.TypeFor[github.IssuesCommentsDeleteRequest](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#delete-an-issue-comment",
+	},
+	{
+		Name:     github.IssuesCommentsUpdateActivityName,
+		Request:  reflect.TypeFor[github.IssuesCommentsUpdateRequest](),
+		Response: reflect.TypeFor[github.IssueComment](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#update-an-issue-comment",
+	},
+	{
+		Name:     github.PullRequestsGetActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsGetRequest](),
+		Response: reflect.TypeFor[github.PullRequest](),
+		DocURL:   "https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#get-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsListCommitsActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsListCommitsRequest](),
+		Response: reflect.TypeFor[[]github.Commit](),
+		DocURL:   "https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-commits-on-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsListFilesActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsListFilesRequest](),
+		Response: reflect.TypeFor[[]github.File](),
+		DocURL:   "https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#list-pull-requests-files",
+	},
+	{
+		Name:     github.PullRequestsMergeActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsMergeRequest](),
+		Response: reflect.TypeFor[github.PullRequestsMergeResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#merge-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsUpdateActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsUpdateRequest](),
+		Response: reflect.TypeFor[github.PullRequest](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/pulls?apiVersion=2022-11-28#update-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsCommentsCreateActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsCommentsCreateRequest](),
+		Response: reflect.TypeFor[github.PullComment](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/comments?apiVersion=2022-11-28#create-a-review-comment-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsCommentsCreateReplyActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsCommentsCreateReplyRequest](),
+		Response: reflect.TypeFor[github.PullComment](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/comments?apiVersion=2022-11-28#create-a-reply-for-a-review-comment",
+	},
+	{
+		Name:     github.PullRequestsCommentsDeleteActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsCommentsDeleteRequest](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/comments?apiVersion=2022-11-28#delete-a-review-comment-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsCommentsUpdateActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsCommentsUpdateRequest](),
+		Response: reflect.TypeFor[github.PullComment](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/comments?apiVersion=2022-11-28#update-a-review-comment-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsReviewsCreateActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsReviewsCreateRequest](),
+		Response: reflect.TypeFor[github.Review](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/reviews?apiVersion=2022-11-28#create-a-review-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsReviewsDeleteActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsReviewsDeleteRequest](),
+		Response: reflect.TypeFor[github.Review](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/reviews?apiVersion=2022-11-28#delete-a-pending-review-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsReviewsDismissActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsReviewsDismissRequest](),
+		Response: reflect.TypeFor[github.Review](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/reviews?apiVersion=2022-11-28#dismiss-a-review-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsReviewsSubmitActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsReviewsSubmitRequest](),
+		Response: reflect.TypeFor[github.Review](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/reviews?apiVersion=2022-11-28#submit-a-review-for-a-pull-request",
+	},
+	{
+		Name:     github.PullRequestsReviewsUpdateActivityName,
+		Request:  reflect.TypeFor[github.PullRequestsReviewsUpdateRequest](),
+		Response: reflect.TypeFor[github.Review](),
+		Mutating: true,
+		DocURL:   "https://docs.github.com/en/rest/pulls/reviews?apiVersion=2022-11-28#update-a-review-for-a-pull-request",
+	},
+	{
+		Name:     github.UsersGetActivityName,
+		Request:  reflect.TypeFor[github.UsersGetRequest](),
+		Response: reflect.TypeFor[github.User](),
+		DocURL:   "https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28",
+	},
+	{
+		Name:     github.UsersListActivityName,
+		Request:  reflect.TypeFor[github.UsersListRequest](),
+		Response: reflect.TypeFor[[]github.User](),
+		DocURL:   "https://docs.github.com/en/rest/users/users?apiVersion=2022-11-28#list-users",
+	},
+}
@@ -0,0 +1,22 @@
+package registry
+
+import (
+	"reflect"
+
+	"github.com/tzrikka/timpani-api/pkg/jira"
+)
+
+var jiraEntries = []Entry{
+	{
+		Name:     jira.UsersGetActivityName,
+		Request:  reflect.TypeFor[jira.UsersGetRequest](),
+		Response: reflect.TypeFor[jira.UsersGetResponse](),
+		DocURL:   "https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-users/#api-rest-api-3-user-get",
+	},
+	{
+		Name:     jira.UsersSearchActivityName,
+		Request:  reflect.TypeFor[jira.UsersSearchRequest](),
+		Response: reflect.TypeFor[[]jira.User](),
+		DocURL:   "https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-user-search/#api-rest-api-3-user-search-get",
+	},
+}
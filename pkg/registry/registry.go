@@ -0,0 +1,129 @@
+// Package registry is a catalog of all the Timpani activities and child workflows
+// that this module knows about, with their request and response types, whether
+// they mutate the state of third-party services, and their upstream API docs.
+//
+// It is the single source of truth for tooling, fakes (see the timpanitest package),
+// validation, and compatibility checks against a running Timpani worker.
+package registry
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/tzrikka/timpani-api/pkg/versioning"
+)
+
+// Kind is the kind of a Temporal entity that the Timpani worker provides.
+type Kind int
+
+// Kinds of Timpani entities.
+const (
+	Activity Kind = iota
+	Workflow
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k == Workflow {
+		return "workflow"
+	}
+	return "activity"
+}
+
+// Entry describes a single Timpani activity or child workflow.
+type Entry struct {
+	// Name is the Temporal name of the activity or workflow, e.g. "slack.chat.postMessage".
+	Name string
+	// Service is the name of the third-party service, e.g. "slack".
+	Service string
+	Kind    Kind
+
+	// Request is the type of the activity's or workflow's input. It is nil if there isn't one.
+	Request reflect.Type
+	// Response is the type of the activity's or workflow's output. It is nil if there isn't one.
+	Response reflect.Type
+
+	// Mutating is true if the activity or workflow changes the
+	// state of the third-party service, e.g. creates or deletes data.
+	Mutating bool
+	// DocURL is the URL of the upstream API's documentation.
+	DocURL string
+}
+
+// NewRequest returns a pointer to a new zero value of the entry's request type, or nil.
+func (e Entry) NewRequest() any {
+	if e.Request == nil {
+		return nil
+	}
+	return reflect.New(e.Request).Interface()
+}
+
+// NewResponse returns a pointer to a new zero value of the entry's response type, or nil.
+func (e Entry) NewResponse() any {
+	if e.Response == nil {
+		return nil
+	}
+	return reflect.New(e.Response).Interface()
+}
+
+var entries = index(slackEntries, githubEntries, bitbucketEntries, jiraEntries, timpaniEntries, generatedEntries)
+
+// index returns a map of entries by name, with their services
+// populated based on the prefixes of their names.
+func index(lists ...[]Entry) map[string]Entry {
+	m := map[string]Entry{}
+	for _, list := range lists {
+		for _, e := range list {
+			e.Service, _, _ = strings.Cut(e.Name, ".")
+			m[e.Name] = e
+		}
+	}
+	return m
+}
+
+// All returns all the entries in the registry, sorted by name.
+func All() []Entry {
+	all := make([]Entry, 0, len(entries))
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		all = append(all, entries[name])
+	}
+	return all
+}
+
+// Lookup returns the entry with the given name, if it exists. Old names of
+// activities with a registered [versioning.Migration] return their current entry.
+func Lookup(name string) (Entry, bool) {
+	e, ok := entries[versioning.Canonical(name)]
+	return e, ok
+}
+
+// Service returns all the entries of a specific third-party service, sorted by name.
+func Service(service string) []Entry {
+	var es []Entry
+	for _, e := range All() {
+		if e.Service == service {
+			es = append(es, e)
+		}
+	}
+	return es
+}
+
+// Services returns the sorted names of all the third-party services in the registry.
+func Services() []string {
+	var ss []string
+	for _, e := range All() {
+		if !slices.Contains(ss, e.Service) {
+			ss = append(ss, e.Service)
+		}
+	}
+	return ss
+}
+
+// IsMutating returns true if the activity or workflow with the given name changes the state
+// of its third-party service. Unknown names are considered mutating, to err on the safe side.
+func IsMutating(name string) bool {
+	e, ok := Lookup(name)
+	return !ok || e.Mutating
+}
@@ -0,0 +1,143 @@
+package registry
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/versioning"
+)
+
+func TestEntries(t *testing.T) {
+	lists := map[string][]Entry{
+		"slack":     slackEntries,
+		"github":    githubEntries,
+		"bitbucket": bitbucketEntries,
+		"jira":      jiraEntries,
+		"timpani":   timpaniEntries,
+	}
+
+	seen := map[string]bool{}
+	for service, list := range lists {
+		for _, e := range list {
+			t.Run(e.Name, func(t *testing.T) {
+				if seen[e.Name] {
+					t.Errorf("duplicate entry")
+				}
+				seen[e.Name] = true
+
+				if !strings.HasPrefix(e.Name, service+".") {
+					t.Errorf("name doesn't start with %q", service+".")
+				}
+				if !strings.HasPrefix(e.DocURL, "https://") {
+					t.Errorf("DocURL = %q, want an HTTPS URL", e.DocURL)
+				}
+				for _, typ := range []reflect.Type{e.Request, e.Response} {
+					if typ != nil && typ.Kind() == reflect.Pointer {
+						t.Errorf("type %v is a pointer, want its element type", typ)
+					}
+				}
+				if e.Request != nil && e.Request.Kind() == reflect.Struct {
+					if _, ok := e.NewRequest().(interface{ Validate() error }); !ok {
+						t.Errorf("request type %v doesn't have a Validate method", e.Request)
+					}
+				}
+			})
+		}
+	}
+
+	if got, want := len(All()), len(seen)+len(generatedEntries); got != want {
+		t.Errorf("len(All()) = %d, want %d", got, want)
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	if !slices.IsSortedFunc(all, func(a, b Entry) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Error("All() is not sorted by name")
+	}
+	for _, e := range all {
+		if e.Service == "" {
+			t.Errorf("entry %q has no service", e.Name)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	const oldName = "slack.chat.postMessageV0"
+	versioning.Register(slack.ChatPostMessageActivityName, versioning.Rename(oldName, slack.ChatPostMessageActivityName))
+	t.Cleanup(func() { versioning.Unregister(slack.ChatPostMessageActivityName) })
+
+	tests := []struct {
+		name         string
+		lookup       string
+		want         string
+		wantOK       bool
+		wantMutating bool
+	}{
+		{
+			name:   "read_only_activity",
+			lookup: slack.ConversationsHistoryActivityName,
+			want:   slack.ConversationsHistoryActivityName,
+			wantOK: true,
+		},
+		{
+			name:         "mutating_activity",
+			lookup:       slack.ChatPostMessageActivityName,
+			want:         slack.ChatPostMessageActivityName,
+			wantOK:       true,
+			wantMutating: true,
+		},
+		{
+			name:         "old_name",
+			lookup:       oldName,
+			want:         slack.ChatPostMessageActivityName,
+			wantOK:       true,
+			wantMutating: true,
+		},
+		{
+			name:         "workflow",
+			lookup:       slack.TimpaniPostApprovalWorkflowName,
+			want:         slack.TimpaniPostApprovalWorkflowName,
+			wantOK:       true,
+			wantMutating: true,
+		},
+		{
+			name:         "unknown",
+			lookup:       "slack.unknown",
+			wantMutating: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := Lookup(tt.lookup)
+			if ok != tt.wantOK || e.Name != tt.want {
+				t.Errorf("Lookup() = %q, %v, want %q, %v", e.Name, ok, tt.want, tt.wantOK)
+			}
+			if got := IsMutating(tt.lookup); got != tt.wantMutating {
+				t.Errorf("IsMutating() = %v, want %v", got, tt.wantMutating)
+			}
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+	want := []string{"bitbucket", "github", "jira", "slack", "timpani"}
+	if got := Services(); !slices.Equal(got, want) {
+		t.Errorf("Services() = %q, want %q", got, want)
+	}
+
+	for _, service := range want {
+		es := Service(service)
+		if len(es) == 0 {
+			t.Errorf("Service(%q) is empty", service)
+		}
+		for _, e := range es {
+			if e.Service != service {
+				t.Errorf("Service(%q) contains %q", service, e.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package registry
+
+import (
+	"reflect"
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+)
+
+var slackEntries = []Entry{
+	{
+		Name:     slack.AuthTestActivityName,
+		Request:  reflect.TypeFor[slack.AuthTestRequest](),
+		Response: reflect.TypeFor[slack.AuthTestResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/auth.test/",
+	},
+	{
+		Name:     slack.BookmarksAddActivityName,
+		Request:  reflect.TypeFor[slack.BookmarksAddRequest](),
+		Response: reflect.TypeFor[slack.BookmarksAddResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/bookmarks.add/",
+	},
+	{
+		Name:     slack.BookmarksEditActivityName,
+		Request:  reflect.TypeFor[slack.BookmarksEditRequest](),
+		Response: reflect.TypeFor[slack.BookmarksEditResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/bookmarks.edit/",
+	},
+	{
+		Name:     slack.BookmarksListActivityName,
+		Request:  reflect.TypeFor[slack.BookmarksListRequest](),
+		Response: reflect.TypeFor[slack.BookmarksListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/bookmarks.list/",
+	},
+	{
+		Name:     slack.BookmarksRemoveActivityName,
+		Request:  reflect.TypeFor[slack.BookmarksRemoveRequest](),
+		Response: reflect.TypeFor[slack.BookmarksRemoveResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/bookmarks.remove/",
+	},
+	{
+		Name:     slack.BotsInfoActivityName,
+		Request:  reflect.TypeFor[slack.BotsInfoRequest](),
+		Response: reflect.TypeFor[slack.BotsInfoResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/bots.info/",
+	},
+	{
+		Name:     slack.ChatDeleteActivityName,
+		Request:  reflect.TypeFor[slack.ChatDeleteRequest](),
+		Response: reflect.TypeFor[slack.ChatDeleteResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.delete/",
+	},
+	{
+		Name:     slack.ChatDeleteScheduledMessageActivityName,
+		Request:  reflect.TypeFor[slack.ChatDeleteScheduledMessageRequest](),
+		Response: reflect.TypeFor[slack.ChatDeleteScheduledMessageResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.deleteScheduledMessage/",
+	},
+	{
+		Name:     slack.ChatGetPermalinkActivityName,
+		Request:  reflect.TypeFor[slack.ChatGetPermalinkRequest](),
+		Response: reflect.TypeFor[slack.ChatGetPermalinkResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.getPermalink/",
+	},
+	{
+		Name:     slack.ChatPostEphemeralActivityName,
+		Request:  reflect.TypeFor[slack.ChatPostEphemeralRequest](),
+		Response: reflect.TypeFor[slack.ChatPostEphemeralResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.postEphemeral/",
+	},
+	{
+		Name:     slack.ChatPostMessageActivityName,
+		Request:  reflect.TypeFor[slack.ChatPostMessageRequest](),
+		Response: reflect.TypeFor[slack.ChatPostMessageResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.postMessage/",
+	},
+	{
+		Name:     slack.ChatScheduleMessageActivityName,
+		Request:  reflect.TypeFor[slack.ChatScheduleMessageRequest](),
+		Response: reflect.TypeFor[slack.ChatScheduleMessageResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.scheduleMessage/",
+	},
+	{
+		Name:     slack.ChatScheduledMessagesListActivityName,
+		Request:  reflect.TypeFor[slack.ChatScheduledMessagesListRequest](),
+		Response: reflect.TypeFor[slack.ChatScheduledMessagesListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/",
+	},
+	{
+		Name:     slack.ChatUpdateActivityName,
+		Request:  reflect.TypeFor[slack.ChatUpdateRequest](),
+		Response: reflect.TypeFor[slack.ChatUpdateResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/chat.update/",
+	},
+	{
+		Name:     slack.TimpaniPostApprovalWorkflowName,
+		Kind:     Workflow,
+		Request:  reflect.TypeFor[slack.TimpaniPostApprovalRequest](),
+		Response: reflect.TypeFor[slack.TimpaniPostApprovalResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/interaction-payloads/",
+	},
+	{
+		Name:     slack.ConversationsArchiveActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsArchiveRequest](),
+		Response: reflect.TypeFor[slack.ConversationsArchiveResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.archive/",
+	},
+	{
+		Name:     slack.ConversationsCloseActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsCloseRequest](),
+		Response: reflect.TypeFor[slack.ConversationsCloseResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.close/",
+	},
+	{
+		Name:     slack.ConversationsCreateActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsCreateRequest](),
+		Response: reflect.TypeFor[slack.ConversationsCreateResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.create/",
+	},
+	{
+		Name:     slack.ConversationsHistoryActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsHistoryRequest](),
+		Response: reflect.TypeFor[slack.ConversationsHistoryResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.history/",
+	},
+	{
+		Name:     slack.ConversationsInfoActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsInfoRequest](),
+		Response: reflect.TypeFor[slack.ConversationsInfoResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.info/",
+	},
+	{
+		Name:     slack.ConversationsInviteActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsInviteRequest](),
+		Response: reflect.TypeFor[slack.ConversationsInviteResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.invite/",
+	},
+	{
+		Name:     slack.ConversationsJoinActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsJoinRequest](),
+		Response: reflect.TypeFor[slack.ConversationsJoinResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.join/",
+	},
+	{
+		Name:     slack.ConversationsKickActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsKickRequest](),
+		Response: reflect.TypeFor[slack.ConversationsKickResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.kick/",
+	},
+	{
+		Name:     slack.ConversationsLeaveActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsLeaveRequest](),
+		Response: reflect.TypeFor[slack.ConversationsLeaveResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.leave/",
+	},
+	{
+		Name:     slack.ConversationsListActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsListRequest](),
+		Response: reflect.TypeFor[slack.ConversationsListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.list/",
+	},
+	{
+		Name:     slack.ConversationsMembersActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsMembersRequest](),
+		Response: reflect.TypeFor[slack.ConversationsMembersResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.members/",
+	},
+	{
+		Name:     slack.ConversationsOpenActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsOpenRequest](),
+		Response: reflect.TypeFor[slack.ConversationsOpenResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.open/",
+	},
+	{
+		Name:     slack.ConversationsRenameActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsRenameRequest](),
+		Response: reflect.TypeFor[slack.ConversationsRenameResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.rename/",
+	},
+	{
+		Name:     slack.ConversationsRepliesActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsRepliesRequest](),
+		Response: reflect.TypeFor[slack.ConversationsRepliesResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.replies/",
+	},
+	{
+		Name:     slack.ConversationsSetPurposeActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsSetPurposeRequest](),
+		Response: reflect.TypeFor[slack.ConversationsSetPurposeResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.setPurpose/",
+	},
+	{
+		Name:     slack.ConversationsSetTopicActivityName,
+		Request:  reflect.TypeFor[slack.ConversationsSetTopicRequest](),
+		Response: reflect.TypeFor[slack.ConversationsSetTopicResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/conversations.setTopic/",
+	},
+	{
+		Name:     slack.FilesCompleteUploadExternalActivityName,
+		Request:  reflect.TypeFor[slack.FilesCompleteUploadExternalRequest](),
+		Response: reflect.TypeFor[slack.FilesCompleteUploadExternalResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/files.completeuploadexternal/",
+	},
+	{
+		Name:     slack.FilesDeleteActivityName,
+		Request:  reflect.TypeFor[slack.FilesDeleteRequest](),
+		Response: reflect.TypeFor[slack.FilesDeleteResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/files.delete/",
+	},
+	{
+		Name:     slack.FilesGetUploadURLExternalActivityName,
+		Request:  reflect.TypeFor[slack.FilesGetUploadURLExternalRequest](),
+		Response: reflect.TypeFor[slack.FilesGetUploadURLExternalResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/files.getuploadurlexternal/",
+	},
+	{
+		Name:     slack.TimpaniUploadExternalActivityName,
+		Request:  reflect.TypeFor[slack.TimpaniUploadExternalRequest](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/messaging/working-with-files/",
+	},
+	{
+		Name:     slack.ReactionsAddActivityName,
+		Request:  reflect.TypeFor[slack.ReactionsAddRequest](),
+		Response: reflect.TypeFor[slack.ReactionsAddResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/reactions.add/",
+	},
+	{
+		Name:     slack.ReactionsGetActivityName,
+		Request:  reflect.TypeFor[slack.ReactionsGetRequest](),
+		Response: reflect.TypeFor[slack.ReactionsGetResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/reactions.get/",
+	},
+	{
+		Name:     slack.ReactionsListActivityName,
+		Request:  reflect.TypeFor[slack.ReactionsListRequest](),
+		Response: reflect.TypeFor[slack.ReactionsListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/reactions.list/",
+	},
+	{
+		Name:     slack.ReactionsRemoveActivityName,
+		Request:  reflect.TypeFor[slack.ReactionsRemoveRequest](),
+		Response: reflect.TypeFor[slack.ReactionsRemoveResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/reactions.remove/",
+	},
+	{
+		Name:     slack.UserGroupsListActivityName,
+		Request:  reflect.TypeFor[slack.UserGroupsListRequest](),
+		Response: reflect.TypeFor[slack.UserGroupsListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/usergroups.list/",
+	},
+	{
+		Name:     slack.UserGroupsUsersListActivityName,
+		Request:  reflect.TypeFor[slack.UserGroupsUsersListRequest](),
+		Response: reflect.TypeFor[slack.UserGroupsUsersListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/usergroups.users.list/",
+	},
+	{
+		Name:     slack.UsersConversationsActivityName,
+		Request:  reflect.TypeFor[slack.UsersConversationsRequest](),
+		Response: reflect.TypeFor[slack.UsersConversationsResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.conversations/",
+	},
+	{
+		Name:     slack.UsersGetPresenceActivityName,
+		Request:  reflect.TypeFor[slack.UsersGetPresenceRequest](),
+		Response: reflect.TypeFor[slack.UsersGetPresenceResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.getPresence/",
+	},
+	{
+		Name:     slack.UsersInfoActivityName,
+		Request:  reflect.TypeFor[slack.UsersInfoRequest](),
+		Response: reflect.TypeFor[slack.UsersInfoResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.info/",
+	},
+	{
+		Name:     slack.UsersListActivityName,
+		Request:  reflect.TypeFor[slack.UsersListRequest](),
+		Response: reflect.TypeFor[slack.UsersListResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.list/",
+	},
+	{
+		Name:     slack.UsersLookupByEmailActivityName,
+		Request:  reflect.TypeFor[slack.UsersLookupByEmailRequest](),
+		Response: reflect.TypeFor[slack.UsersLookupByEmailResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.lookupByEmail/",
+	},
+	{
+		Name:     slack.UsersProfileGetActivityName,
+		Request:  reflect.TypeFor[slack.UsersProfileGetRequest](),
+		Response: reflect.TypeFor[slack.UsersProfileGetResponse](),
+		DocURL:   "https://docs.slack.dev/reference/methods/users.profile.get/",
+	},
+	{
+		Name:     slack.ViewsOpenActivityName,
+		Request:  reflect.TypeFor[slack.ViewsOpenRequest](),
+		Response: reflect.TypeFor[slack.ViewsOpenResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/views.open/",
+	},
+	{
+		Name:     slack.ViewsPublishActivityName,
+		Request:  reflect.TypeFor[slack.ViewsPublishRequest](),
+		Response: reflect.TypeFor[slack.ViewsPublishResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/views.publish/",
+	},
+	{
+		Name:     slack.ViewsPushActivityName,
+		Request:  reflect.TypeFor[slack.ViewsPushRequest](),
+		Response: reflect.TypeFor[slack.ViewsPushResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/views.push/",
+	},
+	{
+		Name:     slack.ViewsUpdateActivityName,
+		Request:  reflect.TypeFor[slack.ViewsUpdateRequest](),
+		Response: reflect.TypeFor[slack.ViewsUpdateResponse](),
+		Mutating: true,
+		DocURL:   "https://docs.slack.dev/reference/methods/views.update/",
+	},
+}
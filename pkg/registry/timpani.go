@@ -0,0 +1,15 @@
+package registry
+
+import (
+	"reflect"
+
+	"github.com/tzrikka/timpani-api/pkg/timpani"
+)
+
+var timpaniEntries = []Entry{
+	{
+		Name:     timpani.CapabilitiesActivityName,
+		Response: reflect.TypeFor[timpani.CapabilitiesResponse](),
+		DocURL:   "https://pkg.go.dev/github.com/tzrikka/timpani",
+	},
+}
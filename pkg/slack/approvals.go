@@ -0,0 +1,489 @@
+package slack
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack/blocks"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+)
+
+const (
+	approvalBlockID       = "timpani_approval"
+	justificationBlockID  = "timpani_justification"
+	justificationActionID = "justification"
+
+	// justificationTimeout limits how long [RequestApproval] waits for a
+	// justification modal, during which it doesn't handle other choices.
+	justificationTimeout = 15 * time.Minute
+)
+
+// mrkdwnEscaper escapes user input, so it can't inject links and mentions into mrkdwn text.
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ApprovalRequest is a richer alternative to [TimpaniPostApprovalRequest], for [RequestApproval].
+type ApprovalRequest struct {
+	Channel        string
+	ThreadTS       string
+	ReplyBroadcast bool
+
+	// Header is an optional plain-text title.
+	Header string
+	// Message is the markdown text of the request.
+	Message string
+	// Options are the buttons in the message. The default is "Approve" and "Deny".
+	Options []ApprovalOption
+
+	// Approvers and UserGroups are the IDs of Slack users and user groups whose members
+	// may choose an option. If both are empty, anyone who can see the message may
+	// choose. Other users who click a button are notified with an ephemeral message.
+	Approvers  []string
+	UserGroups []string
+	// Quorum is the number of different users who need to choose the same option
+	// in order to decide (e.g. 2 out of 5 approvers). The default is 1. It can't exceed
+	// the number of approvers, unless they are (also) specified as user groups.
+	Quorum int
+	// RequireJustification opens a modal with a text input when a user chooses an
+	// option. The choice is counted only if the user submits the modal in time.
+	RequireJustification bool
+
+	// Timeout is optional. If it expires before a decision, the request is either
+	// posted again with the escalation settings, or it times out for good.
+	Timeout    time.Duration
+	Escalation *ApprovalEscalation
+}
+
+// ApprovalOption is a button in the message of an [ApprovalRequest].
+type ApprovalOption struct {
+	Label string
+	Value string // Unique identifier of the option, returned in [Decision.Option].
+	Style blocks.Style
+	// Quorum overrides [ApprovalRequest.Quorum] for this option,
+	// e.g. 1 for a "Deny" option that acts as a veto.
+	Quorum int
+}
+
+// ApprovalEscalation specifies where to post an [ApprovalRequest] again if its timeout expires.
+type ApprovalEscalation struct {
+	Channel string
+	// Message is optional markdown text shown above the request (e.g. to mention someone).
+	Message string
+	// Approvers and UserGroups are added to those of the original request, if it restricts
+	// who may choose. If the original request doesn't, neither does the escalation.
+	Approvers  []string
+	UserGroups []string
+	// Timeout is optional, and starts when the request is escalated.
+	Timeout time.Duration
+}
+
+// Decision is the result of [RequestApproval].
+type Decision struct {
+	Option string // Value of the chosen [ApprovalOption].
+	Label  string // Label of the chosen [ApprovalOption].
+	UserID string // ID of the user whose choice completed the quorum.
+	Votes  []Vote // All the votes for the chosen option, in chronological order.
+
+	// Channel and TS identify the message in which the decision was made
+	// (the original one, or the escalated one if Escalated is true).
+	Channel   string
+	TS        string
+	Escalated bool
+
+	RequestedAt time.Time
+	DecidedAt   time.Time
+}
+
+// Vote is a single user's choice in an [ApprovalRequest]. Users may change their choice
+// before the decision, in which case only their latest choice is counted.
+type Vote struct {
+	UserID        string
+	Option        string
+	Justification string
+	Time          time.Time
+}
+
+var defaultApprovalOptions = []ApprovalOption{
+	{Label: "Approve", Value: "approve", Style: blocks.Primary},
+	{Label: "Deny", Value: "deny", Style: blocks.Danger},
+}
+
+// RequestApproval is a richer alternative to [TimpaniPostApprovalWorkflow], which runs
+// in the calling workflow instead of in the Timpani worker. It posts an interactive
+// message with any number of options, and waits for the relevant [events.BlockActionsSignal]
+// interactions, until one of the options reaches its quorum or the timeout expires.
+//
+// The message is updated automatically after each vote and when the request is decided,
+// escalated or timed out. If the final timeout expires, it returns [errors.ErrTimeout].
+// If the decision is made or the final timeout expires, but updating the message fails,
+// it returns both the decision or timeout and the update error.
+//
+// Interactions which don't match the request are kept for other receivers (see
+// [events.Receive]), so workflows may wait for other [events.BlockActionsSignal]
+// interactions at the same time, e.g. in other coroutines.
+//
+// In dry-run mode, it posts nothing, doesn't wait, and returns an empty decision.
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func RequestApproval(ctx workflow.Context, req ApprovalRequest) (*Decision, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := &approval{req: req, options: req.Options, requestedAt: workflow.Now(ctx)}
+	if len(a.options) == 0 {
+		a.options = defaultApprovalOptions
+	}
+	if err := a.allow(ctx, req.Approvers, req.UserGroups); err != nil {
+		return nil, err
+	}
+	if err := a.post(ctx, req.Channel, req.ThreadTS, req.ReplyBroadcast, ""); err != nil {
+		return nil, err
+	}
+	if internal.DryRunEnabled(ctx) {
+		return &Decision{RequestedAt: a.requestedAt}, nil // No user selection in dry-run mode.
+	}
+	if req.Timeout > 0 {
+		a.deadline = a.requestedAt.Add(req.Timeout)
+	}
+
+	for {
+		p, err := a.next(ctx)
+		if errors.IsTimeout(err) && req.Escalation != nil && !a.escalated {
+			if err := a.escalate(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if errors.IsTimeout(err) {
+			a.status = ":hourglass: *Timed out* without a decision."
+			if updateErr := a.update(ctx); updateErr != nil {
+				return nil, fmt.Errorf("%w: %w", err, updateErr)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := a.vote(ctx, p)
+		if d != nil || err != nil {
+			return d, err
+		}
+	}
+}
+
+// approval is the state of a single [RequestApproval] call.
+type approval struct {
+	req     ApprovalRequest
+	options []ApprovalOption
+
+	allowed  map[string]bool // Nil if anyone may choose.
+	mentions []string        // Of the allowed users and user groups.
+	votes    []Vote          // Latest vote of each user, in chronological order.
+	messages []approvalMessage
+	status   string // Replaces the buttons when the request is no longer pending.
+
+	escalated   bool
+	requestedAt time.Time
+	deadline    time.Time
+}
+
+type approvalMessage struct {
+	channel string
+	ts      string
+	note    string // Optional markdown text above the request.
+}
+
+// allow adds users and the members of user groups to the set of allowed approvers.
+func (a *approval) allow(ctx workflow.Context, users, groups []string) error {
+	if len(users) == 0 && len(groups) == 0 {
+		return nil
+	}
+	if a.allowed == nil {
+		a.allowed = map[string]bool{}
+	}
+
+	for _, u := range users {
+		a.allowed[u] = true
+		a.mentions = append(a.mentions, "<@"+u+">")
+	}
+	for _, g := range groups {
+		members, err := UserGroupsUsersList(ctx, g, false)
+		if err != nil {
+			return err
+		}
+		for _, u := range members {
+			a.allowed[u] = true
+		}
+		a.mentions = append(a.mentions, "<!subteam^"+g+">")
+	}
+
+	return nil
+}
+
+// next waits for the next button click in any of the request's messages, until the current deadline.
+func (a *approval) next(ctx workflow.Context) (*events.BlockActionsPayload, error) {
+	var timeout time.Duration
+	if !a.deadline.IsZero() {
+		if timeout = a.deadline.Sub(workflow.Now(ctx)); timeout <= 0 {
+			return nil, errors.ErrTimeout
+		}
+	}
+
+	return events.Receive(ctx, events.BlockActionsSignal, timeout, func(p *events.BlockActionsPayload) bool {
+		if p.Container == nil || len(p.Actions) == 0 || p.Actions[0].BlockID != approvalBlockID {
+			return false
+		}
+		return slices.ContainsFunc(a.messages, func(m approvalMessage) bool {
+			return m.channel == p.Container.ChannelID && m.ts == p.Container.MessageTS
+		})
+	})
+}
+
+// vote counts a user's choice, and returns a decision if the chosen option reached its quorum.
+func (a *approval) vote(ctx workflow.Context, p *events.BlockActionsPayload) (*Decision, error) {
+	user := p.User.ID
+	if a.allowed != nil && !a.allowed[user] {
+		// Failing to notify the user doesn't affect the request, so keep waiting for other choices.
+		if err := ChatPostEphemeral(ctx, ChatPostEphemeralRequest{
+			Channel: p.Container.ChannelID, User: user, ThreadTS: p.Container.ThreadTS,
+			Text: "You are not allowed to decide on this request.",
+		}); err != nil {
+			workflow.GetLogger(ctx).Warn("failed to notify user who isn't allowed to decide", "user", user, "error", err)
+		}
+		return nil, nil
+	}
+
+	i := slices.IndexFunc(a.options, func(o ApprovalOption) bool { return o.Value == p.Actions[0].Value })
+	if i < 0 {
+		return nil, nil // Stale button, e.g. from a previous version of the workflow.
+	}
+	opt := a.options[i]
+
+	v := Vote{UserID: user, Option: opt.Value}
+	if a.req.RequireJustification {
+		text, ok, err := a.justify(ctx, p.TriggerID, opt)
+		if err != nil || !ok {
+			return nil, err
+		}
+		v.Justification = text
+	}
+	v.Time = workflow.Now(ctx)
+
+	a.votes = slices.DeleteFunc(a.votes, func(old Vote) bool { return old.UserID == user })
+	a.votes = append(a.votes, v)
+
+	var votes []Vote
+	for _, other := range a.votes {
+		if other.Option == opt.Value {
+			votes = append(votes, other)
+		}
+	}
+	if len(votes) < a.quorum(opt) {
+		return nil, a.update(ctx)
+	}
+
+	users := make([]string, 0, len(votes))
+	for _, other := range votes {
+		users = append(users, "<@"+other.UserID+">")
+	}
+	a.status = "*Decision:* " + opt.Label + ", by " + strings.Join(users, ", ")
+
+	d := &Decision{
+		Option: opt.Value, Label: opt.Label, UserID: user, Votes: votes,
+		Channel: p.Container.ChannelID, TS: p.Container.MessageTS, Escalated: a.escalated,
+		RequestedAt: a.requestedAt, DecidedAt: v.Time,
+	}
+	return d, a.update(ctx)
+}
+
+// justify opens a modal which asks the user to justify their choice, and waits for it. It returns
+// false (without an error) if the user closes the modal, or doesn't submit it in time.
+func (a *approval) justify(ctx workflow.Context, triggerID string, opt ApprovalOption) (string, bool, error) {
+	in := blocks.NewInput("Justification", blocks.NewPlainTextInput(justificationActionID, true))
+	in.BlockID = justificationBlockID
+	view, err := NewModal("Justification", "Submit", blocks.NewSection(blocks.Mrkdwn("You chose: *"+opt.Label+"*")), in)
+	if err != nil {
+		return "", false, err
+	}
+
+	timeout := justificationTimeout
+	if !a.deadline.IsZero() {
+		timeout = min(timeout, a.deadline.Sub(workflow.Now(ctx)))
+	}
+
+	r, err := ViewsOpenAndWait(ctx, triggerID, view, timeout)
+	if errors.IsTimeout(err) {
+		return "", false, nil
+	}
+	if err != nil || !r.Submitted {
+		return "", false, err
+	}
+
+	v, _ := r.Value(justificationBlockID, justificationActionID)
+	return v.Value, true, nil
+}
+
+// escalate posts the request again, in the escalation channel, and extends the deadline.
+func (a *approval) escalate(ctx workflow.Context) error {
+	e := a.req.Escalation
+	a.escalated = true
+	a.deadline = time.Time{}
+	if e.Timeout > 0 {
+		a.deadline = workflow.Now(ctx).Add(e.Timeout)
+	}
+
+	if a.allowed != nil {
+		if err := a.allow(ctx, e.Approvers, e.UserGroups); err != nil {
+			return err
+		}
+	}
+
+	a.messages[0].note = ":arrow_heading_up: Escalated to <#" + e.Channel + ">"
+	if err := a.post(ctx, e.Channel, "", false, e.Message); err != nil {
+		return err
+	}
+	return a.update(ctx)
+}
+
+// post sends a new message with the current state of the request.
+func (a *approval) post(ctx workflow.Context, channel, threadTS string, broadcast bool, note string) error {
+	m := approvalMessage{channel: channel, note: note}
+	bs, err := a.blocks(m)
+	if err != nil {
+		return err
+	}
+
+	req := ChatPostMessageRequest{Channel: channel, Blocks: bs, Text: a.text(), ThreadTS: threadTS, ReplyBroadcast: broadcast}
+	resp, err := ChatPostMessage(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	m.channel, m.ts = cmp.Or(resp.Channel, channel), resp.TS
+	a.messages = append(a.messages, m)
+	return nil
+}
+
+// update replaces all the request's messages with its current state.
+func (a *approval) update(ctx workflow.Context) error {
+	for _, m := range a.messages {
+		bs, err := a.blocks(m)
+		if err != nil {
+			return err
+		}
+		if err := ChatUpdate(ctx, ChatUpdateRequest{Channel: m.channel, TS: m.ts, Blocks: bs, Text: a.text()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text returns the fallback text of the request's messages, for notifications.
+func (a *approval) text() string {
+	return cmp.Or(a.req.Header, a.req.Message)
+}
+
+// blocks returns the layout of a message with the current state of the request.
+func (a *approval) blocks(m approvalMessage) ([]map[string]any, error) {
+	var bs []blocks.Block
+	if m.note != "" {
+		bs = append(bs, blocks.NewSection(blocks.Mrkdwn(m.note)))
+	}
+	if a.req.Header != "" {
+		bs = append(bs, blocks.NewHeader(a.req.Header))
+	}
+	bs = append(bs, blocks.NewSection(blocks.Mrkdwn(a.req.Message)))
+
+	if len(a.votes) > 0 {
+		lines := make([]string, 0, len(a.votes))
+		for _, v := range a.votes {
+			line := "<@" + v.UserID + "> chose *" + a.label(v.Option) + "*"
+			if v.Justification != "" {
+				line += ": " + mrkdwnEscaper.Replace(v.Justification)
+			}
+			lines = append(lines, line)
+		}
+		bs = append(bs, blocks.NewContext(blocks.Mrkdwn(truncate(strings.Join(lines, "\n"), 3000))))
+	}
+
+	if a.status != "" {
+		return blocks.Encode(append(bs, blocks.NewSection(blocks.Mrkdwn(a.status)))...)
+	}
+
+	buttons := make([]blocks.Element, 0, len(a.options))
+	for i, o := range a.options {
+		buttons = append(buttons, blocks.NewButton("option_"+strconv.Itoa(i), o.Label, o.Value).WithStyle(o.Style))
+	}
+	actions := blocks.NewActions(buttons...)
+	actions.BlockID = approvalBlockID
+	bs = append(bs, actions)
+
+	if rule := a.rule(); rule != "" {
+		bs = append(bs, blocks.NewContext(blocks.Mrkdwn(truncate(rule, 3000))))
+	}
+
+	return blocks.Encode(bs...)
+}
+
+// quorum returns the number of matching choices which are required to choose an option.
+func (a *approval) quorum(opt ApprovalOption) int {
+	return cmp.Or(opt.Quorum, a.req.Quorum, 1)
+}
+
+// rule describes the quorum of each option and the allowed approvers, if they're not trivial.
+func (a *approval) rule() string {
+	quorums := make([]int, 0, len(a.options))
+	for _, o := range a.options {
+		quorums = append(quorums, a.quorum(o))
+	}
+	if slices.Max(quorums) == 1 && len(a.mentions) == 0 {
+		return ""
+	}
+
+	rule := "Requires " + choices(quorums[0])
+	if slices.ContainsFunc(quorums, func(q int) bool { return q != quorums[0] }) {
+		parts := make([]string, 0, len(a.options))
+		for i, o := range a.options {
+			parts = append(parts, choices(quorums[i])+" for *"+o.Label+"*")
+		}
+		rule = "Requires " + strings.Join(parts, ", or ")
+	}
+
+	if len(a.mentions) > 0 {
+		rule += " from: " + strings.Join(a.mentions, ", ")
+	}
+	return rule
+}
+
+func choices(quorum int) string {
+	if quorum == 1 {
+		return "1 choice"
+	}
+	return strconv.Itoa(quorum) + " matching choices"
+}
+
+func (a *approval) label(value string) string {
+	for _, o := range a.options {
+		if o.Value == value {
+			return o.Label
+		}
+	}
+	return value
+}
+
+// truncate shortens a string to a maximum number of characters, if necessary.
+func truncate(s string, n int) string {
+	rs := []rune(s)
+	if len(rs) <= n {
+		return s
+	}
+	return string(rs[:n-1]) + "…"
+}
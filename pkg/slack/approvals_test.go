@@ -0,0 +1,218 @@
+package slack_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const channel = "C1"
+
+func TestApprovalRequestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  slack.ApprovalRequest
+		want string // Empty if the request is valid.
+	}{
+		{
+			name: "anyone",
+			req:  slack.ApprovalRequest{Channel: channel, Message: "ok?", Quorum: 5},
+		},
+		{
+			name: "quorum_of_approvers",
+			req:  slack.ApprovalRequest{Channel: channel, Message: "ok?", Approvers: []string{"U1", "U2"}, Quorum: 2},
+		},
+		{
+			name: "quorum_too_high",
+			req:  slack.ApprovalRequest{Channel: channel, Message: "ok?", Approvers: []string{"U1", "U2", "U1"}, Quorum: 3},
+			want: `"Quorum" must be at most the number of approvers (2)`,
+		},
+		{
+			name: "option_quorum_too_high",
+			req: slack.ApprovalRequest{
+				Channel: channel, Message: "ok?", Approvers: []string{"U1"},
+				Options: []slack.ApprovalOption{{Label: "Yes", Value: "yes", Quorum: 2}, {Label: "No", Value: "no"}},
+			},
+			want: `"Options[0].Quorum" must be at most the number of approvers (1)`,
+		},
+		{
+			name: "quorum_with_escalation_approvers",
+			req: slack.ApprovalRequest{
+				Channel: channel, Message: "ok?", Approvers: []string{"U1"}, Quorum: 2, Timeout: time.Hour,
+				Escalation: &slack.ApprovalEscalation{Channel: "C2", Approvers: []string{"U2"}},
+			},
+		},
+		{
+			name: "quorum_with_user_groups",
+			req:  slack.ApprovalRequest{Channel: channel, Message: "ok?", Approvers: []string{"U1"}, UserGroups: []string{"S1"}, Quorum: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestApproval(t *testing.T) {
+	slackErr := func(_ *timpanitest.Worker, _ json.RawMessage) (any, error) {
+		return nil, temporal.NewNonRetryableApplicationError("Slack API error", "channel_not_found", nil)
+	}
+
+	tests := []struct {
+		name     string
+		req      slack.ApprovalRequest
+		handlers map[string]timpanitest.Handler
+		// Button clicks by user IDs, one minute apart, and an optional justification for each of them.
+		clicks        []string
+		justification string
+		wantUser      string // Of the decision, if there is one.
+		wantErr       string
+		wantTimeout   bool
+		wantBlocks    string // Substring of the last posted or updated message.
+	}{
+		{
+			name: "quorum_per_option",
+			req: slack.ApprovalRequest{Quorum: 2, Options: []slack.ApprovalOption{
+				{Label: "Approve", Value: "approve"}, {Label: "Deny", Value: "deny", Quorum: 1},
+			}},
+			wantBlocks: "Requires 2 matching choices for *Approve*, or 1 choice for *Deny*",
+			wantErr:    "timed out",
+		},
+		{
+			name:       "disallowed_user_and_failed_ephemeral_message",
+			req:        slack.ApprovalRequest{Approvers: []string{"U1"}},
+			handlers:   map[string]timpanitest.Handler{slack.ChatPostEphemeralActivityName: slackErr},
+			clicks:     []string{"U2", "U1"},
+			wantUser:   "U1",
+			wantBlocks: "*Decision:* Approve, by <@U1>",
+		},
+		{
+			name:          "escaped_justification",
+			req:           slack.ApprovalRequest{Quorum: 2, RequireJustification: true},
+			clicks:        []string{"U1"},
+			justification: "<!here> ship it & <https://evil.example|click>",
+			wantBlocks:    "<@U1> chose *Approve*: &lt;!here&gt; ship it &amp; &lt;https://evil.example|click&gt;",
+			wantErr:       "timed out",
+		},
+		{
+			name:        "timeout_and_failed_update",
+			req:         slack.ApprovalRequest{},
+			handlers:    map[string]timpanitest.Handler{slack.ChatUpdateActivityName: slackErr},
+			wantErr:     "timed out waiting for event: ",
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			for name, h := range tt.handlers {
+				w.Handle(name, h)
+			}
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			for i, user := range tt.clicks {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow(string(events.BlockActionsSignal), events.BlockActionsPayload{
+						TriggerID: "trigger-" + user,
+						User:      events.User{ID: user},
+						Container: &events.Container{Type: "message", ChannelID: channel, MessageTS: w.Messages(channel)[0].TS},
+						Actions:   []events.Action{{BlockID: "timpani_approval", Value: "approve"}},
+					})
+				}, time.Duration(i+1)*time.Minute)
+
+				if tt.justification != "" {
+					env.RegisterDelayedCallback(func() {
+						vs := w.Views()
+						v := vs[len(vs)-1]
+						v.State = &events.ViewState{Values: map[string]map[string]events.StateValue{
+							"timpani_justification": {"justification": {Type: "plain_text_input", Value: tt.justification}},
+						}}
+						env.SignalWorkflow(string(events.ViewSubmissionSignal), events.ViewSubmissionPayload{User: events.User{ID: user}, View: v})
+					}, time.Duration(i+1)*time.Minute+time.Second)
+				}
+			}
+
+			var d *slack.Decision
+			var err error
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				req := tt.req
+				req.Channel, req.Message, req.Timeout = channel, "Deploy?", time.Hour
+				d, err = slack.RequestApproval(ctx, req)
+				return nil
+			})
+			if wfErr := env.GetWorkflowError(); wfErr != nil {
+				t.Fatalf("workflow error: %v", wfErr)
+			}
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("RequestApproval() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("RequestApproval() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantTimeout && (!errors.IsTimeout(err) || !strings.Contains(err.Error(), "channel_not_found")) {
+				t.Errorf("RequestApproval() error = %v, want both a timeout and an update error", err)
+			}
+			if tt.wantUser != "" && (d == nil || d.UserID != tt.wantUser) {
+				t.Errorf("RequestApproval() = %+v, want a decision by %s", d, tt.wantUser)
+			}
+
+			if tt.wantBlocks != "" {
+				if got := lastBlocks(t, w); !strings.Contains(got, tt.wantBlocks) {
+					t.Errorf("message blocks = %s\nwant substring %q", got, tt.wantBlocks)
+				}
+			}
+		})
+	}
+}
+
+// lastBlocks returns the JSON-encoded blocks of the last Slack message that was posted or updated,
+// before the request timed out (the timeout replaces the message's buttons, but not its votes).
+func lastBlocks(t *testing.T, w *timpanitest.Worker) string {
+	t.Helper()
+
+	var bs []map[string]any
+	for _, c := range w.Calls("") {
+		switch c.Name {
+		case slack.ChatPostMessageActivityName, slack.ChatUpdateActivityName:
+			var req slack.ChatPostMessageRequest
+			if err := c.Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(c.Request), "Timed out") {
+				bs = req.Blocks
+			}
+		}
+	}
+
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(bs); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
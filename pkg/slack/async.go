@@ -0,0 +1,358 @@
+package slack
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/async"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+)
+
+// RequestApprovalAsync is an asynchronous version of [RequestApproval].
+func RequestApprovalAsync(ctx workflow.Context, req ApprovalRequest) async.Future[*Decision] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Decision, error) {
+		return RequestApproval(ctx, req)
+	})
+}
+
+// AuthTestAsync is an asynchronous version of [AuthTest].
+func AuthTestAsync(ctx workflow.Context) async.Future[*AuthTestResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*AuthTestResponse, error) {
+		return AuthTest(ctx)
+	})
+}
+
+// BookmarksAddAsync is an asynchronous version of [BookmarksAdd].
+func BookmarksAddAsync(ctx workflow.Context, channelID, title, url, emoji string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return BookmarksAdd(ctx, channelID, title, url, emoji)
+	})
+}
+
+// BookmarksEditTitleAsync is an asynchronous version of [BookmarksEditTitle].
+func BookmarksEditTitleAsync(ctx workflow.Context, channelID, bookmarkID, title string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return BookmarksEditTitle(ctx, channelID, bookmarkID, title)
+	})
+}
+
+// BookmarksListAsync is an asynchronous version of [BookmarksList].
+func BookmarksListAsync(ctx workflow.Context, channelID string) async.Future[[]Bookmark] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Bookmark, error) {
+		return BookmarksList(ctx, channelID)
+	})
+}
+
+// BookmarksRemoveAsync is an asynchronous version of [BookmarksRemove].
+func BookmarksRemoveAsync(ctx workflow.Context, channelID, bookmarkID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return BookmarksRemove(ctx, channelID, bookmarkID)
+	})
+}
+
+// BotsInfoAsync is an asynchronous version of [BotsInfo].
+func BotsInfoAsync(ctx workflow.Context, botID string) async.Future[*Bot] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Bot, error) {
+		return BotsInfo(ctx, botID)
+	})
+}
+
+// ChatDeleteAsync is an asynchronous version of [ChatDelete].
+func ChatDeleteAsync(ctx workflow.Context, channelID, timestamp string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ChatDelete(ctx, channelID, timestamp)
+	})
+}
+
+// ChatDeleteScheduledMessageAsync is an asynchronous version of [ChatDeleteScheduledMessage].
+func ChatDeleteScheduledMessageAsync(ctx workflow.Context, channelID, scheduledMessageID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ChatDeleteScheduledMessage(ctx, channelID, scheduledMessageID)
+	})
+}
+
+// ChatGetPermalinkAsync is an asynchronous version of [ChatGetPermalink].
+func ChatGetPermalinkAsync(ctx workflow.Context, channelID, timestamp string) async.Future[string] {
+	return async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return ChatGetPermalink(ctx, channelID, timestamp)
+	})
+}
+
+// ChatPostEphemeralAsync is an asynchronous version of [ChatPostEphemeral].
+func ChatPostEphemeralAsync(ctx workflow.Context, req ChatPostEphemeralRequest) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ChatPostEphemeral(ctx, req)
+	})
+}
+
+// ChatPostMessageAsync is an asynchronous version of [ChatPostMessage].
+func ChatPostMessageAsync(ctx workflow.Context, req ChatPostMessageRequest) async.Future[*ChatPostMessageResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*ChatPostMessageResponse, error) {
+		return ChatPostMessage(ctx, req)
+	})
+}
+
+// ChatScheduleMessageAsync is an asynchronous version of [ChatScheduleMessage].
+func ChatScheduleMessageAsync(ctx workflow.Context, req ChatScheduleMessageRequest) async.Future[*ChatScheduleMessageResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*ChatScheduleMessageResponse, error) {
+		return ChatScheduleMessage(ctx, req)
+	})
+}
+
+// ChatScheduledMessagesListAsync is an asynchronous version of [ChatScheduledMessagesList].
+func ChatScheduledMessagesListAsync(ctx workflow.Context, channelID string) async.Future[[]ScheduledMessage] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]ScheduledMessage, error) {
+		return ChatScheduledMessagesList(ctx, channelID)
+	})
+}
+
+// ChatUpdateAsync is an asynchronous version of [ChatUpdate].
+func ChatUpdateAsync(ctx workflow.Context, req ChatUpdateRequest) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ChatUpdate(ctx, req)
+	})
+}
+
+// TimpaniPostApprovalWorkflowAsync is an asynchronous version of [TimpaniPostApprovalWorkflow].
+func TimpaniPostApprovalWorkflowAsync(ctx workflow.Context, req TimpaniPostApprovalRequest) async.Future[map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) (map[string]any, error) {
+		return TimpaniPostApprovalWorkflow(ctx, req)
+	})
+}
+
+// ConversationsArchiveAsync is an asynchronous version of [ConversationsArchive].
+func ConversationsArchiveAsync(ctx workflow.Context, channelID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsArchive(ctx, channelID)
+	})
+}
+
+// ConversationsCloseAsync is an asynchronous version of [ConversationsClose].
+func ConversationsCloseAsync(ctx workflow.Context, channelID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsClose(ctx, channelID)
+	})
+}
+
+// ConversationsCreateAsync is an asynchronous version of [ConversationsCreate].
+func ConversationsCreateAsync(ctx workflow.Context, name string, private bool) async.Future[string] {
+	return async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return ConversationsCreate(ctx, name, private)
+	})
+}
+
+// ConversationsHistoryAsync is an asynchronous version of [ConversationsHistory].
+func ConversationsHistoryAsync(ctx workflow.Context, channelID, oldest, latest string) async.Future[[]Message] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Message, error) {
+		return ConversationsHistory(ctx, channelID, oldest, latest)
+	})
+}
+
+// ConversationsInfoAsync is an asynchronous version of [ConversationsInfo].
+func ConversationsInfoAsync(ctx workflow.Context, channelID string, locale, numMembers bool) async.Future[*Conversation] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Conversation, error) {
+		return ConversationsInfo(ctx, channelID, locale, numMembers)
+	})
+}
+
+// ConversationsInviteAsync is an asynchronous version of [ConversationsInvite].
+func ConversationsInviteAsync(ctx workflow.Context, channelID string, users []string, force bool) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsInvite(ctx, channelID, users, force)
+	})
+}
+
+// ConversationsJoinAsync is an asynchronous version of [ConversationsJoin].
+func ConversationsJoinAsync(ctx workflow.Context, channelID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsJoin(ctx, channelID)
+	})
+}
+
+// ConversationsKickAsync is an asynchronous version of [ConversationsKick].
+func ConversationsKickAsync(ctx workflow.Context, channelID, userID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsKick(ctx, channelID, userID)
+	})
+}
+
+// ConversationsLeaveAsync is an asynchronous version of [ConversationsLeave].
+func ConversationsLeaveAsync(ctx workflow.Context, channelID string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsLeave(ctx, channelID)
+	})
+}
+
+// ConversationsListAsync is an asynchronous version of [ConversationsList].
+func ConversationsListAsync(ctx workflow.Context, types []string, excludeArchived bool) async.Future[[]Conversation] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Conversation, error) {
+		return ConversationsList(ctx, types, excludeArchived)
+	})
+}
+
+// ConversationsMembersAsync is an asynchronous version of [ConversationsMembers].
+func ConversationsMembersAsync(ctx workflow.Context, channelID string) async.Future[[]string] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]string, error) {
+		return ConversationsMembers(ctx, channelID)
+	})
+}
+
+// ConversationsOpenAsync is an asynchronous version of [ConversationsOpen].
+func ConversationsOpenAsync(ctx workflow.Context, users []string) async.Future[string] {
+	return async.Go(ctx, func(ctx workflow.Context) (string, error) {
+		return ConversationsOpen(ctx, users)
+	})
+}
+
+// ConversationsRenameAsync is an asynchronous version of [ConversationsRename].
+func ConversationsRenameAsync(ctx workflow.Context, channelID, name string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsRename(ctx, channelID, name)
+	})
+}
+
+// ConversationsRepliesAsync is an asynchronous version of [ConversationsReplies].
+func ConversationsRepliesAsync(ctx workflow.Context, channelID, threadTS, oldest, latest string) async.Future[[]Message] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]Message, error) {
+		return ConversationsReplies(ctx, channelID, threadTS, oldest, latest)
+	})
+}
+
+// ConversationsSetPurposeAsync is an asynchronous version of [ConversationsSetPurpose].
+func ConversationsSetPurposeAsync(ctx workflow.Context, channelID, purpose string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsSetPurpose(ctx, channelID, purpose)
+	})
+}
+
+// ConversationsSetTopicAsync is an asynchronous version of [ConversationsSetTopic].
+func ConversationsSetTopicAsync(ctx workflow.Context, channelID, topic string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ConversationsSetTopic(ctx, channelID, topic)
+	})
+}
+
+// FilesGetUploadURLExternalAsync is an asynchronous version of [FilesGetUploadURLExternal].
+// Its future's value contains both the upload URL and the file ID.
+func FilesGetUploadURLExternalAsync(
+	ctx workflow.Context,
+	length int,
+	filename, snippetType, altTxt string,
+) async.Future[*FilesGetUploadURLExternalResponse] {
+	req := FilesGetUploadURLExternalRequest{Length: length, Filename: filename, SnippetType: snippetType, AltTxt: altTxt}
+	return internal.StartTimpaniActivity[FilesGetUploadURLExternalResponse](ctx, FilesGetUploadURLExternalActivityName, req)
+}
+
+// FilesCompleteUploadExternalAsync is an asynchronous version of [FilesCompleteUploadExternal].
+func FilesCompleteUploadExternalAsync(ctx workflow.Context, req FilesCompleteUploadExternalRequest) async.Future[[]File] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]File, error) {
+		return FilesCompleteUploadExternal(ctx, req)
+	})
+}
+
+// FilesDeleteAsync is an asynchronous version of [FilesDelete].
+func FilesDeleteAsync(ctx workflow.Context, file string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return FilesDelete(ctx, file)
+	})
+}
+
+// TimpaniUploadExternalAsync is an asynchronous version of [TimpaniUploadExternal].
+func TimpaniUploadExternalAsync(ctx workflow.Context, url, mimeType string, content []byte) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return TimpaniUploadExternal(ctx, url, mimeType, content)
+	})
+}
+
+// ReactionsAddAsync is an asynchronous version of [ReactionsAdd].
+func ReactionsAddAsync(ctx workflow.Context, channelID, timestamp, name string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ReactionsAdd(ctx, channelID, timestamp, name)
+	})
+}
+
+// ReactionsGetAsync is an asynchronous version of [ReactionsGet].
+func ReactionsGetAsync(ctx workflow.Context, channelID, timestamp string) async.Future[map[string]any] {
+	return async.Go(ctx, func(ctx workflow.Context) (map[string]any, error) {
+		return ReactionsGet(ctx, channelID, timestamp)
+	})
+}
+
+// ReactionsRemoveAsync is an asynchronous version of [ReactionsRemove].
+func ReactionsRemoveAsync(ctx workflow.Context, channelID, timestamp, name string) async.Future[struct{}] {
+	return async.GoNoResp(ctx, func(ctx workflow.Context) error {
+		return ReactionsRemove(ctx, channelID, timestamp, name)
+	})
+}
+
+// UserGroupsListAsync is an asynchronous version of [UserGroupsList].
+func UserGroupsListAsync(ctx workflow.Context, count, disabled, users bool) async.Future[[]UserGroup] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]UserGroup, error) {
+		return UserGroupsList(ctx, count, disabled, users)
+	})
+}
+
+// UserGroupsUsersListAsync is an asynchronous version of [UserGroupsUsersList].
+func UserGroupsUsersListAsync(ctx workflow.Context, usergroup string, includeDisabled bool) async.Future[[]string] {
+	return async.Go(ctx, func(ctx workflow.Context) ([]string, error) {
+		return UserGroupsUsersList(ctx, usergroup, includeDisabled)
+	})
+}
+
+// UsersInfoAsync is an asynchronous version of [UsersInfo].
+func UsersInfoAsync(ctx workflow.Context, userID string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersInfo(ctx, userID)
+	})
+}
+
+// UsersLookupByEmailAsync is an asynchronous version of [UsersLookupByEmail].
+func UsersLookupByEmailAsync(ctx workflow.Context, email string) async.Future[*User] {
+	return async.Go(ctx, func(ctx workflow.Context) (*User, error) {
+		return UsersLookupByEmail(ctx, email)
+	})
+}
+
+// UsersProfileGetAsync is an asynchronous version of [UsersProfileGet].
+func UsersProfileGetAsync(ctx workflow.Context, userID string) async.Future[*Profile] {
+	return async.Go(ctx, func(ctx workflow.Context) (*Profile, error) {
+		return UsersProfileGet(ctx, userID)
+	})
+}
+
+// ViewsOpenAsync is an asynchronous version of [ViewsOpen].
+func ViewsOpenAsync(ctx workflow.Context, triggerID string, view View) async.Future[*events.View] {
+	return async.Go(ctx, func(ctx workflow.Context) (*events.View, error) {
+		return ViewsOpen(ctx, triggerID, view)
+	})
+}
+
+// ViewsOpenAndWaitAsync is an asynchronous version of [ViewsOpenAndWait].
+func ViewsOpenAndWaitAsync(ctx workflow.Context, triggerID string, view View, timeout time.Duration) async.Future[*ModalResult] {
+	return async.Go(ctx, func(ctx workflow.Context) (*ModalResult, error) {
+		return ViewsOpenAndWait(ctx, triggerID, view, timeout)
+	})
+}
+
+// ViewsPublishAsync is an asynchronous version of [ViewsPublish].
+func ViewsPublishAsync(ctx workflow.Context, userID string, view View) async.Future[*events.View] {
+	return async.Go(ctx, func(ctx workflow.Context) (*events.View, error) {
+		return ViewsPublish(ctx, userID, view)
+	})
+}
+
+// ViewsPushAsync is an asynchronous version of [ViewsPush].
+func ViewsPushAsync(ctx workflow.Context, triggerID string, view View) async.Future[*events.View] {
+	return async.Go(ctx, func(ctx workflow.Context) (*events.View, error) {
+		return ViewsPush(ctx, triggerID, view)
+	})
+}
+
+// ViewsUpdateAsync is an asynchronous version of [ViewsUpdate].
+func ViewsUpdateAsync(ctx workflow.Context, viewID, hash string, view View) async.Future[*events.View] {
+	return async.Go(ctx, func(ctx workflow.Context) (*events.View, error) {
+		return ViewsUpdate(ctx, viewID, hash, view)
+	})
+}
@@ -11,6 +11,12 @@ const (
 	AuthTestActivityName = "slack.auth.test"
 ) //revive:enable:exported
 
+// AuthTestRequest is based on:
+// https://docs.slack.dev/reference/methods/auth.test/
+type AuthTestRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+}
+
 // AuthTestResponse is based on:
 // https://docs.slack.dev/reference/methods/auth.test/
 type AuthTestResponse struct {
@@ -29,5 +35,5 @@ type AuthTestResponse struct {
 // AuthTest is based on:
 // https://docs.slack.dev/reference/methods/auth.test/
 func AuthTest(ctx workflow.Context) (*AuthTestResponse, error) {
-	return internal.ExecuteTimpaniActivity[AuthTestResponse](ctx, AuthTestActivityName, nil)
+	return internal.ExecuteTimpaniActivity[AuthTestResponse](ctx, AuthTestActivityName, AuthTestRequest{})
 }
@@ -0,0 +1,367 @@
+// Package blocks provides typed [Block Kit] blocks, elements and composition
+// objects, for Slack messages, modals and App Home tabs, with client-side
+// validation of Slack's documented length and count limits.
+//
+// Invalid blocks are reported as an [errors.ValidationFailedError]
+// before sending them to Slack, instead of an "invalid_blocks" error:
+//
+//	bs, err := blocks.Encode(
+//		blocks.NewHeader("Deployment"),
+//		blocks.NewSection(blocks.Mrkdwn("*Service:* api")).WithAccessory(
+//			blocks.NewButton("approve", "Approve", "yes").WithStyle(blocks.Primary),
+//		),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	req := slack.ChatPostMessageRequest{Channel: id, Text: "Deployment", Blocks: bs}
+//
+// This package does not depend on the slack package, so the slack package may use it.
+//
+// [Block Kit]: https://docs.slack.dev/block-kit/
+// [errors.ValidationFailedError]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ValidationFailedError
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Maximum number of blocks in different surfaces, based on:
+// https://docs.slack.dev/reference/block-kit/blocks/
+const (
+	MaxMessageBlocks = 50
+	MaxViewBlocks    = 100 // Modals and App Home tabs.
+)
+
+// Block is a single Block Kit layout block, e.g. [Section] or [Actions].
+type Block interface {
+	block()
+	validate(v *internal.Validator, path string)
+}
+
+// Validate checks that the given blocks don't exceed any of Slack's documented
+// limits, including the maximum number of blocks (e.g. [MaxMessageBlocks]).
+// Problems refer to fields by their JSON paths, e.g. "blocks[0].text.text".
+func Validate(maxBlocks int, bs ...Block) error {
+	v := internal.NewValidator("")
+	maxItems(v, "blocks", len(bs), maxBlocks)
+	tables := 0
+	for i, b := range bs {
+		p := fmt.Sprintf("blocks[%d]", i)
+		if b == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		if _, ok := b.(*Table); ok {
+			tables++
+		}
+		b.validate(v, p)
+	}
+	v.Check(tables <= 1, `"blocks" must have at most 1 table`)
+	return v.Err()
+}
+
+// Encode validates blocks for a Slack message, and encodes them as JSON objects, which
+// can be used as-is in the "Blocks" field of chat requests in the slack package.
+func Encode(bs ...Block) ([]map[string]any, error) {
+	return encode(MaxMessageBlocks, bs)
+}
+
+// EncodeView is similar to [Encode], but for modals and App Home tabs, which allow more blocks.
+func EncodeView(bs ...Block) ([]map[string]any, error) {
+	return encode(MaxViewBlocks, bs)
+}
+
+func encode(maxBlocks int, bs []Block) ([]map[string]any, error) {
+	if err := Validate(maxBlocks, bs...); err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(bs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Slack blocks: %w", err)
+	}
+
+	var ms []map[string]any
+	if err := json.Unmarshal(b, &ms); err != nil {
+		return nil, fmt.Errorf("failed to encode Slack blocks: %w", err)
+	}
+	return ms, nil
+}
+
+// marshal encodes a block, element or object as a JSON object,
+// with its Block Kit type as the first field.
+func marshal(typ string, v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Slack %q object: %w", typ, err)
+	}
+
+	t := fmt.Appendf(nil, `{"type":%q`, typ)
+	if len(b) <= 2 {
+		return append(t, '}'), nil
+	}
+	return append(append(t, ','), b[1:]...), nil
+}
+
+// Section is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/section-block/
+type Section struct {
+	Text      *Text   `json:"text,omitempty"`
+	BlockID   string  `json:"block_id,omitempty"`
+	Fields    []*Text `json:"fields,omitempty"`
+	Accessory Element `json:"accessory,omitempty"`
+	Expand    bool    `json:"expand,omitempty"`
+}
+
+// NewSection returns a section block with the given text.
+func NewSection(text *Text) *Section {
+	return &Section{Text: text}
+}
+
+// WithAccessory sets the section's accessory element, e.g. a [Button] or an [ImageElement].
+func (b *Section) WithAccessory(e Element) *Section {
+	b.Accessory = e
+	return b
+}
+
+// WithFields adds text fields to the section, which are rendered in two columns.
+func (b *Section) WithFields(fields ...*Text) *Section {
+	b.Fields = append(b.Fields, fields...)
+	return b
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Section) MarshalJSON() ([]byte, error) {
+	type section Section
+	return marshal("section", (*section)(b))
+}
+
+func (b *Section) block() {}
+
+func (b *Section) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Check(b.Text != nil || len(b.Fields) > 0, fmt.Sprintf(`%q requires "text" or "fields"`, path))
+	text(v, path+".text", b.Text, 1, 3000, false)
+	maxItems(v, path+".fields", len(b.Fields), 10)
+	for i, f := range b.Fields {
+		p := fmt.Sprintf("%s.fields[%d]", path, i)
+		v.Check(f != nil, fmt.Sprintf("%q is nil", p))
+		text(v, p, f, 1, 2000, false)
+	}
+	element(v, path+".accessory", b.Accessory, sectionAccessories)
+}
+
+// Context is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/context-block/
+type Context struct {
+	Elements []ContextElement `json:"elements"`
+	BlockID  string           `json:"block_id,omitempty"`
+}
+
+// ContextElement is an element of a [Context] block: a [Text] or an [ImageElement].
+type ContextElement interface {
+	contextElement()
+	validate(v *internal.Validator, path string)
+}
+
+// NewContext returns a context block with the given texts and images.
+func NewContext(elements ...ContextElement) *Context {
+	return &Context{Elements: elements}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Context) MarshalJSON() ([]byte, error) {
+	type context Context
+	return marshal("context", (*context)(b))
+}
+
+func (b *Context) block() {}
+
+func (b *Context) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".elements", b.Elements)
+	maxItems(v, path+".elements", len(b.Elements), 10)
+	for i, e := range b.Elements {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		if e == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		e.validate(v, p)
+	}
+}
+
+// Actions is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/actions-block/
+type Actions struct {
+	Elements []Element `json:"elements"`
+	BlockID  string    `json:"block_id,omitempty"`
+}
+
+// NewActions returns an actions block with the given interactive elements.
+func NewActions(elements ...Element) *Actions {
+	return &Actions{Elements: elements}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Actions) MarshalJSON() ([]byte, error) {
+	type actions Actions
+	return marshal("actions", (*actions)(b))
+}
+
+func (b *Actions) block() {}
+
+func (b *Actions) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".elements", b.Elements)
+	maxItems(v, path+".elements", len(b.Elements), 25)
+	for i, e := range b.Elements {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		v.Check(e != nil, fmt.Sprintf("%q is nil", p))
+		element(v, p, e, actionsElements)
+	}
+}
+
+// Header is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/header-block/
+type Header struct {
+	Text    *Text  `json:"text"`
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// NewHeader returns a header block with the given plain text.
+func NewHeader(s string) *Header {
+	return &Header{Text: PlainText(s)}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Header) MarshalJSON() ([]byte, error) {
+	type header Header
+	return marshal("header", (*header)(b))
+}
+
+func (b *Header) block() {}
+
+func (b *Header) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".text", b.Text)
+	text(v, path+".text", b.Text, 1, 150, true)
+}
+
+// Divider is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/divider-block/
+type Divider struct {
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// NewDivider returns a divider block.
+func NewDivider() *Divider {
+	return &Divider{}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Divider) MarshalJSON() ([]byte, error) {
+	type divider Divider
+	return marshal("divider", (*divider)(b))
+}
+
+func (b *Divider) block() {}
+
+func (b *Divider) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+}
+
+// Image is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/image-block/
+type Image struct {
+	AltText   string     `json:"alt_text"`
+	ImageURL  string     `json:"image_url,omitempty"`
+	SlackFile *SlackFile `json:"slack_file,omitempty"`
+	Title     *Text      `json:"title,omitempty"`
+	BlockID   string     `json:"block_id,omitempty"`
+}
+
+// NewImage returns an image block with the given public image URL.
+func NewImage(url, altText string) *Image {
+	return &Image{ImageURL: url, AltText: altText}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Image) MarshalJSON() ([]byte, error) {
+	type image Image
+	return marshal("image", (*image)(b))
+}
+
+func (b *Image) block() {}
+
+func (b *Image) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".alt_text", b.AltText)
+	maxLen(v, path+".alt_text", b.AltText, 2000)
+	imageSource(v, path, b.ImageURL, b.SlackFile)
+	text(v, path+".title", b.Title, 1, 2000, true)
+}
+
+// Input is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/input-block/
+type Input struct {
+	Label          *Text   `json:"label"`
+	Element        Element `json:"element"`
+	DispatchAction bool    `json:"dispatch_action,omitempty"`
+	BlockID        string  `json:"block_id,omitempty"`
+	Hint           *Text   `json:"hint,omitempty"`
+	Optional       bool    `json:"optional,omitempty"`
+}
+
+// NewInput returns an input block with the given plain-text label and input element.
+func NewInput(label string, e Element) *Input {
+	return &Input{Label: PlainText(label), Element: e}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Input) MarshalJSON() ([]byte, error) {
+	type input Input
+	return marshal("input", (*input)(b))
+}
+
+func (b *Input) block() {}
+
+func (b *Input) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".label", b.Label)
+	text(v, path+".label", b.Label, 1, 2000, true)
+	v.Require(path+".element", b.Element)
+	element(v, path+".element", b.Element, inputElements)
+	text(v, path+".hint", b.Hint, 1, 2000, true)
+}
+
+// blockID checks the optional ID of a block, which is used to identify
+// it in interaction payloads, and must be unique within a message or view.
+func blockID(v *internal.Validator, path, id string) {
+	maxLen(v, path+".block_id", id, 255)
+}
+
+// maxLen adds a problem if a string field is longer than the given number of characters.
+func maxLen(v *internal.Validator, path, s string, n int) {
+	v.Check(utf8.RuneCountInString(s) <= n, fmt.Sprintf("%q must be at most %d characters", path, n))
+}
+
+// maxItems adds a problem if a list field has more than the given number of items.
+func maxItems(v *internal.Validator, path string, count, n int) {
+	v.Check(count <= n, fmt.Sprintf("%q must have at most %d items", path, n))
+}
+
+// imageSource checks that an image has exactly one source: a public URL or a Slack file.
+func imageSource(v *internal.Validator, path, url string, f *SlackFile) {
+	v.RequireAny(fmt.Sprintf(`%q or %q`, path+".image_url", path+".slack_file"), url, f)
+	v.Exclusive(fmt.Sprintf(`%q and %q`, path+".image_url", path+".slack_file"), url, f)
+	maxLen(v, path+".image_url", url, 3000)
+	if f != nil {
+		f.validate(v, path+".slack_file")
+	}
+}
@@ -0,0 +1,299 @@
+package blocks_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack/blocks"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxBlocks int
+		blocks    []blocks.Block
+		want      []string // Problems, in order.
+	}{
+		{
+			name: "valid_message",
+			blocks: []blocks.Block{
+				blocks.NewHeader("Deployment"),
+				blocks.NewSection(blocks.Mrkdwn("*Service:* api")).WithAccessory(
+					blocks.NewButton("approve", "Approve", "yes").WithStyle(blocks.Primary),
+				),
+				blocks.NewDivider(),
+				blocks.NewContext(blocks.Mrkdwn("context"), blocks.NewImageElement("https://example.com/a.png", "alt")),
+				blocks.NewActions(
+					blocks.NewSelect(blocks.StaticMenu, "pick", "Pick one", blocks.NewOption("A", "a")),
+					blocks.NewDatePicker("date"),
+				),
+				blocks.NewTable([]string{"a", "b"}, []string{"c", "d"}),
+			},
+		},
+		{
+			name: "valid_view",
+			blocks: []blocks.Block{
+				blocks.NewInput("Reason", blocks.NewPlainTextInput("reason", true)),
+				blocks.NewInput("Options", blocks.NewCheckboxes("opts", &blocks.Option{Text: blocks.Mrkdwn("*A*"), Value: "a"})),
+			},
+		},
+		{
+			name:   "too_many_blocks",
+			blocks: repeat[blocks.Block](blocks.NewDivider(), blocks.MaxMessageBlocks+1),
+			want:   []string{`"blocks" must have at most 50 items`},
+		},
+		{
+			name:      "view_blocks",
+			maxBlocks: blocks.MaxViewBlocks,
+			blocks:    repeat[blocks.Block](blocks.NewDivider(), blocks.MaxMessageBlocks+1),
+		},
+		{
+			name:   "nil_block",
+			blocks: []blocks.Block{nil},
+			want:   []string{`"blocks[0]" is nil`},
+		},
+		{
+			name:   "multiple_tables",
+			blocks: []blocks.Block{blocks.NewTable([]string{"a"}), blocks.NewTable([]string{"b"})},
+			want:   []string{`"blocks" must have at most 1 table`},
+		},
+		{
+			name:   "section_without_text",
+			blocks: []blocks.Block{&blocks.Section{}},
+			want:   []string{`"blocks[0]" requires "text" or "fields"`},
+		},
+		{
+			name:   "section_text_too_long",
+			blocks: []blocks.Block{blocks.NewSection(blocks.Mrkdwn(strings.Repeat("é", 3001)))},
+			want:   []string{`"blocks[0].text.text" must be at most 3000 characters`},
+		},
+		{
+			name:   "section_too_many_fields",
+			blocks: []blocks.Block{blocks.NewSection(nil).WithFields(repeat(blocks.PlainText("f"), 11)...)},
+			want:   []string{`"blocks[0].fields" must have at most 10 items`},
+		},
+		{
+			name:   "section_invalid_text_type",
+			blocks: []blocks.Block{blocks.NewSection(&blocks.Text{Type: "html", Text: "hi"})},
+			want:   []string{`invalid value "html" in field "blocks[0].text.type" (allowed: plain_text, mrkdwn)`},
+		},
+		{
+			name:   "section_disallowed_accessory",
+			blocks: []blocks.Block{blocks.NewSection(blocks.Mrkdwn("hi")).WithAccessory(blocks.NewPlainTextInput("in", false))},
+			want:   []string{`"blocks[0].accessory" can't be a "plain_text_input" element`},
+		},
+		{
+			name:   "header_mrkdwn",
+			blocks: []blocks.Block{&blocks.Header{Text: blocks.Mrkdwn("*hi*")}},
+			want:   []string{`"blocks[0].text" must be a "plain_text" object`},
+		},
+		{
+			name:   "header_too_long",
+			blocks: []blocks.Block{blocks.NewHeader(strings.Repeat("a", 151))},
+			want:   []string{`"blocks[0].text.text" must be at most 150 characters`},
+		},
+		{
+			name:   "header_without_text",
+			blocks: []blocks.Block{&blocks.Header{}},
+			want:   []string{`missing required field "blocks[0].text"`},
+		},
+		{
+			name:   "block_id_too_long",
+			blocks: []blocks.Block{&blocks.Divider{BlockID: strings.Repeat("a", 256)}},
+			want:   []string{`"blocks[0].block_id" must be at most 255 characters`},
+		},
+		{
+			name:   "empty_context",
+			blocks: []blocks.Block{blocks.NewContext()},
+			want:   []string{`missing required field "blocks[0].elements"`},
+		},
+		{
+			name:   "empty_actions",
+			blocks: []blocks.Block{blocks.NewActions()},
+			want:   []string{`missing required field "blocks[0].elements"`},
+		},
+		{
+			name: "invalid_button",
+			blocks: []blocks.Block{blocks.NewActions(
+				blocks.NewButton("b", strings.Repeat("a", 76), "v").WithStyle("loud").
+					WithConfirm(blocks.NewConfirm("Sure?", "Really?", "Yes", "")),
+			)},
+			want: []string{
+				`"blocks[0].elements[0].text.text" must be at most 75 characters`,
+				`invalid value "loud" in field "blocks[0].elements[0].style" (allowed: primary, danger)`,
+				`missing required field "blocks[0].elements[0].confirm.deny.text"`,
+			},
+		},
+		{
+			name:   "image_without_source",
+			blocks: []blocks.Block{&blocks.Image{AltText: "alt"}},
+			want:   []string{`at least one of "blocks[0].image_url" or "blocks[0].slack_file" is required`},
+		},
+		{
+			name: "image_with_two_sources",
+			blocks: []blocks.Block{
+				&blocks.Image{AltText: "alt", ImageURL: "https://example.com/a.png", SlackFile: &blocks.SlackFile{ID: "F1"}},
+			},
+			want: []string{`only one of "blocks[0].image_url" and "blocks[0].slack_file" may be set`},
+		},
+		{
+			name:   "input_without_element",
+			blocks: []blocks.Block{blocks.NewInput("Label", nil)},
+			want:   []string{`missing required field "blocks[0].element"`},
+		},
+		{
+			name:   "input_disallowed_element",
+			blocks: []blocks.Block{blocks.NewInput("Label", blocks.NewButton("b", "B", "v"))},
+			want:   []string{`"blocks[0].element" can't be a "button" element`},
+		},
+		{
+			name:   "input_invalid_lengths",
+			blocks: []blocks.Block{blocks.NewInput("Label", &blocks.PlainTextInput{MinLength: 10, MaxLength: 5})},
+			want:   []string{`"blocks[0].element.max_length" must not be less than "blocks[0].element.min_length"`},
+		},
+		{
+			name:   "invalid_date",
+			blocks: []blocks.Block{blocks.NewActions(&blocks.DatePicker{InitialDate: "18/10/2026"})},
+			want:   []string{`"blocks[0].elements[0].initial_date" must be in the format "YYYY-MM-DD"`},
+		},
+		{
+			name:   "static_select_without_options",
+			blocks: []blocks.Block{blocks.NewActions(blocks.NewSelect(blocks.StaticMenu, "s", "Pick"))},
+			want: []string{
+				`at least one of "blocks[0].elements[0].options" or "blocks[0].elements[0].option_groups" is required`,
+			},
+		},
+		{
+			name: "users_select_with_options",
+			blocks: []blocks.Block{blocks.NewActions(
+				blocks.NewSelect(blocks.UsersMenu, "s", "Pick", blocks.NewOption("A", "a")),
+			)},
+			want: []string{`"blocks[0].elements[0]" may have options only in static menus`},
+		},
+		{
+			name: "multi_select_with_singular_initial_option",
+			blocks: []blocks.Block{blocks.NewActions(&blocks.Select{
+				Menu: blocks.StaticMenu, Multi: true, Options: []*blocks.Option{blocks.NewOption("A", "a")},
+				InitialOption: blocks.NewOption("A", "a"),
+			})},
+			want: []string{`"blocks[0].elements[0]" must use plural "initial_*" fields`},
+		},
+		{
+			name: "option_with_mrkdwn_in_select",
+			blocks: []blocks.Block{blocks.NewActions(
+				blocks.NewSelect(blocks.StaticMenu, "s", "Pick", &blocks.Option{Text: blocks.Mrkdwn("*A*"), Value: "a"}),
+			)},
+			want: []string{`"blocks[0].elements[0].options[0].text" must be a "plain_text" object`},
+		},
+		{
+			name:   "too_many_checkboxes",
+			blocks: []blocks.Block{blocks.NewActions(blocks.NewCheckboxes("c", repeat(blocks.NewOption("A", "a"), 11)...))},
+			want:   []string{`"blocks[0].elements[0].options" must have at most 10 items`},
+		},
+		{
+			name: "invalid_rich_text",
+			blocks: []blocks.Block{blocks.NewRichText(
+				blocks.NewRichTextSection(&blocks.RichTextElement{Type: "broadcast", Range: "world"}),
+				&blocks.RichTextList{Style: "dashed", Elements: []*blocks.RichTextSection{blocks.NewRichTextSection()}},
+			)},
+			want: []string{
+				`invalid value "world" in field "blocks[0].elements[0].elements[0].range" (allowed: here, channel, everyone)`,
+				`invalid value "dashed" in field "blocks[0].elements[1].style" (allowed: bullet, ordered)`,
+			},
+		},
+		{
+			name: "invalid_table",
+			blocks: []blocks.Block{&blocks.Table{
+				Rows:           [][]blocks.TableCell{{&blocks.RawText{}, nil}},
+				ColumnSettings: []*blocks.ColumnSetting{{Align: "justify"}},
+			}},
+			want: []string{
+				`missing required field "blocks[0].rows[0][0].text"`,
+				`"blocks[0].rows[0][1]" is nil`,
+				`invalid value "justify" in field "blocks[0].column_settings[0].align" (allowed: left, center, right)`,
+			},
+		},
+		{
+			name: "multiple_problems",
+			blocks: []blocks.Block{
+				blocks.NewHeader(""),
+				blocks.NewSection(blocks.Mrkdwn("")),
+			},
+			want: []string{
+				`missing required field "blocks[0].text.text"`,
+				`missing required field "blocks[1].text.text"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxBlocks := tt.maxBlocks
+			if maxBlocks == 0 {
+				maxBlocks = blocks.MaxMessageBlocks
+			}
+
+			err := blocks.Validate(maxBlocks, tt.blocks...)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %q", tt.want)
+			}
+			if errors.IsRetryable(err) {
+				t.Errorf("Validate() error = %T, want a non-retryable validation error", err)
+			}
+			if want := "invalid request: " + strings.Join(tt.want, "; "); err.Error() != want {
+				t.Errorf("Validate() error =\n%s\nwant\n%s", err, want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	got, err := blocks.Encode(
+		blocks.NewHeader("Title"),
+		blocks.NewSection(blocks.Mrkdwn("*hi*")).WithAccessory(blocks.NewButton("b", "Click", "v").WithStyle(blocks.Danger)),
+		blocks.NewDivider(),
+	)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var want []map[string]any
+	if err := json.Unmarshal([]byte(`[
+		{"type": "header", "text": {"type": "plain_text", "text": "Title", "emoji": true}},
+		{"type": "section", "text": {"type": "mrkdwn", "text": "*hi*"}, "accessory": {
+			"type": "button", "text": {"type": "plain_text", "text": "Click", "emoji": true},
+			"action_id": "b", "value": "v", "style": "danger"
+		}},
+		{"type": "divider"}
+	]`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Encode() = %v, want %v", got, want)
+	}
+
+	if _, err := blocks.Encode(repeat[blocks.Block](blocks.NewDivider(), blocks.MaxMessageBlocks+1)...); err == nil {
+		t.Error("Encode() error = nil, want a validation error")
+	}
+	if _, err := blocks.EncodeView(repeat[blocks.Block](blocks.NewDivider(), blocks.MaxMessageBlocks+1)...); err != nil {
+		t.Errorf("EncodeView() error = %v", err)
+	}
+}
+
+func repeat[T any](v T, n int) []T {
+	vs := make([]T, n)
+	for i := range vs {
+		vs[i] = v
+	}
+	return vs
+}
@@ -0,0 +1,228 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Text is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/text-object/
+type Text struct {
+	Type     string `json:"type"` // "plain_text" or "mrkdwn".
+	Text     string `json:"text"`
+	Emoji    bool   `json:"emoji,omitempty"`    // Only in "plain_text".
+	Verbatim bool   `json:"verbatim,omitempty"` // Only in "mrkdwn".
+}
+
+// PlainText returns a plain-text object, with emoji shortcodes (e.g. ":smile:") enabled.
+func PlainText(s string) *Text {
+	return &Text{Type: "plain_text", Text: s, Emoji: true}
+}
+
+// Mrkdwn returns a text object with Slack mrkdwn formatting (see also the markup package).
+func Mrkdwn(s string) *Text {
+	return &Text{Type: "mrkdwn", Text: s}
+}
+
+func (t *Text) contextElement() {}
+
+func (t *Text) validate(v *internal.Validator, path string) {
+	text(v, path, t, 1, 3000, false)
+}
+
+// text checks an optional text object, with minimum and maximum lengths.
+// Some fields accept only plain text, and don't support mrkdwn formatting.
+func text(v *internal.Validator, path string, t *Text, minChars, maxChars int, plainOnly bool) {
+	if t == nil {
+		return
+	}
+
+	if plainOnly {
+		v.Check(t.Type == "plain_text", fmt.Sprintf(`%q must be a "plain_text" object`, path))
+	} else {
+		v.Enum(path+".type", t.Type, "plain_text", "mrkdwn")
+		v.Require(path+".type", t.Type)
+	}
+	if minChars > 0 {
+		v.Require(path+".text", t.Text)
+	}
+	maxLen(v, path+".text", t.Text, maxChars)
+}
+
+// Style is the visual style of [Button], [WorkflowButton] and [Confirm] objects.
+type Style string
+
+// Button and confirmation dialog styles.
+const (
+	Default Style = ""
+	Primary Style = "primary"
+	Danger  Style = "danger"
+)
+
+func style(v *internal.Validator, path string, s Style) {
+	v.Enum(path, string(s), string(Primary), string(Danger))
+}
+
+// Confirm is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/confirmation-dialog-object/
+type Confirm struct {
+	Title   *Text `json:"title"`
+	Text    *Text `json:"text"`
+	Confirm *Text `json:"confirm"`
+	Deny    *Text `json:"deny"`
+	Style   Style `json:"style,omitempty"`
+}
+
+// NewConfirm returns a confirmation dialog with the given plain texts.
+func NewConfirm(title, text, confirm, deny string) *Confirm {
+	return &Confirm{Title: PlainText(title), Text: PlainText(text), Confirm: PlainText(confirm), Deny: PlainText(deny)}
+}
+
+func (c *Confirm) validate(v *internal.Validator, path string) {
+	v.Require(path+".title", c.Title).Require(path+".text", c.Text)
+	v.Require(path+".confirm", c.Confirm).Require(path+".deny", c.Deny)
+	text(v, path+".title", c.Title, 1, 100, true)
+	text(v, path+".text", c.Text, 1, 300, true)
+	text(v, path+".confirm", c.Confirm, 1, 30, true)
+	text(v, path+".deny", c.Deny, 1, 30, true)
+	style(v, path+".style", c.Style)
+}
+
+func confirm(v *internal.Validator, path string, c *Confirm) {
+	if c != nil {
+		c.validate(v, path)
+	}
+}
+
+// Option is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/option-object/
+type Option struct {
+	Text        *Text  `json:"text"`
+	Value       string `json:"value"`
+	Description *Text  `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"` // Only in [Overflow] menus.
+}
+
+// NewOption returns an option with the given plain text and value.
+func NewOption(text, value string) *Option {
+	return &Option{Text: PlainText(text), Value: value}
+}
+
+// validate checks an option. Options in [Checkboxes] and [RadioButtons] may contain mrkdwn text.
+func (o *Option) validate(v *internal.Validator, path string, mrkdwn bool) {
+	v.Require(path+".text", o.Text).Require(path+".value", o.Value)
+	text(v, path+".text", o.Text, 1, 75, !mrkdwn)
+	maxLen(v, path+".value", o.Value, 150)
+	text(v, path+".description", o.Description, 1, 75, !mrkdwn)
+	maxLen(v, path+".url", o.URL, 3000)
+}
+
+// options checks a list of options, with a maximum number of items.
+func options(v *internal.Validator, path string, opts []*Option, maxOpts int, mrkdwn bool) {
+	maxItems(v, path, len(opts), maxOpts)
+	for i, o := range opts {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if o == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		o.validate(v, p, mrkdwn)
+	}
+}
+
+// OptionGroup is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/option-group-object/
+type OptionGroup struct {
+	Label   *Text     `json:"label"`
+	Options []*Option `json:"options"`
+}
+
+// NewOptionGroup returns an option group with the given plain-text label.
+func NewOptionGroup(label string, opts ...*Option) *OptionGroup {
+	return &OptionGroup{Label: PlainText(label), Options: opts}
+}
+
+func (g *OptionGroup) validate(v *internal.Validator, path string) {
+	v.Require(path+".label", g.Label).Require(path+".options", g.Options)
+	text(v, path+".label", g.Label, 1, 75, true)
+	options(v, path+".options", g.Options, 100, false)
+}
+
+// DispatchActionConfig is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/dispatch-action-configuration-object/
+type DispatchActionConfig struct {
+	// "on_enter_pressed" and/or "on_character_entered".
+	TriggerActionsOn []string `json:"trigger_actions_on,omitempty"`
+}
+
+func dispatchActionConfig(v *internal.Validator, path string, c *DispatchActionConfig) {
+	if c == nil {
+		return
+	}
+	for i, t := range c.TriggerActionsOn {
+		v.Enum(fmt.Sprintf("%s.trigger_actions_on[%d]", path, i), t, "on_enter_pressed", "on_character_entered")
+	}
+}
+
+// ConversationFilter is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/conversation-filter-object/
+type ConversationFilter struct {
+	// "im", "mpim", "private" and/or "public".
+	Include []string `json:"include,omitempty"`
+
+	ExcludeExternalSharedChannels bool `json:"exclude_external_shared_channels,omitempty"`
+	ExcludeBotUsers               bool `json:"exclude_bot_users,omitempty"`
+}
+
+func (f *ConversationFilter) validate(v *internal.Validator, path string) {
+	v.RequireAny(fmt.Sprintf(`%q, %q or %q`, path+".include", path+".exclude_external_shared_channels",
+		path+".exclude_bot_users"), f.Include, f.ExcludeExternalSharedChannels, f.ExcludeBotUsers)
+	for i, t := range f.Include {
+		v.Enum(fmt.Sprintf("%s.include[%d]", path, i), t, "im", "mpim", "private", "public")
+	}
+}
+
+// SlackFile is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/slack-file-object/
+type SlackFile struct {
+	URL string `json:"url,omitempty"`
+	ID  string `json:"id,omitempty"`
+}
+
+func (f *SlackFile) validate(v *internal.Validator, path string) {
+	v.RequireAny(fmt.Sprintf(`%q or %q`, path+".url", path+".id"), f.URL, f.ID)
+	v.Exclusive(fmt.Sprintf(`%q and %q`, path+".url", path+".id"), f.URL, f.ID)
+}
+
+// Workflow is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/workflow-object/
+type Workflow struct {
+	Trigger *Trigger `json:"trigger"`
+}
+
+// Trigger is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/trigger-object/
+type Trigger struct {
+	URL                         string            `json:"url"`
+	CustomizableInputParameters []*InputParameter `json:"customizable_input_parameters,omitempty"`
+}
+
+// InputParameter is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/input-parameter-object/
+type InputParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (w *Workflow) validate(v *internal.Validator, path string) {
+	v.Require(path+".trigger", w.Trigger)
+	if w.Trigger == nil {
+		return
+	}
+	v.Require(path+".trigger.url", w.Trigger.URL)
+	for i, p := range w.Trigger.CustomizableInputParameters {
+		field := fmt.Sprintf("%s.trigger.customizable_input_parameters[%d].name", path, i)
+		v.Check(p != nil && p.Name != "", fmt.Sprintf("missing required field %q", field))
+	}
+}
@@ -0,0 +1,715 @@
+package blocks
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Element is a Block Kit block element, e.g. [Button] or [Select]. Each block
+// type accepts only some element types, which is checked by [Validate].
+type Element interface {
+	element()
+	typ() string
+	validate(v *internal.Validator, path string)
+}
+
+var (
+	selectTypes = []string{
+		"static_select", "external_select", "users_select", "conversations_select", "channels_select",
+		"multi_static_select", "multi_external_select", "multi_users_select", "multi_conversations_select", "multi_channels_select",
+	}
+
+	sectionAccessories = slices.Concat(selectTypes, []string{
+		"button", "checkboxes", "datepicker", "image", "overflow", "radio_buttons", "timepicker", "workflow_button",
+	})
+	actionsElements = slices.Concat(selectTypes, []string{
+		"button", "checkboxes", "datepicker", "datetimepicker", "overflow", "radio_buttons", "rich_text_input", "timepicker", "workflow_button",
+	})
+	inputElements = slices.Concat(selectTypes, []string{
+		"checkboxes", "datepicker", "datetimepicker", "email_text_input", "file_input", "number_input",
+		"plain_text_input", "radio_buttons", "rich_text_input", "timepicker", "url_text_input",
+	})
+
+	datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	timePattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+)
+
+// element checks an optional element, including whether its type is allowed in its parent block.
+func element(v *internal.Validator, path string, e Element, allowed []string) {
+	if e == nil {
+		return
+	}
+	v.Check(slices.Contains(allowed, e.typ()), fmt.Sprintf("%q can't be a %q element", path, e.typ()))
+	e.validate(v, path)
+}
+
+// actionID checks the optional ID of an interactive element, which is used to identify
+// it in interaction payloads, and must be unique among all the elements in a block.
+func actionID(v *internal.Validator, path, id string) {
+	maxLen(v, path+".action_id", id, 255)
+}
+
+// placeholder checks the optional placeholder text of an input element.
+func placeholder(v *internal.Validator, path string, t *Text) {
+	text(v, path+".placeholder", t, 1, 150, true)
+}
+
+// Button is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/button-element/
+type Button struct {
+	Text               *Text    `json:"text"`
+	ActionID           string   `json:"action_id,omitempty"`
+	URL                string   `json:"url,omitempty"`
+	Value              string   `json:"value,omitempty"`
+	Style              Style    `json:"style,omitempty"`
+	Confirm            *Confirm `json:"confirm,omitempty"`
+	AccessibilityLabel string   `json:"accessibility_label,omitempty"`
+}
+
+// NewButton returns a button with the given action ID, plain-text label, and value.
+func NewButton(actionID, text, value string) *Button {
+	return &Button{ActionID: actionID, Text: PlainText(text), Value: value}
+}
+
+// WithStyle sets the button's style, e.g. [Primary] for affirmative actions.
+func (e *Button) WithStyle(s Style) *Button {
+	e.Style = s
+	return e
+}
+
+// WithConfirm adds a confirmation dialog, which is shown after clicking the button.
+func (e *Button) WithConfirm(c *Confirm) *Button {
+	e.Confirm = c
+	return e
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *Button) MarshalJSON() ([]byte, error) {
+	type button Button
+	return marshal(e.typ(), (*button)(e))
+}
+
+func (e *Button) element() {}
+
+func (e *Button) typ() string {
+	return "button"
+}
+
+func (e *Button) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Require(path+".text", e.Text)
+	text(v, path+".text", e.Text, 1, 75, true)
+	maxLen(v, path+".url", e.URL, 3000)
+	maxLen(v, path+".value", e.Value, 2000)
+	style(v, path+".style", e.Style)
+	confirm(v, path+".confirm", e.Confirm)
+	maxLen(v, path+".accessibility_label", e.AccessibilityLabel, 75)
+}
+
+// Checkboxes is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/checkboxes-element/
+type Checkboxes struct {
+	ActionID       string    `json:"action_id,omitempty"`
+	Options        []*Option `json:"options"`
+	InitialOptions []*Option `json:"initial_options,omitempty"`
+	Confirm        *Confirm  `json:"confirm,omitempty"`
+	FocusOnLoad    bool      `json:"focus_on_load,omitempty"`
+}
+
+// NewCheckboxes returns a checkbox group with the given action ID and options.
+func NewCheckboxes(actionID string, opts ...*Option) *Checkboxes {
+	return &Checkboxes{ActionID: actionID, Options: opts}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *Checkboxes) MarshalJSON() ([]byte, error) {
+	type checkboxes Checkboxes
+	return marshal(e.typ(), (*checkboxes)(e))
+}
+
+func (e *Checkboxes) element() {}
+
+func (e *Checkboxes) typ() string {
+	return "checkboxes"
+}
+
+func (e *Checkboxes) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Require(path+".options", e.Options)
+	options(v, path+".options", e.Options, 10, true)
+	options(v, path+".initial_options", e.InitialOptions, 10, true)
+	confirm(v, path+".confirm", e.Confirm)
+}
+
+// DatePicker is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/date-picker-element/
+type DatePicker struct {
+	ActionID    string   `json:"action_id,omitempty"`
+	InitialDate string   `json:"initial_date,omitempty"` // "YYYY-MM-DD".
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	FocusOnLoad bool     `json:"focus_on_load,omitempty"`
+	Placeholder *Text    `json:"placeholder,omitempty"`
+}
+
+// NewDatePicker returns a date picker with the given action ID.
+func NewDatePicker(actionID string) *DatePicker {
+	return &DatePicker{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *DatePicker) MarshalJSON() ([]byte, error) {
+	type datePicker DatePicker
+	return marshal(e.typ(), (*datePicker)(e))
+}
+
+func (e *DatePicker) element() {}
+
+func (e *DatePicker) typ() string {
+	return "datepicker"
+}
+
+func (e *DatePicker) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(e.InitialDate == "" || datePattern.MatchString(e.InitialDate),
+		fmt.Sprintf(`%q must be in the format "YYYY-MM-DD"`, path+".initial_date"))
+	confirm(v, path+".confirm", e.Confirm)
+	placeholder(v, path, e.Placeholder)
+}
+
+// DateTimePicker is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/datetime-picker-element/
+type DateTimePicker struct {
+	ActionID        string   `json:"action_id,omitempty"`
+	InitialDateTime int64    `json:"initial_date_time,omitempty"` // Unix timestamp in seconds.
+	Confirm         *Confirm `json:"confirm,omitempty"`
+	FocusOnLoad     bool     `json:"focus_on_load,omitempty"`
+}
+
+// NewDateTimePicker returns a date and time picker with the given action ID.
+func NewDateTimePicker(actionID string) *DateTimePicker {
+	return &DateTimePicker{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *DateTimePicker) MarshalJSON() ([]byte, error) {
+	type dateTimePicker DateTimePicker
+	return marshal(e.typ(), (*dateTimePicker)(e))
+}
+
+func (e *DateTimePicker) element() {}
+
+func (e *DateTimePicker) typ() string {
+	return "datetimepicker"
+}
+
+func (e *DateTimePicker) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(e.InitialDateTime >= 0, fmt.Sprintf("%q must not be negative", path+".initial_date_time"))
+	confirm(v, path+".confirm", e.Confirm)
+}
+
+// EmailInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/email-input-element/
+type EmailInput struct {
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+}
+
+// NewEmailInput returns an email address input with the given action ID.
+func NewEmailInput(actionID string) *EmailInput {
+	return &EmailInput{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *EmailInput) MarshalJSON() ([]byte, error) {
+	type emailInput EmailInput
+	return marshal(e.typ(), (*emailInput)(e))
+}
+
+func (e *EmailInput) element() {}
+
+func (e *EmailInput) typ() string {
+	return "email_text_input"
+}
+
+func (e *EmailInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	dispatchActionConfig(v, path+".dispatch_action_config", e.DispatchActionConfig)
+	placeholder(v, path, e.Placeholder)
+}
+
+// FileInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/file-input-element/
+type FileInput struct {
+	ActionID  string   `json:"action_id,omitempty"`
+	FileTypes []string `json:"filetypes,omitempty"`
+	MaxFiles  int      `json:"max_files,omitempty"` // Between 1 and 10 (default).
+}
+
+// NewFileInput returns a file input with the given action ID, and optional file types (e.g. "pdf").
+func NewFileInput(actionID string, fileTypes ...string) *FileInput {
+	return &FileInput{ActionID: actionID, FileTypes: fileTypes}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *FileInput) MarshalJSON() ([]byte, error) {
+	type fileInput FileInput
+	return marshal(e.typ(), (*fileInput)(e))
+}
+
+func (e *FileInput) element() {}
+
+func (e *FileInput) typ() string {
+	return "file_input"
+}
+
+func (e *FileInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(e.MaxFiles >= 0 && e.MaxFiles <= 10, fmt.Sprintf("%q must be between 1 and 10", path+".max_files"))
+}
+
+// ImageElement is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/image-element/
+//
+// It may be used as a [Section] block's accessory, or in a [Context] block.
+// Images as standalone blocks are represented by [Image] instead.
+type ImageElement struct {
+	AltText   string     `json:"alt_text"`
+	ImageURL  string     `json:"image_url,omitempty"`
+	SlackFile *SlackFile `json:"slack_file,omitempty"`
+}
+
+// NewImageElement returns an image element with the given public image URL.
+func NewImageElement(url, altText string) *ImageElement {
+	return &ImageElement{ImageURL: url, AltText: altText}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *ImageElement) MarshalJSON() ([]byte, error) {
+	type image ImageElement
+	return marshal(e.typ(), (*image)(e))
+}
+
+func (e *ImageElement) element() {}
+
+func (e *ImageElement) contextElement() {}
+
+func (e *ImageElement) typ() string {
+	return "image"
+}
+
+func (e *ImageElement) validate(v *internal.Validator, path string) {
+	v.Require(path+".alt_text", e.AltText)
+	maxLen(v, path+".alt_text", e.AltText, 2000)
+	imageSource(v, path, e.ImageURL, e.SlackFile)
+}
+
+// NumberInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/number-input-element/
+type NumberInput struct {
+	IsDecimalAllowed     bool                  `json:"is_decimal_allowed"`
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	MinValue             string                `json:"min_value,omitempty"`
+	MaxValue             string                `json:"max_value,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+}
+
+// NewNumberInput returns a number input with the given action ID.
+func NewNumberInput(actionID string, decimal bool) *NumberInput {
+	return &NumberInput{ActionID: actionID, IsDecimalAllowed: decimal}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *NumberInput) MarshalJSON() ([]byte, error) {
+	type numberInput NumberInput
+	return marshal(e.typ(), (*numberInput)(e))
+}
+
+func (e *NumberInput) element() {}
+
+func (e *NumberInput) typ() string {
+	return "number_input"
+}
+
+func (e *NumberInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	dispatchActionConfig(v, path+".dispatch_action_config", e.DispatchActionConfig)
+	placeholder(v, path, e.Placeholder)
+}
+
+// Overflow is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/overflow-menu-element/
+type Overflow struct {
+	ActionID string    `json:"action_id,omitempty"`
+	Options  []*Option `json:"options"`
+	Confirm  *Confirm  `json:"confirm,omitempty"`
+}
+
+// NewOverflow returns an overflow menu with the given action ID and options.
+func NewOverflow(actionID string, opts ...*Option) *Overflow {
+	return &Overflow{ActionID: actionID, Options: opts}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *Overflow) MarshalJSON() ([]byte, error) {
+	type overflow Overflow
+	return marshal(e.typ(), (*overflow)(e))
+}
+
+func (e *Overflow) element() {}
+
+func (e *Overflow) typ() string {
+	return "overflow"
+}
+
+func (e *Overflow) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(len(e.Options) >= 2, fmt.Sprintf("%q must have at least 2 items", path+".options"))
+	options(v, path+".options", e.Options, 5, false)
+	confirm(v, path+".confirm", e.Confirm)
+}
+
+// PlainTextInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/plain-text-input-element/
+type PlainTextInput struct {
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	Multiline            bool                  `json:"multiline,omitempty"`
+	MinLength            int                   `json:"min_length,omitempty"`
+	MaxLength            int                   `json:"max_length,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+}
+
+// NewPlainTextInput returns a plain-text input with the given action ID.
+func NewPlainTextInput(actionID string, multiline bool) *PlainTextInput {
+	return &PlainTextInput{ActionID: actionID, Multiline: multiline}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *PlainTextInput) MarshalJSON() ([]byte, error) {
+	type plainTextInput PlainTextInput
+	return marshal(e.typ(), (*plainTextInput)(e))
+}
+
+func (e *PlainTextInput) element() {}
+
+func (e *PlainTextInput) typ() string {
+	return "plain_text_input"
+}
+
+func (e *PlainTextInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(e.MinLength >= 0 && e.MinLength <= 3000, fmt.Sprintf("%q must be between 0 and 3000", path+".min_length"))
+	v.Check(e.MaxLength >= 0, fmt.Sprintf("%q must not be negative", path+".max_length"))
+	v.Check(e.MaxLength == 0 || e.MaxLength >= e.MinLength,
+		fmt.Sprintf("%q must not be less than %q", path+".max_length", path+".min_length"))
+	dispatchActionConfig(v, path+".dispatch_action_config", e.DispatchActionConfig)
+	placeholder(v, path, e.Placeholder)
+}
+
+// RadioButtons is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/radio-button-group-element/
+type RadioButtons struct {
+	ActionID      string    `json:"action_id,omitempty"`
+	Options       []*Option `json:"options"`
+	InitialOption *Option   `json:"initial_option,omitempty"`
+	Confirm       *Confirm  `json:"confirm,omitempty"`
+	FocusOnLoad   bool      `json:"focus_on_load,omitempty"`
+}
+
+// NewRadioButtons returns a radio button group with the given action ID and options.
+func NewRadioButtons(actionID string, opts ...*Option) *RadioButtons {
+	return &RadioButtons{ActionID: actionID, Options: opts}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *RadioButtons) MarshalJSON() ([]byte, error) {
+	type radioButtons RadioButtons
+	return marshal(e.typ(), (*radioButtons)(e))
+}
+
+func (e *RadioButtons) element() {}
+
+func (e *RadioButtons) typ() string {
+	return "radio_buttons"
+}
+
+func (e *RadioButtons) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Require(path+".options", e.Options)
+	options(v, path+".options", e.Options, 10, true)
+	if e.InitialOption != nil {
+		e.InitialOption.validate(v, path+".initial_option", true)
+	}
+	confirm(v, path+".confirm", e.Confirm)
+}
+
+// RichTextInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/rich-text-input-element/
+type RichTextInput struct {
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         *RichText             `json:"initial_value,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+}
+
+// NewRichTextInput returns a rich-text input with the given action ID.
+func NewRichTextInput(actionID string) *RichTextInput {
+	return &RichTextInput{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *RichTextInput) MarshalJSON() ([]byte, error) {
+	type richTextInput RichTextInput
+	return marshal(e.typ(), (*richTextInput)(e))
+}
+
+func (e *RichTextInput) element() {}
+
+func (e *RichTextInput) typ() string {
+	return "rich_text_input"
+}
+
+func (e *RichTextInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	if e.InitialValue != nil {
+		e.InitialValue.validate(v, path+".initial_value")
+	}
+	dispatchActionConfig(v, path+".dispatch_action_config", e.DispatchActionConfig)
+	placeholder(v, path, e.Placeholder)
+}
+
+// Menu is the data source of a [Select] menu.
+type Menu string
+
+// Data sources of select menus.
+const (
+	StaticMenu        Menu = "static"
+	ExternalMenu      Menu = "external"
+	UsersMenu         Menu = "users"
+	ConversationsMenu Menu = "conversations"
+	ChannelsMenu      Menu = "channels"
+)
+
+// Select is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/select-menu-element/
+// https://docs.slack.dev/reference/block-kit/block-elements/multi-select-menu-element/
+//
+// It represents all the types of select and multi-select menus, depending on
+// its data source and the [Select.Multi] flag. Only some of the fields are
+// relevant to each type, e.g. options are specified only in static menus.
+type Select struct {
+	Menu  Menu `json:"-"`
+	Multi bool `json:"-"`
+
+	ActionID    string `json:"action_id,omitempty"`
+	Placeholder *Text  `json:"placeholder,omitempty"`
+
+	Options        []*Option      `json:"options,omitempty"`
+	OptionGroups   []*OptionGroup `json:"option_groups,omitempty"`
+	InitialOption  *Option        `json:"initial_option,omitempty"`
+	InitialOptions []*Option      `json:"initial_options,omitempty"`
+	MinQueryLength int            `json:"min_query_length,omitempty"` // Only in external menus.
+
+	InitialUser          string              `json:"initial_user,omitempty"`
+	InitialUsers         []string            `json:"initial_users,omitempty"`
+	InitialConversation  string              `json:"initial_conversation,omitempty"`
+	InitialConversations []string            `json:"initial_conversations,omitempty"`
+	InitialChannel       string              `json:"initial_channel,omitempty"`
+	InitialChannels      []string            `json:"initial_channels,omitempty"`
+	DefaultToCurrent     bool                `json:"default_to_current_conversation,omitempty"`
+	Filter               *ConversationFilter `json:"filter,omitempty"`
+	ResponseURLEnabled   bool                `json:"response_url_enabled,omitempty"` // Only in modals.
+
+	MaxSelectedItems int      `json:"max_selected_items,omitempty"`
+	Confirm          *Confirm `json:"confirm,omitempty"`
+	FocusOnLoad      bool     `json:"focus_on_load,omitempty"`
+}
+
+// NewSelect returns a single-select menu with the given data source, action
+// ID and plain-text placeholder. Options may be specified for static menus.
+func NewSelect(m Menu, actionID, placeholder string, opts ...*Option) *Select {
+	return &Select{Menu: m, ActionID: actionID, Placeholder: PlainText(placeholder), Options: opts}
+}
+
+// NewMultiSelect is similar to [NewSelect], but returns a multi-select menu.
+func NewMultiSelect(m Menu, actionID, placeholder string, opts ...*Option) *Select {
+	s := NewSelect(m, actionID, placeholder, opts...)
+	s.Multi = true
+	return s
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *Select) MarshalJSON() ([]byte, error) {
+	type menu Select
+	return marshal(e.typ(), (*menu)(e))
+}
+
+func (e *Select) element() {}
+
+func (e *Select) typ() string {
+	t := string(e.Menu) + "_select"
+	if e.Multi {
+		t = "multi_" + t
+	}
+	return t
+}
+
+func (e *Select) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	placeholder(v, path, e.Placeholder)
+
+	static := e.Menu == StaticMenu
+	if static {
+		v.RequireAny(fmt.Sprintf("%q or %q", path+".options", path+".option_groups"), e.Options, e.OptionGroups)
+	}
+	v.Exclusive(fmt.Sprintf("%q and %q", path+".options", path+".option_groups"), e.Options, e.OptionGroups)
+	v.Check(static || (len(e.Options) == 0 && len(e.OptionGroups) == 0),
+		fmt.Sprintf(`%q may have options only in static menus`, path))
+	options(v, path+".options", e.Options, 100, false)
+	maxItems(v, path+".option_groups", len(e.OptionGroups), 100)
+	for i, g := range e.OptionGroups {
+		p := fmt.Sprintf("%s.option_groups[%d]", path, i)
+		if g == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		g.validate(v, p)
+	}
+
+	if e.InitialOption != nil {
+		e.InitialOption.validate(v, path+".initial_option", false)
+	}
+	options(v, path+".initial_options", e.InitialOptions, 100, false)
+	v.Check(!e.Multi || (e.InitialOption == nil && e.InitialUser == "" && e.InitialConversation == "" && e.InitialChannel == ""),
+		fmt.Sprintf(`%q must use plural "initial_*" fields`, path))
+	v.Check(e.Multi || (e.InitialOptions == nil && e.InitialUsers == nil && e.InitialConversations == nil && e.InitialChannels == nil),
+		fmt.Sprintf(`%q must use singular "initial_*" fields`, path))
+	v.Check(e.MaxSelectedItems >= 0 && (e.Multi || e.MaxSelectedItems == 0),
+		fmt.Sprintf("%q must be positive, and only in multi-select menus", path+".max_selected_items"))
+
+	if e.Filter != nil {
+		v.Check(e.Menu == ConversationsMenu, fmt.Sprintf(`%q is supported only in conversations menus`, path+".filter"))
+		e.Filter.validate(v, path+".filter")
+	}
+	confirm(v, path+".confirm", e.Confirm)
+}
+
+// TimePicker is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/time-picker-element/
+type TimePicker struct {
+	ActionID    string   `json:"action_id,omitempty"`
+	InitialTime string   `json:"initial_time,omitempty"` // "HH:mm" (24-hour format).
+	Confirm     *Confirm `json:"confirm,omitempty"`
+	FocusOnLoad bool     `json:"focus_on_load,omitempty"`
+	Placeholder *Text    `json:"placeholder,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"` // IANA name, e.g. "America/Los_Angeles".
+}
+
+// NewTimePicker returns a time picker with the given action ID.
+func NewTimePicker(actionID string) *TimePicker {
+	return &TimePicker{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *TimePicker) MarshalJSON() ([]byte, error) {
+	type timePicker TimePicker
+	return marshal(e.typ(), (*timePicker)(e))
+}
+
+func (e *TimePicker) element() {}
+
+func (e *TimePicker) typ() string {
+	return "timepicker"
+}
+
+func (e *TimePicker) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	v.Check(e.InitialTime == "" || timePattern.MatchString(e.InitialTime),
+		fmt.Sprintf(`%q must be in the format "HH:mm"`, path+".initial_time"))
+	confirm(v, path+".confirm", e.Confirm)
+	placeholder(v, path, e.Placeholder)
+}
+
+// URLInput is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/url-input-element/
+type URLInput struct {
+	ActionID             string                `json:"action_id,omitempty"`
+	InitialValue         string                `json:"initial_value,omitempty"`
+	DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+	FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	Placeholder          *Text                 `json:"placeholder,omitempty"`
+}
+
+// NewURLInput returns a URL input with the given action ID.
+func NewURLInput(actionID string) *URLInput {
+	return &URLInput{ActionID: actionID}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *URLInput) MarshalJSON() ([]byte, error) {
+	type urlInput URLInput
+	return marshal(e.typ(), (*urlInput)(e))
+}
+
+func (e *URLInput) element() {}
+
+func (e *URLInput) typ() string {
+	return "url_text_input"
+}
+
+func (e *URLInput) validate(v *internal.Validator, path string) {
+	actionID(v, path, e.ActionID)
+	dispatchActionConfig(v, path+".dispatch_action_config", e.DispatchActionConfig)
+	placeholder(v, path, e.Placeholder)
+}
+
+// WorkflowButton is based on:
+// https://docs.slack.dev/reference/block-kit/block-elements/workflow-button-element/
+type WorkflowButton struct {
+	Text               *Text     `json:"text"`
+	Workflow           *Workflow `json:"workflow"`
+	ActionID           string    `json:"action_id,omitempty"`
+	Style              Style     `json:"style,omitempty"`
+	AccessibilityLabel string    `json:"accessibility_label,omitempty"`
+}
+
+// NewWorkflowButton returns a button which starts a Slack workflow with the given link trigger URL.
+func NewWorkflowButton(text, triggerURL string) *WorkflowButton {
+	return &WorkflowButton{Text: PlainText(text), Workflow: &Workflow{Trigger: &Trigger{URL: triggerURL}}}
+}
+
+// MarshalJSON encodes the element as a JSON object, including its type.
+func (e *WorkflowButton) MarshalJSON() ([]byte, error) {
+	type workflowButton WorkflowButton
+	return marshal(e.typ(), (*workflowButton)(e))
+}
+
+func (e *WorkflowButton) element() {}
+
+func (e *WorkflowButton) typ() string {
+	return "workflow_button"
+}
+
+func (e *WorkflowButton) validate(v *internal.Validator, path string) {
+	v.Require(path+".text", e.Text).Require(path+".workflow", e.Workflow)
+	text(v, path+".text", e.Text, 1, 75, true)
+	if e.Workflow != nil {
+		e.Workflow.validate(v, path+".workflow")
+	}
+	actionID(v, path, e.ActionID)
+	style(v, path+".style", e.Style)
+	maxLen(v, path+".accessibility_label", e.AccessibilityLabel, 75)
+}
@@ -0,0 +1,352 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// RichText is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/
+type RichText struct {
+	Elements []RichTextObject `json:"elements"`
+	BlockID  string           `json:"block_id,omitempty"`
+}
+
+// RichTextObject is a top-level element of a [RichText] block:
+// a [RichTextSection], [RichTextList], [RichTextPreformatted] or [RichTextQuote].
+type RichTextObject interface {
+	richTextObject()
+	validate(v *internal.Validator, path string)
+}
+
+// NewRichText returns a rich-text block with the given sections, lists, code blocks and quotes.
+func NewRichText(objects ...RichTextObject) *RichText {
+	return &RichText{Elements: objects}
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *RichText) MarshalJSON() ([]byte, error) {
+	type richText RichText
+	return marshal("rich_text", (*richText)(b))
+}
+
+func (b *RichText) block() {}
+
+func (b *RichText) tableCell() {}
+
+func (b *RichText) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".elements", b.Elements)
+	for i, o := range b.Elements {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		if o == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		o.validate(v, p)
+	}
+}
+
+// RichTextSection is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#rich_text_section
+type RichTextSection struct {
+	Elements []*RichTextElement `json:"elements"`
+}
+
+// NewRichTextSection returns a rich-text section (i.e. a paragraph) with the given inline elements.
+func NewRichTextSection(elements ...*RichTextElement) *RichTextSection {
+	return &RichTextSection{Elements: elements}
+}
+
+// MarshalJSON encodes the object as a JSON object, including its type.
+func (o *RichTextSection) MarshalJSON() ([]byte, error) {
+	type section RichTextSection
+	return marshal("rich_text_section", (*section)(o))
+}
+
+func (o *RichTextSection) richTextObject() {}
+
+func (o *RichTextSection) validate(v *internal.Validator, path string) {
+	richTextElements(v, path, o.Elements)
+}
+
+// RichTextList is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#rich_text_list
+type RichTextList struct {
+	Style    string             `json:"style"` // "bullet" or "ordered".
+	Elements []*RichTextSection `json:"elements"`
+	Indent   int                `json:"indent,omitempty"`
+	Offset   int                `json:"offset,omitempty"`
+	Border   int                `json:"border,omitempty"`
+}
+
+// NewRichTextList returns a bulleted or numbered list, with one section per list item.
+func NewRichTextList(ordered bool, items ...*RichTextSection) *RichTextList {
+	style := "bullet"
+	if ordered {
+		style = "ordered"
+	}
+	return &RichTextList{Style: style, Elements: items}
+}
+
+// MarshalJSON encodes the object as a JSON object, including its type.
+func (o *RichTextList) MarshalJSON() ([]byte, error) {
+	type list RichTextList
+	return marshal("rich_text_list", (*list)(o))
+}
+
+func (o *RichTextList) richTextObject() {}
+
+func (o *RichTextList) validate(v *internal.Validator, path string) {
+	v.Require(path+".style", o.Style)
+	v.Enum(path+".style", o.Style, "bullet", "ordered")
+	v.Require(path+".elements", o.Elements)
+	for i, s := range o.Elements {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		if s == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		s.validate(v, p)
+	}
+	v.Check(o.Indent >= 0 && o.Indent <= 8, fmt.Sprintf("%q must be between 0 and 8", path+".indent"))
+}
+
+// RichTextPreformatted is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#rich_text_preformatted
+type RichTextPreformatted struct {
+	Elements []*RichTextElement `json:"elements"`
+	Border   int                `json:"border,omitempty"`
+	Language string             `json:"language,omitempty"`
+}
+
+// NewRichTextPreformatted returns a code block with the given text.
+func NewRichTextPreformatted(code string) *RichTextPreformatted {
+	return &RichTextPreformatted{Elements: []*RichTextElement{RichTextString(code, nil)}}
+}
+
+// MarshalJSON encodes the object as a JSON object, including its type.
+func (o *RichTextPreformatted) MarshalJSON() ([]byte, error) {
+	type preformatted RichTextPreformatted
+	return marshal("rich_text_preformatted", (*preformatted)(o))
+}
+
+func (o *RichTextPreformatted) richTextObject() {}
+
+func (o *RichTextPreformatted) validate(v *internal.Validator, path string) {
+	richTextElements(v, path, o.Elements)
+}
+
+// RichTextQuote is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#rich_text_quote
+type RichTextQuote struct {
+	Elements []*RichTextElement `json:"elements"`
+	Border   int                `json:"border,omitempty"`
+}
+
+// NewRichTextQuote returns a quote with the given inline elements.
+func NewRichTextQuote(elements ...*RichTextElement) *RichTextQuote {
+	return &RichTextQuote{Elements: elements}
+}
+
+// MarshalJSON encodes the object as a JSON object, including its type.
+func (o *RichTextQuote) MarshalJSON() ([]byte, error) {
+	type quote RichTextQuote
+	return marshal("rich_text_quote", (*quote)(o))
+}
+
+func (o *RichTextQuote) richTextObject() {}
+
+func (o *RichTextQuote) validate(v *internal.Validator, path string) {
+	richTextElements(v, path, o.Elements)
+}
+
+// RichTextElement is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#element-types
+//
+// It represents all the types of inline rich-text elements. Only
+// some of the fields are relevant to each type, e.g. "user" elements
+// have a user ID, and "text" and "link" elements have text.
+type RichTextElement struct {
+	// "broadcast", "channel", "color", "date", "emoji", "link", "text", "user" or "usergroup".
+	Type string `json:"type"`
+
+	Text        string `json:"text,omitempty"`
+	URL         string `json:"url,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	UsergroupID string `json:"usergroup_id,omitempty"`
+	Range       string `json:"range,omitempty"` // "here", "channel" or "everyone".
+	Value       string `json:"value,omitempty"` // Hex color code, e.g. "#F405B3".
+
+	Name    string `json:"name,omitempty"`    // Emoji name, e.g. "wave".
+	Unicode string `json:"unicode,omitempty"` // Emoji code point(s), e.g. "1f44b".
+
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Format    string `json:"format,omitempty"`   // E.g. "{date_short} at {time}".
+	Fallback  string `json:"fallback,omitempty"` // If the client can't render the date.
+
+	Style *RichTextStyle `json:"style,omitempty"`
+}
+
+// RichTextStyle is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/rich-text-block/#element-types
+type RichTextStyle struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+	Strike bool `json:"strike,omitempty"`
+	Code   bool `json:"code,omitempty"`
+
+	Highlight       bool `json:"highlight,omitempty"`        // Only in mentions.
+	ClientHighlight bool `json:"client_highlight,omitempty"` // Only in mentions.
+	Unlink          bool `json:"unlink,omitempty"`           // Only in mentions.
+}
+
+// RichTextString returns a rich-text element with the given text and optional style.
+func RichTextString(s string, style *RichTextStyle) *RichTextElement {
+	return &RichTextElement{Type: "text", Text: s, Style: style}
+}
+
+// RichTextLink returns a rich-text element with a link, and optional text instead of the URL.
+func RichTextLink(url, text string) *RichTextElement {
+	return &RichTextElement{Type: "link", URL: url, Text: text}
+}
+
+// RichTextUser returns a rich-text element with a user mention.
+func RichTextUser(userID string) *RichTextElement {
+	return &RichTextElement{Type: "user", UserID: userID}
+}
+
+// RichTextChannel returns a rich-text element with a channel mention.
+func RichTextChannel(channelID string) *RichTextElement {
+	return &RichTextElement{Type: "channel", ChannelID: channelID}
+}
+
+// RichTextEmoji returns a rich-text element with an emoji, e.g. "wave".
+func RichTextEmoji(name string) *RichTextElement {
+	return &RichTextElement{Type: "emoji", Name: name}
+}
+
+func richTextElements(v *internal.Validator, path string, es []*RichTextElement) {
+	for i, e := range es {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		if e == nil {
+			v.Check(false, fmt.Sprintf("%q is nil", p))
+			continue
+		}
+		e.validate(v, p)
+	}
+}
+
+func (e *RichTextElement) validate(v *internal.Validator, path string) {
+	v.Require(path+".type", e.Type)
+	v.Enum(path+".type", e.Type, "broadcast", "channel", "color", "date", "emoji", "link", "text", "user", "usergroup")
+
+	switch e.Type {
+	case "broadcast":
+		v.Require(path+".range", e.Range)
+		v.Enum(path+".range", e.Range, "here", "channel", "everyone")
+	case "channel":
+		v.Require(path+".channel_id", e.ChannelID)
+	case "color":
+		v.Require(path+".value", e.Value)
+	case "date":
+		v.Require(path+".timestamp", e.Timestamp).Require(path+".format", e.Format)
+	case "emoji":
+		v.Require(path+".name", e.Name)
+	case "link":
+		v.Require(path+".url", e.URL)
+	case "text":
+		v.Require(path+".text", e.Text)
+	case "user":
+		v.Require(path+".user_id", e.UserID)
+	case "usergroup":
+		v.Require(path+".usergroup_id", e.UsergroupID)
+	}
+}
+
+// Table is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/table-block/
+//
+// Tables are supported only in messages, and only one table is allowed per message.
+type Table struct {
+	Rows           [][]TableCell    `json:"rows"`
+	ColumnSettings []*ColumnSetting `json:"column_settings,omitempty"`
+	BlockID        string           `json:"block_id,omitempty"`
+}
+
+// TableCell is a cell in a [Table] block: a [RawText] or a [RichText] block.
+type TableCell interface {
+	tableCell()
+	validate(v *internal.Validator, path string)
+}
+
+// ColumnSetting is based on:
+// https://docs.slack.dev/reference/block-kit/blocks/table-block/
+type ColumnSetting struct {
+	Align     string `json:"align,omitempty"` // "left" (default), "center" or "right".
+	IsWrapped bool   `json:"is_wrapped,omitempty"`
+}
+
+// NewTable returns a table with the given rows of plain-text cells.
+func NewTable(rows ...[]string) *Table {
+	t := &Table{Rows: make([][]TableCell, 0, len(rows))}
+	for _, r := range rows {
+		cells := make([]TableCell, 0, len(r))
+		for _, s := range r {
+			cells = append(cells, &RawText{Text: s})
+		}
+		t.Rows = append(t.Rows, cells)
+	}
+	return t
+}
+
+// MarshalJSON encodes the block as a JSON object, including its type.
+func (b *Table) MarshalJSON() ([]byte, error) {
+	type table Table
+	return marshal("table", (*table)(b))
+}
+
+func (b *Table) block() {}
+
+func (b *Table) validate(v *internal.Validator, path string) {
+	blockID(v, path, b.BlockID)
+	v.Require(path+".rows", b.Rows)
+	maxItems(v, path+".rows", len(b.Rows), 100)
+	for i, r := range b.Rows {
+		p := fmt.Sprintf("%s.rows[%d]", path, i)
+		maxItems(v, p, len(r), 20)
+		for j, c := range r {
+			if c == nil {
+				v.Check(false, fmt.Sprintf("%q is nil", fmt.Sprintf("%s[%d]", p, j)))
+				continue
+			}
+			c.validate(v, fmt.Sprintf("%s[%d]", p, j))
+		}
+	}
+
+	maxItems(v, path+".column_settings", len(b.ColumnSettings), 20)
+	for i, s := range b.ColumnSettings {
+		if s != nil {
+			v.Enum(fmt.Sprintf("%s.column_settings[%d].align", path, i), s.Align, "left", "center", "right")
+		}
+	}
+}
+
+// RawText is a plain-text cell in a [Table] block.
+type RawText struct {
+	Text string `json:"text"`
+}
+
+// MarshalJSON encodes the cell as a JSON object, including its type.
+func (c *RawText) MarshalJSON() ([]byte, error) {
+	type rawText RawText
+	return marshal("raw_text", (*rawText)(c))
+}
+
+func (c *RawText) tableCell() {}
+
+func (c *RawText) validate(v *internal.Validator, path string) {
+	v.Require(path+".text", c.Text)
+}
@@ -17,6 +17,8 @@ const (
 // BookmarksAddRequest is based on:
 // https://docs.slack.dev/reference/methods/bookmarks.add/
 type BookmarksAddRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	ChannelID string `json:"channel_id"`
 	Title     string `json:"title"`
 	Type      string `json:"type"`
@@ -46,6 +48,8 @@ func BookmarksAdd(ctx workflow.Context, channelID, title, url, emoji string) err
 // BookmarksEditRequest is based on:
 // https://docs.slack.dev/reference/methods/bookmarks.edit/
 type BookmarksEditRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	ChannelID  string `json:"channel_id"`
 	BookmarkID string `json:"bookmark_id"`
 
@@ -72,6 +76,8 @@ func BookmarksEditTitle(ctx workflow.Context, channelID, bookmarkID, title strin
 // BookmarksListRequest is based on:
 // https://docs.slack.dev/reference/methods/bookmarks.list/
 type BookmarksListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	ChannelID string `json:"channel_id"`
 }
 
@@ -97,6 +103,8 @@ func BookmarksList(ctx workflow.Context, channelID string) ([]Bookmark, error) {
 // BookmarksRemoveRequest is based on:
 // https://docs.slack.dev/reference/methods/bookmarks.remove/
 type BookmarksRemoveRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	ChannelID  string `json:"channel_id"`
 	BookmarkID string `json:"bookmark_id"`
 
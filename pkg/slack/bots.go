@@ -14,6 +14,8 @@ const (
 // BotsInfoRequest is based on:
 // https://docs.slack.dev/reference/methods/bots.info/
 type BotsInfoRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Bot string `json:"bot"`
 
 	TeamID string `json:"team_id,omitempty"`
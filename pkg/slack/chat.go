@@ -1,25 +1,38 @@
 package slack
 
 import (
+	"iter"
+	"time"
+
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
 const (
-	ChatDeleteActivityName        = "slack.chat.delete"
-	ChatGetPermalinkActivityName  = "slack.chat.getPermalink"
-	ChatPostEphemeralActivityName = "slack.chat.postEphemeral"
-	ChatPostMessageActivityName   = "slack.chat.postMessage"
-	ChatUpdateActivityName        = "slack.chat.update"
+	ChatDeleteActivityName                 = "slack.chat.delete"
+	ChatDeleteScheduledMessageActivityName = "slack.chat.deleteScheduledMessage"
+	ChatGetPermalinkActivityName           = "slack.chat.getPermalink"
+	ChatPostEphemeralActivityName          = "slack.chat.postEphemeral"
+	ChatPostMessageActivityName            = "slack.chat.postMessage"
+	ChatScheduleMessageActivityName        = "slack.chat.scheduleMessage"
+	ChatScheduledMessagesListActivityName  = "slack.chat.scheduledMessages.list"
+	ChatUpdateActivityName                 = "slack.chat.update"
 
 	TimpaniPostApprovalWorkflowName = "slack.timpani.postApproval"
 ) //revive:enable:exported
 
+// maxScheduleAhead is how far in the future Slack allows scheduling messages.
+const maxScheduleAhead = 120 * 24 * time.Hour
+
 // ChatDeleteRequest is based on:
 // https://docs.slack.dev/reference/methods/chat.delete/
 type ChatDeleteRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	TS      string `json:"ts"`
 
@@ -42,9 +55,33 @@ func ChatDelete(ctx workflow.Context, channelID, timestamp string) error {
 	return internal.ExecuteTimpaniActivityNoResp(ctx, ChatDeleteActivityName, req)
 }
 
+// ChatDeleteScheduledMessageRequest is based on:
+// https://docs.slack.dev/reference/methods/chat.deleteScheduledMessage/
+type ChatDeleteScheduledMessageRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	Channel            string `json:"channel"`
+	ScheduledMessageID string `json:"scheduled_message_id"`
+
+	AsUser bool `json:"as_user,omitempty"`
+}
+
+// ChatDeleteScheduledMessageResponse is based on:
+// https://docs.slack.dev/reference/methods/chat.deleteScheduledMessage/
+type ChatDeleteScheduledMessageResponse Response
+
+// ChatDeleteScheduledMessage is based on:
+// https://docs.slack.dev/reference/methods/chat.deleteScheduledMessage/
+func ChatDeleteScheduledMessage(ctx workflow.Context, channelID, scheduledMessageID string) error {
+	req := ChatDeleteScheduledMessageRequest{Channel: channelID, ScheduledMessageID: scheduledMessageID}
+	return internal.ExecuteTimpaniActivityNoResp(ctx, ChatDeleteScheduledMessageActivityName, req)
+}
+
 // ChatGetPermalinkRequest is based on:
 // https://docs.slack.dev/reference/methods/chat.getPermalink/
 type ChatGetPermalinkRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel   string `json:"channel"`
 	MessageTS string `json:"message_ts"`
 }
@@ -72,6 +109,8 @@ func ChatGetPermalink(ctx workflow.Context, channelID, timestamp string) (string
 // ChatPostEphemeralRequest is based on:
 // https://docs.slack.dev/reference/methods/chat.postEphemeral/
 type ChatPostEphemeralRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	User    string `json:"user"`
 
@@ -107,6 +146,8 @@ func ChatPostEphemeral(ctx workflow.Context, req ChatPostEphemeralRequest) error
 // ChatPostMessageRequest is based on:
 // https://docs.slack.dev/reference/methods/chat.postMessage/
 type ChatPostMessageRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 
 	Blocks       []map[string]any `json:"blocks,omitempty"`
@@ -135,9 +176,9 @@ type ChatPostMessageRequest struct {
 type ChatPostMessageResponse struct {
 	Response
 
-	Channel string         `json:"channel,omitempty"`
-	TS      string         `json:"ts,omitempty"`
-	Message map[string]any `json:"message,omitempty"`
+	Channel string   `json:"channel,omitempty"`
+	TS      string   `json:"ts,omitempty"`
+	Message *Message `json:"message,omitempty"`
 }
 
 // ChatPostMessage is based on:
@@ -146,9 +187,119 @@ func ChatPostMessage(ctx workflow.Context, req ChatPostMessageRequest) (*ChatPos
 	return internal.ExecuteTimpaniActivity[ChatPostMessageResponse](ctx, ChatPostMessageActivityName, req)
 }
 
+// ChatScheduleMessageRequest is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduleMessage/
+type ChatScheduleMessageRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	Channel string `json:"channel"`
+	PostAt  int64  `json:"post_at"` // Unix time, up to 120 days in the future.
+
+	Blocks       []map[string]any `json:"blocks,omitempty"`
+	Attachments  []map[string]any `json:"attachments,omitempty"`
+	MarkdownText string           `json:"markdown_text,omitempty"`
+	Text         string           `json:"text,omitempty"`
+
+	ThreadTS       string `json:"thread_ts,omitempty"`
+	ReplyBroadcast bool   `json:"reply_broadcast,omitempty"`
+
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	LinkNames   bool   `json:"link_names,omitempty"`
+	Parse       string `json:"parse,omitempty"`
+	UnfurlLinks bool   `json:"unfurl_links,omitempty"`
+	UnfurlMedia bool   `json:"unfurl_media,omitempty"`
+}
+
+// ChatScheduleMessageResponse is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduleMessage/
+type ChatScheduleMessageResponse struct {
+	Response
+
+	Channel            string   `json:"channel,omitempty"`
+	ScheduledMessageID string   `json:"scheduled_message_id,omitempty"`
+	PostAt             int64    `json:"post_at,omitempty"`
+	Message            *Message `json:"message,omitempty"`
+}
+
+// ChatScheduleMessage is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduleMessage/
+//
+// This is an alternative to sleeping in the workflow and then calling [ChatPostMessage].
+// Scheduled messages can be listed with [ChatScheduledMessagesList], and deleted
+// with [ChatDeleteScheduledMessage] until shortly before they are posted.
+//
+// The "post_at" time must be in the future (according to [workflow.Now]), and up to
+// 120 days ahead, otherwise the request isn't sent to Slack. To schedule messages
+// for each recipient's local time, see [schedule.LocalPostAt].
+//
+// [schedule.LocalPostAt]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/slack/schedule#LocalPostAt
+func ChatScheduleMessage(ctx workflow.Context, req ChatScheduleMessageRequest) (*ChatScheduleMessageResponse, error) {
+	return internal.ExecuteTimpaniActivity[ChatScheduleMessageResponse](ctx, ChatScheduleMessageActivityName, req)
+}
+
+// ChatScheduledMessagesListRequest is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/
+type ChatScheduledMessagesListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	Channel string `json:"channel,omitempty"`
+	Latest  string `json:"latest,omitempty"`
+	Oldest  string `json:"oldest,omitempty"`
+
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+
+	TeamID string `json:"team_id,omitempty"`
+}
+
+// ChatScheduledMessagesListResponse is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/
+type ChatScheduledMessagesListResponse struct {
+	Response
+
+	ScheduledMessages []ScheduledMessage `json:"scheduled_messages,omitempty"`
+}
+
+// ChatScheduledMessagesList is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/
+//
+// It returns all the pending scheduled messages in a specific
+// channel (or in all channels if the channel ID is empty),
+// and handles pagination internally.
+func ChatScheduledMessagesList(ctx workflow.Context, channelID string) ([]ScheduledMessage, error) {
+	req := ChatScheduledMessagesListRequest{Channel: channelID}
+	return pagination.Collect(ChatScheduledMessagesListIter(ctx, req), 0)
+}
+
+// ChatScheduledMessagesListIter is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/
+//
+// It returns an iterator over all the pending scheduled
+// messages, and handles pagination internally.
+func ChatScheduledMessagesListIter(ctx workflow.Context, req ChatScheduledMessagesListRequest) iter.Seq2[ScheduledMessage, error] {
+	setCursor := func(r *ChatScheduledMessagesListRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ChatScheduledMessagesListResponse) ([]ScheduledMessage, string) {
+		return r.ScheduledMessages, r.nextCursor()
+	}
+	return pagination.Activity(ctx, ChatScheduledMessagesListActivityName, req, setCursor, page)
+}
+
+// ScheduledMessage is based on:
+// https://docs.slack.dev/reference/methods/chat.scheduledMessages.list/
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text,omitempty"`
+}
+
 // ChatUpdateRequest is based on:
 // https://docs.slack.dev/reference/methods/chat.update/
 type ChatUpdateRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	TS      string `json:"ts"`
 
@@ -171,10 +322,10 @@ type ChatUpdateRequest struct {
 type ChatUpdateResponse struct {
 	Response
 
-	Channel string         `json:"channel,omitempty"`
-	TS      string         `json:"ts,omitempty"`
-	Text    string         `json:"text,omitempty"`
-	Message map[string]any `json:"message,omitempty"`
+	Channel string   `json:"channel,omitempty"`
+	TS      string   `json:"ts,omitempty"`
+	Text    string   `json:"text,omitempty"`
+	Message *Message `json:"message,omitempty"`
 }
 
 // ChatUpdate is based on:
@@ -186,6 +337,8 @@ func ChatUpdate(ctx workflow.Context, req ChatUpdateRequest) error {
 // TimpaniPostApprovalRequest is similar to [ChatPostMessageRequest]. If button
 // labels are not specified here, their default values are "Approve" and "Deny".
 type TimpaniPostApprovalRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 
 	Header      string `json:"header"`
@@ -214,12 +367,84 @@ type TimpaniPostApprovalResponse struct {
 //
 // For message formatting tips, see https://docs.slack.dev/messaging/formatting-message-text.
 func TimpaniPostApprovalWorkflow(ctx workflow.Context, req TimpaniPostApprovalRequest) (map[string]any, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	args := internal.WithThrippyLink(ctx, TimpaniPostApprovalWorkflowName, req)
+	if _, ok := internal.DryRun(ctx, TimpaniPostApprovalWorkflowName, args); ok {
+		return map[string]any{}, nil // No user selection in dry-run mode.
+	}
+
 	resp := new(TimpaniPostApprovalResponse)
-	fut := workflow.ExecuteChildWorkflow(ctx, TimpaniPostApprovalWorkflowName, req)
+	fut := workflow.ExecuteChildWorkflow(ctx, TimpaniPostApprovalWorkflowName, args)
 
 	if err := fut.Get(ctx, resp); err != nil {
-		return nil, err
+		return nil, errors.Classify(TimpaniPostApprovalWorkflowName, err)
 	}
 
 	return resp.InteractionEvent, nil
 }
+
+// Message is based on:
+// https://docs.slack.dev/reference/events/message/
+// https://docs.slack.dev/messaging/retrieving-messages/
+type Message struct {
+	Type    string `json:"type,omitempty"`
+	Subtype string `json:"subtype,omitempty"`
+	TS      string `json:"ts"`
+	Channel string `json:"channel,omitempty"` // Not in "conversations.history" and "conversations.replies".
+	Team    string `json:"team,omitempty"`
+
+	User       string      `json:"user,omitempty"`
+	BotID      string      `json:"bot_id,omitempty"`
+	AppID      string      `json:"app_id,omitempty"`
+	Username   string      `json:"username,omitempty"`
+	BotProfile *BotProfile `json:"bot_profile,omitempty"`
+
+	Text        string           `json:"text,omitempty"`
+	Blocks      []map[string]any `json:"blocks,omitempty"`
+	Attachments []map[string]any `json:"attachments,omitempty"`
+	Files       []File           `json:"files,omitempty"`
+	Upload      bool             `json:"upload,omitempty"`
+
+	ThreadTS        string   `json:"thread_ts,omitempty"`
+	ParentUserID    string   `json:"parent_user_id,omitempty"`
+	ReplyCount      int      `json:"reply_count,omitempty"`
+	ReplyUsers      []string `json:"reply_users,omitempty"`
+	ReplyUsersCount int      `json:"reply_users_count,omitempty"`
+	LatestReply     string   `json:"latest_reply,omitempty"`
+	IsLocked        bool     `json:"is_locked,omitempty"`
+	Subscribed      bool     `json:"subscribed,omitempty"`
+
+	Reactions []Reaction `json:"reactions,omitempty"`
+	Edited    *Edited    `json:"edited,omitempty"`
+	Metadata  *Metadata  `json:"metadata,omitempty"` // Only if requested.
+	PinnedTo  []string   `json:"pinned_to,omitempty"`
+}
+
+// BotProfile is based on:
+// https://docs.slack.dev/reference/events/message/bot_message/
+type BotProfile struct {
+	ID      string            `json:"id"`
+	AppID   string            `json:"app_id,omitempty"`
+	Name    string            `json:"name,omitempty"`
+	TeamID  string            `json:"team_id,omitempty"`
+	Deleted bool              `json:"deleted,omitempty"`
+	Updated int64             `json:"updated,omitempty"`
+	Icons   map[string]string `json:"icons,omitempty"`
+}
+
+// Edited is based on:
+// https://docs.slack.dev/reference/events/message/message_changed/
+type Edited struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// Metadata is based on:
+// https://docs.slack.dev/messaging/message-metadata/
+type Metadata struct {
+	EventType    string         `json:"event_type"`
+	EventPayload map[string]any `json:"event_payload,omitempty"`
+}
@@ -0,0 +1,72 @@
+package slack_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+func TestChatScheduleMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		postAt  time.Duration // Relative to the workflow's current time.
+		wantErr string
+	}{
+		{
+			name:   "tomorrow",
+			postAt: 24 * time.Hour,
+		},
+		{
+			name:   "max_schedule_ahead",
+			postAt: 120 * 24 * time.Hour,
+		},
+		{
+			name:    "now",
+			wantErr: `"post_at" must be in the future`,
+		},
+		{
+			name:    "past",
+			postAt:  -time.Hour,
+			wantErr: `"post_at" must be in the future`,
+		},
+		{
+			name:    "too_far_ahead",
+			postAt:  121 * 24 * time.Hour,
+			wantErr: `"post_at" must be at most 120 days in the future`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			var err error
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				req := slack.ChatScheduleMessageRequest{Channel: channel, Text: "Hi", PostAt: workflow.Now(ctx).Add(tt.postAt).Unix()}
+				_, err = slack.ChatScheduleMessage(ctx, req)
+				return nil
+			})
+			if wfErr := env.GetWorkflowError(); wfErr != nil {
+				t.Fatalf("workflow error: %v", wfErr)
+			}
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ChatScheduleMessage() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ChatScheduleMessage() error = %v, want %q", err, tt.wantErr)
+			}
+			if n := len(w.Calls(slack.ChatScheduleMessageActivityName)); (n == 1) != (tt.wantErr == "") {
+				t.Errorf("activity calls = %d", n)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package slack
 
 import (
 	"errors"
+	"iter"
 	"strings"
 
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -32,6 +34,8 @@ const (
 // ConversationsArchiveRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.archive/
 type ConversationsArchiveRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 }
 
@@ -49,6 +53,8 @@ func ConversationsArchive(ctx workflow.Context, channelID string) error {
 // ConversationsCloseRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.close/
 type ConversationsCloseRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 }
 
@@ -61,9 +67,18 @@ type ConversationsCloseResponse struct {
 	AlreadyClosed bool `json:"already_closed,omitempty"`
 }
 
+// ConversationsClose is based on:
+// https://docs.slack.dev/reference/methods/conversations.close/
+func ConversationsClose(ctx workflow.Context, channelID string) error {
+	req := ConversationsCloseRequest{Channel: channelID}
+	return internal.ExecuteTimpaniActivityNoResp(ctx, ConversationsCloseActivityName, req)
+}
+
 // ConversationsCreateRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.create/
 type ConversationsCreateRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Name string `json:"name"`
 
 	IsPrivate bool   `json:"is_private,omitempty"`
@@ -75,8 +90,7 @@ type ConversationsCreateRequest struct {
 type ConversationsCreateResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
 }
 
 // ConversationsCreate is based on:
@@ -88,16 +102,17 @@ func ConversationsCreate(ctx workflow.Context, name string, private bool) (strin
 		return "", err
 	}
 
-	id, ok := resp.Channel["id"].(string)
-	if !ok {
+	if resp.Channel == nil || resp.Channel.ID == "" {
 		return "", errors.New("channel ID is missing or invalid")
 	}
-	return id, nil
+	return resp.Channel.ID, nil
 }
 
 // ConversationsHistoryRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.history/
 type ConversationsHistoryRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 
 	IncludeAllMetadata bool   `json:"include_all_metadata,omitempty"`
@@ -114,18 +129,42 @@ type ConversationsHistoryRequest struct {
 type ConversationsHistoryResponse struct {
 	Response
 
-	Messages  []map[string]any `json:"messages,omitempty"`
-	Latest    string           `json:"latest,omitempty"`
-	HasMore   bool             `json:"has_more,omitempty"`
-	IsLimited bool             `json:"is_limited,omitempty"`
-	PinCount  int              `json:"pin_count,omitempty"`
+	Messages  []Message `json:"messages,omitempty"`
+	Latest    string    `json:"latest,omitempty"`
+	HasMore   bool      `json:"has_more,omitempty"`
+	IsLimited bool      `json:"is_limited,omitempty"`
+	PinCount  int       `json:"pin_count,omitempty"`
 
 	// Undocumented: "channel_actions_ts" and "channel_actions_count".
 }
 
+// ConversationsHistory is based on:
+// https://docs.slack.dev/reference/methods/conversations.history/
+//
+// It returns all the messages in the channel between the oldest and latest
+// timestamps (inclusive), which are optional, and handles pagination internally.
+// Messages are ordered from the most recent to the oldest, like in Slack's API.
+func ConversationsHistory(ctx workflow.Context, channelID, oldest, latest string) ([]Message, error) {
+	req := ConversationsHistoryRequest{Channel: channelID, Inclusive: true, Oldest: oldest, Latest: latest}
+	return pagination.Collect(ConversationsHistoryIter(ctx, req), 0)
+}
+
+// ConversationsHistoryIter is based on:
+// https://docs.slack.dev/reference/methods/conversations.history/
+//
+// It returns an iterator over all the messages in the channel,
+// and handles pagination internally.
+func ConversationsHistoryIter(ctx workflow.Context, req ConversationsHistoryRequest) iter.Seq2[Message, error] {
+	setCursor := func(r *ConversationsHistoryRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ConversationsHistoryResponse) ([]Message, string) { return r.Messages, r.nextCursor() }
+	return pagination.Activity(ctx, ConversationsHistoryActivityName, req, setCursor, page)
+}
+
 // ConversationsInfoRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.info/
 type ConversationsInfoRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 
 	IncludeLocale     bool `json:"include_locale,omitempty"`
@@ -137,13 +176,12 @@ type ConversationsInfoRequest struct {
 type ConversationsInfoResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
 }
 
 // ConversationsInfo is based on:
 // https://docs.slack.dev/reference/methods/conversations.info/
-func ConversationsInfo(ctx workflow.Context, channelID string, locale, numMembers bool) (map[string]any, error) {
+func ConversationsInfo(ctx workflow.Context, channelID string, locale, numMembers bool) (*Conversation, error) {
 	req := ConversationsInfoRequest{Channel: channelID, IncludeLocale: locale, IncludeNumMembers: numMembers}
 	resp, err := internal.ExecuteTimpaniActivity[ConversationsInfoResponse](ctx, ConversationsInfoActivityName, req)
 	if err != nil {
@@ -155,6 +193,8 @@ func ConversationsInfo(ctx workflow.Context, channelID string, locale, numMember
 // ConversationsInviteRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.invite/
 type ConversationsInviteRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	Users   string `json:"users"`
 
@@ -166,8 +206,7 @@ type ConversationsInviteRequest struct {
 type ConversationsInviteResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
 }
 
 // ConversationsInvite is based on:
@@ -180,6 +219,8 @@ func ConversationsInvite(ctx workflow.Context, channelID string, users []string,
 // ConversationsJoinRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.join/
 type ConversationsJoinRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 }
 
@@ -188,13 +229,21 @@ type ConversationsJoinRequest struct {
 type ConversationsJoinResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
+}
+
+// ConversationsJoin is based on:
+// https://docs.slack.dev/reference/methods/conversations.join/
+func ConversationsJoin(ctx workflow.Context, channelID string) error {
+	req := ConversationsJoinRequest{Channel: channelID}
+	return internal.ExecuteTimpaniActivityNoResp(ctx, ConversationsJoinActivityName, req)
 }
 
 // ConversationsKickRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.kick/
 type ConversationsKickRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	User    string `json:"user"`
 }
@@ -213,6 +262,8 @@ func ConversationsKick(ctx workflow.Context, channelID, userID string) error {
 // ConversationsLeaveRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.leave/
 type ConversationsLeaveRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 }
 
@@ -224,9 +275,18 @@ type ConversationsLeaveResponse struct {
 	NotInChannel bool `json:"not_in_channel,omitempty"`
 }
 
+// ConversationsLeave is based on:
+// https://docs.slack.dev/reference/methods/conversations.leave/
+func ConversationsLeave(ctx workflow.Context, channelID string) error {
+	req := ConversationsLeaveRequest{Channel: channelID}
+	return internal.ExecuteTimpaniActivityNoResp(ctx, ConversationsLeaveActivityName, req)
+}
+
 // ConversationsListRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.list/
 type ConversationsListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Types           string `json:"types,omitempty"`
 	ExcludeArchived bool   `json:"exclude_archived,omitempty"`
 
@@ -241,13 +301,36 @@ type ConversationsListRequest struct {
 type ConversationsListResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channels []map[string]any `json:"channels,omitempty"`
+	Channels []Conversation `json:"channels,omitempty"`
+}
+
+// ConversationsList is based on:
+// https://docs.slack.dev/reference/methods/conversations.list/
+//
+// It returns all the conversations of the given types (e.g. "public_channel",
+// "private_channel", "mpim", "im"), or only public channels if no types are
+// specified, and handles pagination internally.
+func ConversationsList(ctx workflow.Context, types []string, excludeArchived bool) ([]Conversation, error) {
+	req := ConversationsListRequest{Types: strings.Join(types, ","), ExcludeArchived: excludeArchived}
+	return pagination.Collect(ConversationsListIter(ctx, req), 0)
+}
+
+// ConversationsListIter is based on:
+// https://docs.slack.dev/reference/methods/conversations.list/
+//
+// It returns an iterator over all the conversations,
+// and handles pagination internally.
+func ConversationsListIter(ctx workflow.Context, req ConversationsListRequest) iter.Seq2[Conversation, error] {
+	setCursor := func(r *ConversationsListRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ConversationsListResponse) ([]Conversation, string) { return r.Channels, r.nextCursor() }
+	return pagination.Activity(ctx, ConversationsListActivityName, req, setCursor, page)
 }
 
 // ConversationsMembersRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.members/
 type ConversationsMembersRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 
 	Limit  int    `json:"limit,omitempty"`
@@ -262,9 +345,32 @@ type ConversationsMembersResponse struct {
 	Members []string `json:"members,omitempty"`
 }
 
+// ConversationsMembers is based on:
+// https://docs.slack.dev/reference/methods/conversations.members/
+//
+// It returns the IDs of all the members in the conversation,
+// and handles pagination internally.
+func ConversationsMembers(ctx workflow.Context, channelID string) ([]string, error) {
+	req := ConversationsMembersRequest{Channel: channelID}
+	return pagination.Collect(ConversationsMembersIter(ctx, req), 0)
+}
+
+// ConversationsMembersIter is based on:
+// https://docs.slack.dev/reference/methods/conversations.members/
+//
+// It returns an iterator over all the member IDs in the conversation,
+// and handles pagination internally.
+func ConversationsMembersIter(ctx workflow.Context, req ConversationsMembersRequest) iter.Seq2[string, error] {
+	setCursor := func(r *ConversationsMembersRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ConversationsMembersResponse) ([]string, string) { return r.Members, r.nextCursor() }
+	return pagination.Activity(ctx, ConversationsMembersActivityName, req, setCursor, page)
+}
+
 // ConversationsOpenRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.open/
 type ConversationsOpenRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel         string `json:"channel,omitempty"`
 	ReturnIM        bool   `json:"return_im,omitempty"`
 	Users           string `json:"users,omitempty"`
@@ -276,15 +382,34 @@ type ConversationsOpenRequest struct {
 type ConversationsOpenResponse struct {
 	Response
 
-	NoOp        bool `json:"no_op,omitempty"`
-	AlreadyOpen bool `json:"already_open,omitempty"`
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	NoOp        bool          `json:"no_op,omitempty"`
+	AlreadyOpen bool          `json:"already_open,omitempty"`
+	Channel     *Conversation `json:"channel,omitempty"`
+}
+
+// ConversationsOpen is based on:
+// https://docs.slack.dev/reference/methods/conversations.open/
+//
+// It opens (or resumes) a direct message or multi-person
+// direct message with the given users, and returns its ID.
+func ConversationsOpen(ctx workflow.Context, users []string) (string, error) {
+	req := ConversationsOpenRequest{Users: strings.Join(users, ",")}
+	resp, err := internal.ExecuteTimpaniActivity[ConversationsOpenResponse](ctx, ConversationsOpenActivityName, req)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.Channel == nil || resp.Channel.ID == "" {
+		return "", errors.New("channel ID is missing or invalid")
+	}
+	return resp.Channel.ID, nil
 }
 
 // ConversationsRenameRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.rename/
 type ConversationsRenameRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	Name    string `json:"name"`
 }
@@ -294,8 +419,7 @@ type ConversationsRenameRequest struct {
 type ConversationsRenameResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
 }
 
 // ConversationsRename is based on:
@@ -308,6 +432,8 @@ func ConversationsRename(ctx workflow.Context, channelID, name string) error {
 // ConversationsRepliesRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.replies/
 type ConversationsRepliesRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	TS      string `json:"ts"`
 
@@ -325,13 +451,37 @@ type ConversationsRepliesRequest struct {
 type ConversationsRepliesResponse struct {
 	Response
 
-	Messages []map[string]any `json:"messages,omitempty"`
-	HasMore  bool             `json:"has_more,omitempty"`
+	Messages []Message `json:"messages,omitempty"`
+	HasMore  bool      `json:"has_more,omitempty"`
+}
+
+// ConversationsReplies is based on:
+// https://docs.slack.dev/reference/methods/conversations.replies/
+//
+// It returns all the messages in the thread between the oldest and latest timestamps
+// (inclusive), which are optional, and handles pagination internally. The first
+// message is the thread's parent message, if it's in the requested time range.
+func ConversationsReplies(ctx workflow.Context, channelID, threadTS, oldest, latest string) ([]Message, error) {
+	req := ConversationsRepliesRequest{Channel: channelID, TS: threadTS, Inclusive: true, Oldest: oldest, Latest: latest}
+	return pagination.Collect(ConversationsRepliesIter(ctx, req), 0)
+}
+
+// ConversationsRepliesIter is based on:
+// https://docs.slack.dev/reference/methods/conversations.replies/
+//
+// It returns an iterator over all the messages in the thread,
+// and handles pagination internally.
+func ConversationsRepliesIter(ctx workflow.Context, req ConversationsRepliesRequest) iter.Seq2[Message, error] {
+	setCursor := func(r *ConversationsRepliesRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ConversationsRepliesResponse) ([]Message, string) { return r.Messages, r.nextCursor() }
+	return pagination.Activity(ctx, ConversationsRepliesActivityName, req, setCursor, page)
 }
 
 // ConversationsSetPurposeRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.setPurpose/
 type ConversationsSetPurposeRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	Purpose string `json:"purpose"`
 }
@@ -355,6 +505,8 @@ func ConversationsSetPurpose(ctx workflow.Context, channelID, purpose string) er
 // ConversationsSetTopicRequest is based on:
 // https://docs.slack.dev/reference/methods/conversations.setTopic/
 type ConversationsSetTopicRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel string `json:"channel"`
 	Topic   string `json:"topic"`
 }
@@ -364,8 +516,7 @@ type ConversationsSetTopicRequest struct {
 type ConversationsSetTopicResponse struct {
 	Response
 
-	// https://docs.slack.dev/reference/objects/conversation-object/
-	Channel map[string]any `json:"channel,omitempty"`
+	Channel *Conversation `json:"channel,omitempty"`
 }
 
 // ConversationsSetTopic is based on:
@@ -374,3 +525,53 @@ func ConversationsSetTopic(ctx workflow.Context, channelID, topic string) error
 	req := ConversationsSetTopicRequest{Channel: channelID, Topic: topic}
 	return internal.ExecuteTimpaniActivityNoResp(ctx, ConversationsSetTopicActivityName, req)
 }
+
+// Conversation is based on:
+// https://docs.slack.dev/reference/objects/conversation-object/
+type Conversation struct {
+	ID             string `json:"id"`
+	Name           string `json:"name,omitempty"`
+	NameNormalized string `json:"name_normalized,omitempty"`
+	ContextTeamID  string `json:"context_team_id,omitempty"`
+
+	Created int64  `json:"created,omitempty"`
+	Creator string `json:"creator,omitempty"`
+	Updated int64  `json:"updated,omitempty"`
+
+	IsChannel          bool `json:"is_channel,omitempty"`
+	IsGroup            bool `json:"is_group,omitempty"`
+	IsIM               bool `json:"is_im,omitempty"`
+	IsMPIM             bool `json:"is_mpim,omitempty"`
+	IsPrivate          bool `json:"is_private,omitempty"`
+	IsArchived         bool `json:"is_archived,omitempty"`
+	IsGeneral          bool `json:"is_general,omitempty"`
+	IsShared           bool `json:"is_shared,omitempty"`
+	IsExtShared        bool `json:"is_ext_shared,omitempty"`
+	IsOrgShared        bool `json:"is_org_shared,omitempty"`
+	IsPendingExtShared bool `json:"is_pending_ext_shared,omitempty"`
+	IsMember           bool `json:"is_member,omitempty"`
+	IsOpen             bool `json:"is_open,omitempty"`
+	IsUserDeleted      bool `json:"is_user_deleted,omitempty"`
+
+	User string `json:"user,omitempty"` // Only in direct messages.
+
+	Topic         *Topic   `json:"topic,omitempty"`
+	Purpose       *Topic   `json:"purpose,omitempty"`
+	PreviousNames []string `json:"previous_names,omitempty"`
+
+	NumMembers int    `json:"num_members,omitempty"` // Only if requested.
+	Locale     string `json:"locale,omitempty"`      // Only if requested.
+
+	LastRead           string   `json:"last_read,omitempty"`
+	Latest             *Message `json:"latest,omitempty"`
+	UnreadCount        int      `json:"unread_count,omitempty"`
+	UnreadCountDisplay int      `json:"unread_count_display,omitempty"`
+}
+
+// Topic is based on:
+// https://docs.slack.dev/reference/objects/conversation-object/
+type Topic struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator,omitempty"`
+	LastSet int64  `json:"last_set,omitempty"`
+}
@@ -0,0 +1,109 @@
+package events
+
+// Callback is based on:
+// https://docs.slack.dev/apis/events-api/#callback-field
+type Callback[E any] struct {
+	Type      string `json:"type"` // Always "event_callback".
+	TeamID    string `json:"team_id"`
+	APIAppID  string `json:"api_app_id"`
+	EventID   string `json:"event_id"`
+	EventTime int64  `json:"event_time"`
+
+	Event E `json:"event"`
+
+	Authorizations      []Authorization `json:"authorizations,omitempty"`
+	IsExtSharedChannel  bool            `json:"is_ext_shared_channel,omitempty"`
+	EventContext        string          `json:"event_context,omitempty"`
+	ContextTeamID       string          `json:"context_team_id,omitempty"`
+	ContextEnterpriseID string          `json:"context_enterprise_id,omitempty"`
+}
+
+// Authorization is based on:
+// https://docs.slack.dev/apis/events-api/#authorizations
+type Authorization struct {
+	EnterpriseID        string `json:"enterprise_id,omitempty"`
+	TeamID              string `json:"team_id"`
+	UserID              string `json:"user_id"`
+	IsBot               bool   `json:"is_bot"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install,omitempty"`
+}
+
+// AppHomeOpenedEvent is based on:
+// https://docs.slack.dev/reference/events/app_home_opened/
+type AppHomeOpenedEvent struct {
+	Type    string `json:"type"`
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+	Tab     string `json:"tab"` // "home" or "messages".
+	View    *View  `json:"view,omitempty"`
+	EventTS string `json:"event_ts"`
+}
+
+// MemberChannelEvent is based on:
+//   - https://docs.slack.dev/reference/events/member_joined_channel/
+//   - https://docs.slack.dev/reference/events/member_left_channel/
+type MemberChannelEvent struct {
+	Type        string `json:"type"`
+	User        string `json:"user"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	Team        string `json:"team"`
+	Inviter     string `json:"inviter,omitempty"`
+	EventTS     string `json:"event_ts"`
+}
+
+// MessageEvent is based on:
+//   - https://docs.slack.dev/reference/events/message/
+//   - https://docs.slack.dev/reference/events/app_mention/
+type MessageEvent struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype,omitempty"` // E.g. "bot_message", "message_changed", "message_deleted".
+
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type,omitempty"` // "channel", "group", "im", "mpim".
+	User        string `json:"user,omitempty"`
+	BotID       string `json:"bot_id,omitempty"`
+	Team        string `json:"team,omitempty"`
+
+	Text   string           `json:"text"`
+	Blocks []map[string]any `json:"blocks,omitempty"`
+	Files  []map[string]any `json:"files,omitempty"`
+
+	TS              string        `json:"ts"`
+	ThreadTS        string        `json:"thread_ts,omitempty"`
+	ParentUserID    string        `json:"parent_user_id,omitempty"`
+	EventTS         string        `json:"event_ts,omitempty"`
+	Edited          *Edit         `json:"edited,omitempty"`
+	Hidden          bool          `json:"hidden,omitempty"`
+	DeletedTS       string        `json:"deleted_ts,omitempty"`
+	Message         *MessageEvent `json:"message,omitempty"`          // Only in "message_changed" events.
+	PreviousMessage *MessageEvent `json:"previous_message,omitempty"` // Only in "message_changed" and "message_deleted" events.
+}
+
+// Edit is based on:
+// https://docs.slack.dev/reference/events/message/message_changed/
+type Edit struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// ReactionEvent is based on:
+//   - https://docs.slack.dev/reference/events/reaction_added/
+//   - https://docs.slack.dev/reference/events/reaction_removed/
+type ReactionEvent struct {
+	Type     string       `json:"type"`
+	User     string       `json:"user"`
+	Reaction string       `json:"reaction"`
+	ItemUser string       `json:"item_user,omitempty"`
+	Item     ReactionItem `json:"item"`
+	EventTS  string       `json:"event_ts"`
+}
+
+// ReactionItem is based on:
+// https://docs.slack.dev/reference/events/reaction_added/
+type ReactionItem struct {
+	Type    string `json:"type"` // "message" or "file".
+	Channel string `json:"channel,omitempty"`
+	TS      string `json:"ts,omitempty"`
+	File    string `json:"file,omitempty"`
+}
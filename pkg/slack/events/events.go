@@ -0,0 +1,60 @@
+// Package events provides typed payloads of Slack event notifications
+// (both the [Events API] and [interactivity]), which the Timpani worker
+// sends to Temporal workflows as signals, and functions to receive them.
+//
+// This package does not depend on the slack package, so the slack package may use it.
+//
+// [Events API]: https://docs.slack.dev/apis/events-api/
+// [interactivity]: https://docs.slack.dev/interactivity/handling-user-interaction
+package events
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+)
+
+// Signal is the name of a Temporal signal which carries Slack event notifications of type T.
+// Signals are named after the Slack event or interaction type, with the "slack.events." prefix.
+type Signal[T any] string
+
+//revive:disable:exported
+const (
+	AppHomeOpenedSignal       Signal[Callback[AppHomeOpenedEvent]] = "slack.events.app_home_opened"
+	AppMentionSignal          Signal[Callback[MessageEvent]]       = "slack.events.app_mention"
+	MemberJoinedChannelSignal Signal[Callback[MemberChannelEvent]] = "slack.events.member_joined_channel"
+	MemberLeftChannelSignal   Signal[Callback[MemberChannelEvent]] = "slack.events.member_left_channel"
+	MessageSignal             Signal[Callback[MessageEvent]]       = "slack.events.message"
+	ReactionAddedSignal       Signal[Callback[ReactionEvent]]      = "slack.events.reaction_added"
+	ReactionRemovedSignal     Signal[Callback[ReactionEvent]]      = "slack.events.reaction_removed"
+
+	BlockActionsSignal   Signal[BlockActionsPayload]   = "slack.events.block_actions"
+	ViewClosedSignal     Signal[ViewClosedPayload]     = "slack.events.view_closed"
+	ViewSubmissionSignal Signal[ViewSubmissionPayload] = "slack.events.view_submission"
+	SlashCommandSignal   Signal[SlashCommandPayload]   = "slack.events.slash_command"
+	ShortcutSignal       Signal[ShortcutPayload]       = "slack.events.shortcut"
+	MessageActionSignal  Signal[ShortcutPayload]       = "slack.events.message_action"
+) //revive:enable:exported
+
+// Receive waits for the next Slack event notification in a Temporal signal which matches
+// a filter (all events match if the filter is nil), and returns it. Non-matching events are
+// kept for other receivers of the same signal in the same workflow, as described in
+// [internal.ReceiveSignal]. If the timeout is positive and expires first, it returns
+// [errors.ErrTimeout].
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func Receive[T any](ctx workflow.Context, s Signal[T], timeout time.Duration, filter func(*T) bool) (*T, error) {
+	return internal.ReceiveSignal(ctx, string(s), timeout, filter)
+}
+
+// ReceiveEither is similar to [Receive], but waits for the next matching event in either one
+// of two signals (e.g. [ViewSubmissionSignal] and [ViewClosedSignal]). If there's no error,
+// exactly one of the returned events is non-nil. Non-matching events in both signals
+// are kept for other receivers, like in [Receive].
+func ReceiveEither[A, B any](ctx workflow.Context, a Signal[A], b Signal[B], timeout time.Duration,
+	filterA func(*A) bool, filterB func(*B) bool,
+) (*A, *B, error) {
+	return internal.ReceiveEitherSignal(ctx, string(a), string(b), timeout, filterA, filterB)
+}
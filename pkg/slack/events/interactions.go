@@ -0,0 +1,204 @@
+package events
+
+// BlockActionsPayload is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type BlockActionsPayload struct {
+	Type        string `json:"type"` // Always "block_actions".
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url,omitempty"`
+	APIAppID    string `json:"api_app_id"`
+
+	User      User       `json:"user"`
+	Team      *Team      `json:"team,omitempty"`
+	Container *Container `json:"container,omitempty"`
+	Channel   *Channel   `json:"channel,omitempty"`
+
+	Message *MessageEvent `json:"message,omitempty"`
+	View    *View         `json:"view,omitempty"`
+	State   *ViewState    `json:"state,omitempty"`
+
+	Actions []Action `json:"actions"`
+}
+
+// ViewClosedPayload is based on:
+// https://docs.slack.dev/reference/interaction-payloads/view-interactions-payload/#view_closed
+type ViewClosedPayload struct {
+	Type     string `json:"type"` // Always "view_closed".
+	APIAppID string `json:"api_app_id"`
+
+	User User  `json:"user"`
+	Team *Team `json:"team,omitempty"`
+	View View  `json:"view"`
+
+	IsCleared bool `json:"is_cleared"`
+}
+
+// ViewSubmissionPayload is based on:
+// https://docs.slack.dev/reference/interaction-payloads/view-interactions-payload/#view_submission
+type ViewSubmissionPayload struct {
+	Type      string `json:"type"` // Always "view_submission".
+	TriggerID string `json:"trigger_id"`
+	APIAppID  string `json:"api_app_id"`
+
+	User User  `json:"user"`
+	Team *Team `json:"team,omitempty"`
+	View View  `json:"view"`
+
+	ResponseURLs []map[string]any `json:"response_urls,omitempty"`
+}
+
+// ShortcutPayload is based on:
+// https://docs.slack.dev/reference/interaction-payloads/shortcuts-interaction-payload/
+type ShortcutPayload struct {
+	Type        string `json:"type"` // "shortcut" or "message_action".
+	CallbackID  string `json:"callback_id"`
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url,omitempty"`
+	ActionTS    string `json:"action_ts"`
+
+	User    User     `json:"user"`
+	Team    *Team    `json:"team,omitempty"`
+	Channel *Channel `json:"channel,omitempty"`
+
+	Message *MessageEvent `json:"message,omitempty"` // Only in "message_action" payloads.
+}
+
+// SlashCommandPayload is based on:
+// https://docs.slack.dev/interactivity/implementing-slash-commands/#app_command_handling
+type SlashCommandPayload struct {
+	Command     string `json:"command"`
+	Text        string `json:"text"`
+	TriggerID   string `json:"trigger_id"`
+	ResponseURL string `json:"response_url"`
+	APIAppID    string `json:"api_app_id"`
+
+	TeamID      string `json:"team_id"`
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name"`
+	UserID      string `json:"user_id"`
+}
+
+// Action is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type Action struct {
+	Type     string `json:"type"`
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	ActionTS string `json:"action_ts"`
+
+	Text  *Text  `json:"text,omitempty"`
+	Value string `json:"value,omitempty"`
+	Style string `json:"style,omitempty"`
+
+	StateValue
+}
+
+// Channel is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type Channel struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// Container is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type Container struct {
+	Type        string `json:"type"` // "message" or "view".
+	MessageTS   string `json:"message_ts,omitempty"`
+	ThreadTS    string `json:"thread_ts,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	IsEphemeral bool   `json:"is_ephemeral,omitempty"`
+	ViewID      string `json:"view_id,omitempty"`
+}
+
+// Option is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/option-object/
+type Option struct {
+	Text        Text   `json:"text"`
+	Value       string `json:"value"`
+	Description *Text  `json:"description,omitempty"`
+}
+
+// StateValue is based on:
+// https://docs.slack.dev/reference/interaction-payloads/view-interactions-payload/#view_submission
+type StateValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+
+	SelectedOption        *Option  `json:"selected_option,omitempty"`
+	SelectedOptions       []Option `json:"selected_options,omitempty"`
+	SelectedUser          string   `json:"selected_user,omitempty"`
+	SelectedUsers         []string `json:"selected_users,omitempty"`
+	SelectedChannel       string   `json:"selected_channel,omitempty"`
+	SelectedChannels      []string `json:"selected_channels,omitempty"`
+	SelectedConversation  string   `json:"selected_conversation,omitempty"`
+	SelectedConversations []string `json:"selected_conversations,omitempty"`
+	SelectedDate          string   `json:"selected_date,omitempty"`
+	SelectedTime          string   `json:"selected_time,omitempty"`
+	SelectedDateTime      int64    `json:"selected_date_time,omitempty"`
+
+	RichTextValue map[string]any `json:"rich_text_value,omitempty"`
+}
+
+// Team is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type Team struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// Text is based on:
+// https://docs.slack.dev/reference/block-kit/composition-objects/text-object/
+type Text struct {
+	Type  string `json:"type"` // "plain_text" or "mrkdwn".
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// User is based on:
+// https://docs.slack.dev/reference/interaction-payloads/block_actions-payload/
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+	TeamID   string `json:"team_id,omitempty"`
+}
+
+// View is based on:
+// https://docs.slack.dev/reference/interaction-payloads/view-interactions-payload/
+type View struct {
+	ID              string `json:"id"`
+	TeamID          string `json:"team_id,omitempty"`
+	Type            string `json:"type"` // "modal" or "home".
+	CallbackID      string `json:"callback_id,omitempty"`
+	ExternalID      string `json:"external_id,omitempty"`
+	PrivateMetadata string `json:"private_metadata,omitempty"`
+	Hash            string `json:"hash,omitempty"`
+
+	Title  *Text            `json:"title,omitempty"`
+	Close  *Text            `json:"close,omitempty"`
+	Submit *Text            `json:"submit,omitempty"`
+	Blocks []map[string]any `json:"blocks,omitempty"`
+	State  *ViewState       `json:"state,omitempty"`
+
+	RootViewID     string `json:"root_view_id,omitempty"`
+	PreviousViewID string `json:"previous_view_id,omitempty"`
+	AppID          string `json:"app_id,omitempty"`
+	BotID          string `json:"bot_id,omitempty"`
+}
+
+// ViewState is based on:
+// https://docs.slack.dev/reference/interaction-payloads/view-interactions-payload/#view_submission
+type ViewState struct {
+	// Values are mapped by block ID, and then by action ID.
+	Values map[string]map[string]StateValue `json:"values"`
+}
+
+// Value returns the state value of a specific input element in the view, if it exists.
+func (s *ViewState) Value(blockID, actionID string) (StateValue, bool) {
+	if s == nil {
+		return StateValue{}, false
+	}
+	v, ok := s.Values[blockID][actionID]
+	return v, ok
+}
@@ -18,6 +18,8 @@ const (
 // FilesGetUploadURLExternalRequest is based on:
 // https://docs.slack.dev/reference/methods/files.getuploadurlexternal/
 type FilesGetUploadURLExternalRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Length   int    `json:"length"`
 	Filename string `json:"filename"`
 
@@ -48,6 +50,8 @@ func FilesGetUploadURLExternal(ctx workflow.Context, length int, filename, snipp
 // FilesCompleteUploadExternalRequest is based on:
 // https://docs.slack.dev/reference/methods/files.completeuploadexternal/
 type FilesCompleteUploadExternalRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Files []File `json:"files"`
 
 	ChannelID      string `json:"channel_id,omitempty"`
@@ -78,6 +82,8 @@ func FilesCompleteUploadExternal(ctx workflow.Context, req FilesCompleteUploadEx
 // FilesDeleteRequest is based on:
 // https://docs.slack.dev/reference/methods/files.delete/
 type FilesDeleteRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	File string `json:"file"`
 }
 
@@ -94,6 +100,8 @@ func FilesDelete(ctx workflow.Context, file string) error {
 // TimpaniUploadExternalRequest is based on:
 // https://docs.slack.dev/messaging/working-with-files/
 type TimpaniUploadExternalRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	URL      string `json:"url"`
 	MimeType string `json:"mime_type"`
 	Content  []byte `json:"content"`
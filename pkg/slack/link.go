@@ -0,0 +1,14 @@
+package slack
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+// WithThrippyLink returns a copy of the workflow context, which selects a specific
+// Thrippy link for all the Slack activities that are executed with it, unless their
+// requests specify a link ID explicitly (see [temporal.WithThrippyLink]).
+func WithThrippyLink(ctx workflow.Context, linkID string) workflow.Context {
+	return temporal.WithThrippyLink(ctx, "slack", linkID)
+}
@@ -1,9 +1,12 @@
 package slack
 
 import (
+	"iter"
+
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -17,6 +20,8 @@ const (
 // ReactionsAddRequest is based on:
 // https://docs.slack.dev/reference/methods/reactions.add/
 type ReactionsAddRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel   string `json:"channel"`
 	Timestamp string `json:"timestamp"`
 	Name      string `json:"name"`
@@ -36,6 +41,8 @@ func ReactionsAdd(ctx workflow.Context, channelID, timestamp, name string) error
 // ReactionsGetRequest is based on:
 // https://docs.slack.dev/reference/methods/reactions.get/
 type ReactionsGetRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Channel     string `json:"channel,omitempty"`
 	Timestamp   string `json:"timestamp,omitempty"`
 	File        string `json:"file,omitempty"`
@@ -69,6 +76,8 @@ func ReactionsGet(ctx workflow.Context, channelID, timestamp string) (map[string
 // ReactionsListRequest is based on:
 // https://docs.slack.dev/reference/methods/reactions.list/
 type ReactionsListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	User string `json:"user,omitempty"`
 
 	Full   bool   `json:"full,omitempty"`
@@ -88,9 +97,22 @@ type ReactionsListResponse struct {
 	Items []map[string]any `json:"items,omitempty"`
 }
 
+// ReactionsListIter is based on:
+// https://docs.slack.dev/reference/methods/reactions.list/
+//
+// It returns an iterator over all the items that the user reacted to,
+// and handles pagination internally.
+func ReactionsListIter(ctx workflow.Context, req ReactionsListRequest) iter.Seq2[map[string]any, error] {
+	setCursor := func(r *ReactionsListRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *ReactionsListResponse) ([]map[string]any, string) { return r.Items, r.nextCursor() }
+	return pagination.Activity(ctx, ReactionsListActivityName, req, setCursor, page)
+}
+
 // ReactionsRemoveRequest is based on:
 // https://docs.slack.dev/reference/methods/reactions.remove/
 type ReactionsRemoveRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Name string `json:"name"`
 
 	Channel     string `json:"channel,omitempty"`
@@ -109,3 +131,11 @@ func ReactionsRemove(ctx workflow.Context, channelID, timestamp, name string) er
 	req := ReactionsRemoveRequest{Channel: channelID, Timestamp: timestamp, Name: name}
 	return internal.ExecuteTimpaniActivityNoResp(ctx, ReactionsRemoveActivityName, req)
 }
+
+// Reaction is based on:
+// https://docs.slack.dev/reference/methods/reactions.get/
+type Reaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users,omitempty"`
+}
@@ -19,3 +19,12 @@ type ResponseMetadata struct {
 }
 
 //revive:enable:exported
+
+// nextCursor returns the cursor of the next page in paginated
+// responses, or an empty string if this is the last page.
+func (r Response) nextCursor() string {
+	if r.ResponseMetadata == nil {
+		return ""
+	}
+	return r.ResponseMetadata.NextCursor
+}
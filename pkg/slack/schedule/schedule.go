@@ -0,0 +1,44 @@
+// Package schedule helps to schedule Slack messages (see [slack.ChatScheduleMessage])
+// for the local time of each recipient.
+//
+// This package embeds Go's IANA time zone database (about 450 KB, see [time/tzdata]),
+// so the results don't depend on the Temporal worker's host, and are deterministic
+// during replays on other hosts. It's separate from the slack package, so that
+// workers which don't use it don't embed the database.
+package schedule
+
+import (
+	"time"
+	_ "time/tzdata" // Worker hosts may not have an IANA time zone database.
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+)
+
+// LocalPostAt returns the Unix time of the next occurrence (strictly after the given time,
+// e.g. [workflow.Now]) of a specific wall-clock time in a user's time zone, for the
+// "post_at" field in [slack.ChatScheduleMessageRequest]. This allows scheduling
+// messages for each recipient's local time, e.g. daily digests at 9 AM.
+//
+// It uses the user's IANA time zone name ([slack.User.TZ]) if it's valid, to handle
+// daylight saving time correctly. Otherwise, it falls back to the user's current offset
+// from UTC ([slack.User.TZOffset]), or to UTC if the user is nil.
+//
+// [workflow.Now]: https://pkg.go.dev/go.temporal.io/sdk/workflow#Now
+func LocalPostAt(u *slack.User, after time.Time, hour, minute int) int64 {
+	loc := time.UTC
+	if u != nil {
+		loc = time.FixedZone(u.TZLabel, u.TZOffset)
+		if u.TZ != "" {
+			if l, err := time.LoadLocation(u.TZ); err == nil {
+				loc = l
+			}
+		}
+	}
+
+	local := after.In(loc)
+	t := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !t.After(after) {
+		t = time.Date(local.Year(), local.Month(), local.Day()+1, hour, minute, 0, 0, loc)
+	}
+	return t.Unix()
+}
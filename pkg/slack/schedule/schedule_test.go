@@ -0,0 +1,41 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/slack/schedule"
+)
+
+func TestLocalPostAt(t *testing.T) {
+	after := time.Date(2026, time.March, 8, 12, 0, 0, 0, time.UTC) // Shortly after the DST change in New York.
+	tests := []struct {
+		name string
+		user *slack.User
+		want time.Time
+	}{
+		{
+			name: "nil_user",
+			want: time.Date(2026, time.March, 9, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "iana_time_zone",
+			user: &slack.User{TZ: "America/New_York", TZOffset: -5 * 3600},
+			want: time.Date(2026, time.March, 8, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "invalid_time_zone",
+			user: &slack.User{TZ: "Nowhere/Special", TZOffset: -5 * 3600},
+			want: time.Date(2026, time.March, 8, 14, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.LocalPostAt(tt.user, after, 9, 0); got != tt.want.Unix() {
+				t.Errorf("LocalPostAt() = %v, want %v", time.Unix(got, 0).UTC(), tt.want)
+			}
+		})
+	}
+}
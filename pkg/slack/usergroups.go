@@ -15,6 +15,8 @@ const (
 // UserGroupsListRequest is based on:
 // https://docs.slack.dev/reference/methods/usergroups.list/
 type UserGroupsListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	IncludeCount    bool `json:"include_count,omitempty"`
 	IncludeDisabled bool `json:"include_disabled,omitempty"`
 	IncludeUsers    bool `json:"include_users,omitempty"`
@@ -44,6 +46,8 @@ func UserGroupsList(ctx workflow.Context, count, disabled, users bool) ([]UserGr
 // UserGroupsUsersListRequest is based on:
 // https://docs.slack.dev/reference/methods/usergroups.users.list/
 type UserGroupsUsersListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Usergroup string `json:"usergroup"`
 
 	IncludeDisabled bool   `json:"include_disabled,omitempty"`
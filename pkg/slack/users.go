@@ -1,9 +1,12 @@
 package slack
 
 import (
+	"iter"
+
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/pagination"
 )
 
 //revive:disable:exported
@@ -19,6 +22,8 @@ const (
 // UsersConversationsRequest is based on:
 // https://docs.slack.dev/reference/methods/users.conversations/
 type UsersConversationsRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Types           string `json:"types,omitempty"`
 	User            string `json:"user,omitempty"`
 	ExcludeArchived bool   `json:"exclude_archived,omitempty"`
@@ -34,12 +39,25 @@ type UsersConversationsRequest struct {
 type UsersConversationsResponse struct {
 	Response
 
-	Channels []map[string]any `json:"channels,omitempty"`
+	Channels []Conversation `json:"channels,omitempty"`
+}
+
+// UsersConversationsIter is based on:
+// https://docs.slack.dev/reference/methods/users.conversations/
+//
+// It returns an iterator over all the conversations of the user,
+// and handles pagination internally.
+func UsersConversationsIter(ctx workflow.Context, req UsersConversationsRequest) iter.Seq2[Conversation, error] {
+	setCursor := func(r *UsersConversationsRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *UsersConversationsResponse) ([]Conversation, string) { return r.Channels, r.nextCursor() }
+	return pagination.Activity(ctx, UsersConversationsActivityName, req, setCursor, page)
 }
 
 // UsersGetPresenceRequest is based on:
 // https://docs.slack.dev/reference/methods/users.getPresence/
 type UsersGetPresenceRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	User string `json:"user,omitempty"`
 }
 
@@ -54,6 +72,8 @@ type UsersGetPresenceResponse struct {
 // UsersInfoRequest is based on:
 // https://docs.slack.dev/reference/methods/users.info/
 type UsersInfoRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	User string `json:"user"`
 
 	IncludeLocale bool `json:"include_locale,omitempty"`
@@ -81,6 +101,8 @@ func UsersInfo(ctx workflow.Context, userID string) (*User, error) {
 // UsersListRequest is based on:
 // https://docs.slack.dev/reference/methods/users.list/
 type UsersListRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	IncludeLocale bool `json:"include_locale,omitempty"`
 
 	Limit  int    `json:"limit,omitempty"`
@@ -98,9 +120,22 @@ type UsersListResponse struct {
 	CacheTS int64            `json:"cache_ts,omitempty"`
 }
 
+// UsersListIter is based on:
+// https://docs.slack.dev/reference/methods/users.list/
+//
+// It returns an iterator over all the users,
+// and handles pagination internally.
+func UsersListIter(ctx workflow.Context, req UsersListRequest) iter.Seq2[map[string]any, error] {
+	setCursor := func(r *UsersListRequest, cursor string) { r.Cursor = cursor }
+	page := func(r *UsersListResponse) ([]map[string]any, string) { return r.Members, r.nextCursor() }
+	return pagination.Activity(ctx, UsersListActivityName, req, setCursor, page)
+}
+
 // UsersLookupByEmailRequest is based on:
 // https://docs.slack.dev/reference/methods/users.lookupByEmail/
 type UsersLookupByEmailRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	Email string `json:"email"`
 }
 
@@ -126,6 +161,8 @@ func UsersLookupByEmail(ctx workflow.Context, email string) (*User, error) {
 // UsersProfileGetRequest is based on:
 // https://docs.slack.dev/reference/methods/users.profile.get/
 type UsersProfileGetRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
 	User string `json:"user"`
 
 	IncludeLabels bool `json:"include_labels,omitempty"`
@@ -0,0 +1,536 @@
+package slack
+
+import (
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/slack/blocks"
+)
+
+// Client-side validation of Slack requests, based on the "Arguments" and
+// "Errors" sections of each method in https://docs.slack.dev/reference/methods.
+// Validation failures are non-retryable, and are reported before sending
+// requests to the Timpani worker, instead of by Slack after several retries.
+
+var (
+	parseModes    = []string{"full", "none"}
+	tooManyBlocks = `"blocks" must have at most ` + strconv.Itoa(blocks.MaxMessageBlocks) + " items"
+	viewTypes     = []string{"modal", "home"}
+)
+
+// Validate checks the request's required fields and limits. Unlike other requests
+// in this package, its fields are named after the Go struct, not a JSON object.
+func (r ApprovalRequest) Validate() error {
+	v := internal.NewValidator("").
+		Require("Channel", r.Channel).
+		Require("Message", r.Message).
+		Check(!r.ReplyBroadcast || r.ThreadTS != "", `"ReplyBroadcast" requires "ThreadTS"`).
+		Check(utf8.RuneCountInString(r.Header) <= 150, `"Header" must be at most 150 characters`).
+		Check(utf8.RuneCountInString(r.Message) <= 3000, `"Message" must be at most 3000 characters`).
+		Check(len(r.Options) <= 25, `"Options" must have at most 25 items`).
+		Check(r.Quorum >= 0, `"Quorum" must not be negative`).
+		Check(r.Timeout >= 0, `"Timeout" must not be negative`).
+		Check(r.Escalation == nil || r.Timeout > 0, `"Escalation" requires "Timeout"`)
+
+	values := map[string]bool{}
+	for i, o := range r.Options {
+		p := "Options[" + strconv.Itoa(i) + "]"
+		v.Require(p+".Label", o.Label).
+			Require(p+".Value", o.Value).
+			Check(utf8.RuneCountInString(o.Label) <= 75, `"`+p+`.Label" must be at most 75 characters`).
+			Check(utf8.RuneCountInString(o.Value) <= 2000, `"`+p+`.Value" must be at most 2000 characters`).
+			Check(!values[o.Value], `"`+p+`.Value" must be unique`).
+			Enum(p+".Style", string(o.Style), string(blocks.Primary), string(blocks.Danger)).
+			Check(o.Quorum >= 0, `"`+p+`.Quorum" must not be negative`)
+		values[o.Value] = true
+	}
+
+	if e := r.Escalation; e != nil {
+		v.Require("Escalation.Channel", e.Channel).
+			Check(e.Timeout >= 0, `"Escalation.Timeout" must not be negative`)
+	}
+
+	if n := r.approvers(); n > 0 {
+		tooHigh := " must be at most the number of approvers (" + strconv.Itoa(n) + ")"
+		v.Check(r.Quorum <= n, `"Quorum"`+tooHigh)
+		for i, o := range r.Options {
+			v.Check(o.Quorum <= n, `"Options[`+strconv.Itoa(i)+`].Quorum"`+tooHigh)
+		}
+	}
+
+	return v.Err()
+}
+
+// approvers returns the number of different users who may choose an option in the request,
+// including its escalation, or 0 if it's unknown: if anyone may choose, or if some of them
+// are specified as user groups, whose members are listed only when the request is posted.
+func (r ApprovalRequest) approvers() int {
+	users := slices.Clone(r.Approvers)
+	groups := len(r.UserGroups)
+	if e := r.Escalation; e != nil && len(users) > 0 {
+		users = append(users, e.Approvers...)
+		groups += len(e.UserGroups)
+	}
+	if groups > 0 {
+		return 0
+	}
+
+	slices.Sort(users)
+	return len(slices.Compact(users))
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (AuthTestRequest) Validate() error {
+	return nil
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r BookmarksAddRequest) Validate() error {
+	return internal.NewValidator(BookmarksAddActivityName).
+		Require("channel_id", r.ChannelID).
+		Require("title", r.Title).
+		Require("type", r.Type).
+		Enum("type", r.Type, "link").
+		Check(r.Type != "link" || r.Link != "", `type "link" requires "link"`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r BookmarksEditRequest) Validate() error {
+	return internal.NewValidator(BookmarksEditActivityName).
+		Require("channel_id", r.ChannelID).
+		Require("bookmark_id", r.BookmarkID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r BookmarksListRequest) Validate() error {
+	return internal.NewValidator(BookmarksListActivityName).
+		Require("channel_id", r.ChannelID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r BookmarksRemoveRequest) Validate() error {
+	return internal.NewValidator(BookmarksRemoveActivityName).
+		Require("channel_id", r.ChannelID).
+		Require("bookmark_id", r.BookmarkID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r BotsInfoRequest) Validate() error {
+	return internal.NewValidator(BotsInfoActivityName).
+		Require("bot", r.Bot).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatDeleteRequest) Validate() error {
+	return internal.NewValidator(ChatDeleteActivityName).
+		Require("channel", r.Channel).
+		Require("ts", r.TS).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatDeleteScheduledMessageRequest) Validate() error {
+	return internal.NewValidator(ChatDeleteScheduledMessageActivityName).
+		Require("channel", r.Channel).
+		Require("scheduled_message_id", r.ScheduledMessageID).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatGetPermalinkRequest) Validate() error {
+	return internal.NewValidator(ChatGetPermalinkActivityName).
+		Require("channel", r.Channel).
+		Require("message_ts", r.MessageTS).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatPostEphemeralRequest) Validate() error {
+	return internal.NewValidator(ChatPostEphemeralActivityName).
+		Require("channel", r.Channel).
+		Require("user", r.User).
+		RequireAny(`"text", "blocks", "attachments" or "markdown_text"`, r.Text, r.Blocks, r.Attachments, r.MarkdownText).
+		Exclusive(`"markdown_text" and "text" or "blocks"`, r.MarkdownText, r.Text != "" || len(r.Blocks) > 0).
+		Exclusive(`"icon_emoji" and "icon_url"`, r.IconEmoji, r.IconURL).
+		Check(len(r.Blocks) <= blocks.MaxMessageBlocks, tooManyBlocks).
+		Enum("parse", r.Parse, parseModes...).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatPostMessageRequest) Validate() error {
+	return internal.NewValidator(ChatPostMessageActivityName).
+		Require("channel", r.Channel).
+		RequireAny(`"text", "blocks", "attachments" or "markdown_text"`, r.Text, r.Blocks, r.Attachments, r.MarkdownText).
+		Exclusive(`"markdown_text" and "text" or "blocks"`, r.MarkdownText, r.Text != "" || len(r.Blocks) > 0).
+		Exclusive(`"icon_emoji" and "icon_url"`, r.IconEmoji, r.IconURL).
+		Check(!r.ReplyBroadcast || r.ThreadTS != "", `"reply_broadcast" requires "thread_ts"`).
+		Check(len(r.Blocks) <= blocks.MaxMessageBlocks, tooManyBlocks).
+		Enum("parse", r.Parse, parseModes...).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields, and the
+// range of "post_at" relative to the wall-clock time (see [ChatScheduleMessageRequest.ValidateAt]).
+func (r ChatScheduleMessageRequest) Validate() error {
+	return r.ValidateAt(time.Now())
+}
+
+// ValidateAt is similar to [ChatScheduleMessageRequest.Validate], but checks that
+// "post_at" is in the future, and up to 120 days ahead, relative to the given time
+// instead of the wall-clock time. Wrapper functions use [workflow.Now], so the
+// validation of workflows is deterministic, including during replays.
+//
+// [workflow.Now]: https://pkg.go.dev/go.temporal.io/sdk/workflow#Now
+func (r ChatScheduleMessageRequest) ValidateAt(now time.Time) error {
+	return internal.NewValidator(ChatScheduleMessageActivityName).
+		Require("channel", r.Channel).
+		Require("post_at", r.PostAt).
+		Check(r.PostAt == 0 || r.PostAt > now.Unix(), `"post_at" must be in the future`).
+		Check(r.PostAt == 0 || r.PostAt <= now.Add(maxScheduleAhead).Unix(), `"post_at" must be at most 120 days in the future`).
+		RequireAny(`"text", "blocks", "attachments" or "markdown_text"`, r.Text, r.Blocks, r.Attachments, r.MarkdownText).
+		Exclusive(`"markdown_text" and "text" or "blocks"`, r.MarkdownText, r.Text != "" || len(r.Blocks) > 0).
+		Check(!r.ReplyBroadcast || r.ThreadTS != "", `"reply_broadcast" requires "thread_ts"`).
+		Check(len(r.Blocks) <= blocks.MaxMessageBlocks, tooManyBlocks).
+		Enum("parse", r.Parse, parseModes...).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (ChatScheduledMessagesListRequest) Validate() error {
+	return nil
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ChatUpdateRequest) Validate() error {
+	return internal.NewValidator(ChatUpdateActivityName).
+		Require("channel", r.Channel).
+		Require("ts", r.TS).
+		RequireAny(`"text", "blocks", "attachments", "markdown_text" or "file_ids"`,
+			r.Text, r.Blocks, r.Attachments, r.MarkdownText, r.FileIDs).
+		Exclusive(`"markdown_text" and "text" or "blocks"`, r.MarkdownText, r.Text != "" || len(r.Blocks) > 0).
+		Check(len(r.Blocks) <= blocks.MaxMessageBlocks, tooManyBlocks).
+		Enum("parse", r.Parse, parseModes...).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r TimpaniPostApprovalRequest) Validate() error {
+	var err error
+	if r.Timeout != "" {
+		_, err = time.ParseDuration(r.Timeout)
+	}
+
+	return internal.NewValidator(TimpaniPostApprovalWorkflowName).
+		Require("channel", r.Channel).
+		Require("message", r.Message).
+		Check(!r.ReplyBroadcast || r.ThreadTS != "", `"reply_broadcast" requires "thread_ts"`).
+		Check(err == nil, `invalid duration in field "timeout"`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsArchiveRequest) Validate() error {
+	return internal.NewValidator(ConversationsArchiveActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsCloseRequest) Validate() error {
+	return internal.NewValidator(ConversationsCloseActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsCreateRequest) Validate() error {
+	return internal.NewValidator(ConversationsCreateActivityName).
+		Require("name", r.Name).
+		Check(len(r.Name) <= 80, `field "name" is longer than 80 characters`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsHistoryRequest) Validate() error {
+	return internal.NewValidator(ConversationsHistoryActivityName).
+		Require("channel", r.Channel).
+		Check(r.Limit >= 0 && r.Limit <= 999, `field "limit" must be between 0 and 999`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsInfoRequest) Validate() error {
+	return internal.NewValidator(ConversationsInfoActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsInviteRequest) Validate() error {
+	return internal.NewValidator(ConversationsInviteActivityName).
+		Require("channel", r.Channel).
+		Require("users", r.Users).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsJoinRequest) Validate() error {
+	return internal.NewValidator(ConversationsJoinActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsKickRequest) Validate() error {
+	return internal.NewValidator(ConversationsKickActivityName).
+		Require("channel", r.Channel).
+		Require("user", r.User).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsLeaveRequest) Validate() error {
+	return internal.NewValidator(ConversationsLeaveActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsListRequest) Validate() error {
+	return internal.NewValidator(ConversationsListActivityName).
+		Check(r.Limit >= 0 && r.Limit <= 1000, `field "limit" must be between 0 and 1000`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsMembersRequest) Validate() error {
+	return internal.NewValidator(ConversationsMembersActivityName).
+		Require("channel", r.Channel).
+		Check(r.Limit >= 0 && r.Limit <= 1000, `field "limit" must be between 0 and 1000`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsOpenRequest) Validate() error {
+	return internal.NewValidator(ConversationsOpenActivityName).
+		RequireAny(`"channel" or "users"`, r.Channel, r.Users).
+		Exclusive(`"channel" and "users"`, r.Channel, r.Users).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsRenameRequest) Validate() error {
+	return internal.NewValidator(ConversationsRenameActivityName).
+		Require("channel", r.Channel).
+		Require("name", r.Name).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsRepliesRequest) Validate() error {
+	return internal.NewValidator(ConversationsRepliesActivityName).
+		Require("channel", r.Channel).
+		Require("ts", r.TS).
+		Check(r.Limit >= 0 && r.Limit <= 1000, `field "limit" must be between 0 and 1000`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsSetPurposeRequest) Validate() error {
+	return internal.NewValidator(ConversationsSetPurposeActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ConversationsSetTopicRequest) Validate() error {
+	return internal.NewValidator(ConversationsSetTopicActivityName).
+		Require("channel", r.Channel).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r FilesGetUploadURLExternalRequest) Validate() error {
+	return internal.NewValidator(FilesGetUploadURLExternalActivityName).
+		Require("filename", r.Filename).
+		Check(r.Length > 0, `field "length" must be positive`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r FilesCompleteUploadExternalRequest) Validate() error {
+	v := internal.NewValidator(FilesCompleteUploadExternalActivityName).
+		Require("files", r.Files).
+		Exclusive(`"channel_id" and "channels"`, r.ChannelID, r.Channels).
+		Check(r.ThreadTS == "" || r.ChannelID != "", `"thread_ts" requires "channel_id"`)
+	for _, f := range r.Files {
+		v.Require("files.id", f.ID)
+	}
+	return v.Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r FilesDeleteRequest) Validate() error {
+	return internal.NewValidator(FilesDeleteActivityName).
+		Require("file", r.File).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r TimpaniUploadExternalRequest) Validate() error {
+	return internal.NewValidator(TimpaniUploadExternalActivityName).
+		Require("url", r.URL).
+		Require("content", r.Content).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ReactionsAddRequest) Validate() error {
+	return internal.NewValidator(ReactionsAddActivityName).
+		Require("channel", r.Channel).
+		Require("timestamp", r.Timestamp).
+		Require("name", r.Name).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ReactionsGetRequest) Validate() error {
+	return internal.NewValidator(ReactionsGetActivityName).
+		RequireAny(`"channel" and "timestamp", "file" or "file_comment"`, r.Channel, r.File, r.FileComment).
+		Exclusive(`"channel", "file" and "file_comment"`, r.Channel, r.File, r.FileComment).
+		Check((r.Channel == "") == (r.Timestamp == ""), `"channel" and "timestamp" must be set together`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ReactionsListRequest) Validate() error {
+	return internal.NewValidator(ReactionsListActivityName).
+		Check(r.Limit >= 0, `field "limit" must not be negative`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ReactionsRemoveRequest) Validate() error {
+	return internal.NewValidator(ReactionsRemoveActivityName).
+		Require("name", r.Name).
+		RequireAny(`"channel" and "timestamp", "file" or "file_comment"`, r.Channel, r.File, r.FileComment).
+		Exclusive(`"channel", "file" and "file_comment"`, r.Channel, r.File, r.FileComment).
+		Check((r.Channel == "") == (r.Timestamp == ""), `"channel" and "timestamp" must be set together`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (UserGroupsListRequest) Validate() error {
+	return nil
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UserGroupsUsersListRequest) Validate() error {
+	return internal.NewValidator(UserGroupsUsersListActivityName).
+		Require("usergroup", r.Usergroup).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersConversationsRequest) Validate() error {
+	return internal.NewValidator(UsersConversationsActivityName).
+		Check(r.Limit >= 0 && r.Limit <= 1000, `field "limit" must be between 0 and 1000`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (UsersGetPresenceRequest) Validate() error {
+	return nil
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersInfoRequest) Validate() error {
+	return internal.NewValidator(UsersInfoActivityName).
+		Require("user", r.User).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersListRequest) Validate() error {
+	return internal.NewValidator(UsersListActivityName).
+		Check(r.Limit >= 0 && r.Limit <= 1000, `field "limit" must be between 0 and 1000`).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r UsersLookupByEmailRequest) Validate() error {
+	return internal.NewValidator(UsersLookupByEmailActivityName).
+		Require("email", r.Email).
+		Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (UsersProfileGetRequest) Validate() error {
+	return nil
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ViewsOpenRequest) Validate() error {
+	v := internal.NewValidator(ViewsOpenActivityName).
+		RequireAny(`"trigger_id" or "interactivity_pointer"`, r.TriggerID, r.InteractivityPointer).
+		Exclusive(`"trigger_id" and "interactivity_pointer"`, r.TriggerID, r.InteractivityPointer)
+	return validateView(v, r.View).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ViewsPublishRequest) Validate() error {
+	v := internal.NewValidator(ViewsPublishActivityName).
+		Require("user_id", r.UserID).
+		Check(r.View.Type == "home", `"view.type" must be "home"`)
+	return validateView(v, r.View).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ViewsPushRequest) Validate() error {
+	v := internal.NewValidator(ViewsPushActivityName).
+		RequireAny(`"trigger_id" or "interactivity_pointer"`, r.TriggerID, r.InteractivityPointer).
+		Exclusive(`"trigger_id" and "interactivity_pointer"`, r.TriggerID, r.InteractivityPointer)
+	return validateView(v, r.View).Err()
+}
+
+// Validate checks the request's required and mutually exclusive fields.
+func (r ViewsUpdateRequest) Validate() error {
+	v := internal.NewValidator(ViewsUpdateActivityName).
+		RequireAny(`"view_id" or "external_id"`, r.ViewID, r.ExternalID).
+		Exclusive(`"view_id" and "external_id"`, r.ViewID, r.ExternalID)
+	return validateView(v, r.View).Err()
+}
+
+// validateView checks the view's required fields and limits, based on:
+// https://docs.slack.dev/reference/views/
+func validateView(v *internal.Validator, view View) *internal.Validator {
+	v.Require("view.type", view.Type).
+		Enum("view.type", view.Type, viewTypes...).
+		Check(view.Type != "modal" || view.Title != nil, `missing required field "view.title"`).
+		Check(view.Type != "home" || (view.Close == nil && view.Submit == nil),
+			`"view.close" and "view.submit" are only allowed in modals`).
+		Check(len(view.Blocks) <= blocks.MaxViewBlocks,
+			`"view.blocks" must have at most `+strconv.Itoa(blocks.MaxViewBlocks)+" items").
+		Check(utf8.RuneCountInString(view.PrivateMetadata) <= 3000, `"view.private_metadata" must be at most 3000 characters`).
+		Check(utf8.RuneCountInString(view.CallbackID) <= 255, `"view.callback_id" must be at most 255 characters`).
+		Check(utf8.RuneCountInString(view.ExternalID) <= 255, `"view.external_id" must be at most 255 characters`)
+
+	for i, t := range []*blocks.Text{view.Title, view.Close, view.Submit} {
+		if t != nil {
+			field := []string{"view.title", "view.close", "view.submit"}[i]
+			v.Check(t.Type == "plain_text", `"`+field+`" must be "plain_text"`).
+				Check(utf8.RuneCountInString(t.Text) <= 24, `"`+field+`" must be at most 24 characters`)
+		}
+	}
+
+	return v
+}
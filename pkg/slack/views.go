@@ -0,0 +1,251 @@
+package slack
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/slack/blocks"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+)
+
+//revive:disable:exported
+const (
+	ViewsOpenActivityName    = "slack.views.open"
+	ViewsPublishActivityName = "slack.views.publish"
+	ViewsPushActivityName    = "slack.views.push"
+	ViewsUpdateActivityName  = "slack.views.update"
+) //revive:enable:exported
+
+// View is based on:
+// https://docs.slack.dev/reference/views/
+//
+// Blocks can be built and validated with the blocks package (see [NewModal] and [NewHomeTab]).
+// The views which Slack returns, and the views in interaction payloads, are [events.View].
+type View struct {
+	Type   string           `json:"type"`            // "modal" or "home".
+	Title  *blocks.Text     `json:"title,omitempty"` // Required in modals.
+	Blocks []map[string]any `json:"blocks"`
+
+	Close           *blocks.Text `json:"close,omitempty"`  // Only in modals.
+	Submit          *blocks.Text `json:"submit,omitempty"` // Only in modals, required with input blocks.
+	SubmitDisabled  bool         `json:"submit_disabled,omitempty"`
+	ClearOnClose    bool         `json:"clear_on_close,omitempty"`
+	NotifyOnClose   bool         `json:"notify_on_close,omitempty"`
+	PrivateMetadata string       `json:"private_metadata,omitempty"`
+	CallbackID      string       `json:"callback_id,omitempty"`
+	ExternalID      string       `json:"external_id,omitempty"`
+}
+
+// NewModal returns a modal view with the given plain-text title, submit button label
+// (optional if the modal doesn't have input blocks), and validated blocks.
+func NewModal(title, submit string, bs ...blocks.Block) (View, error) {
+	ms, err := blocks.EncodeView(bs...)
+	if err != nil {
+		return View{}, err
+	}
+
+	v := View{Type: "modal", Title: blocks.PlainText(title), Blocks: ms}
+	if submit != "" {
+		v.Submit = blocks.PlainText(submit)
+	}
+	return v, nil
+}
+
+// NewHomeTab returns an App Home tab view with the given validated blocks.
+func NewHomeTab(bs ...blocks.Block) (View, error) {
+	ms, err := blocks.EncodeView(bs...)
+	if err != nil {
+		return View{}, err
+	}
+	return View{Type: "home", Blocks: ms}, nil
+}
+
+// ViewsOpenRequest is based on:
+// https://docs.slack.dev/reference/methods/views.open/
+type ViewsOpenRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	View View `json:"view"`
+
+	TriggerID            string `json:"trigger_id,omitempty"`
+	InteractivityPointer string `json:"interactivity_pointer,omitempty"`
+}
+
+// ViewsOpenResponse is based on:
+// https://docs.slack.dev/reference/methods/views.open/
+type ViewsOpenResponse struct {
+	Response
+
+	View *events.View `json:"view,omitempty"`
+}
+
+// ViewsOpen is based on:
+// https://docs.slack.dev/reference/methods/views.open/
+//
+// The trigger ID is valid for 3 seconds after the user interaction which
+// generated it (e.g. [events.BlockActionsPayload] or [events.ShortcutPayload]).
+func ViewsOpen(ctx workflow.Context, triggerID string, view View) (*events.View, error) {
+	req := ViewsOpenRequest{TriggerID: triggerID, View: view}
+	resp, err := internal.ExecuteTimpaniActivity[ViewsOpenResponse](ctx, ViewsOpenActivityName, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.View, nil
+}
+
+// ViewsPublishRequest is based on:
+// https://docs.slack.dev/reference/methods/views.publish/
+type ViewsPublishRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	UserID string `json:"user_id"`
+	View   View   `json:"view"`
+
+	Hash string `json:"hash,omitempty"`
+}
+
+// ViewsPublishResponse is based on:
+// https://docs.slack.dev/reference/methods/views.publish/
+type ViewsPublishResponse struct {
+	Response
+
+	View *events.View `json:"view,omitempty"`
+}
+
+// ViewsPublish is based on:
+// https://docs.slack.dev/reference/methods/views.publish/
+//
+// It publishes a view in the App Home tab of a specific user.
+func ViewsPublish(ctx workflow.Context, userID string, view View) (*events.View, error) {
+	req := ViewsPublishRequest{UserID: userID, View: view}
+	resp, err := internal.ExecuteTimpaniActivity[ViewsPublishResponse](ctx, ViewsPublishActivityName, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.View, nil
+}
+
+// ViewsPushRequest is based on:
+// https://docs.slack.dev/reference/methods/views.push/
+type ViewsPushRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	View View `json:"view"`
+
+	TriggerID            string `json:"trigger_id,omitempty"`
+	InteractivityPointer string `json:"interactivity_pointer,omitempty"`
+}
+
+// ViewsPushResponse is based on:
+// https://docs.slack.dev/reference/methods/views.push/
+type ViewsPushResponse struct {
+	Response
+
+	View *events.View `json:"view,omitempty"`
+}
+
+// ViewsPush is based on:
+// https://docs.slack.dev/reference/methods/views.push/
+//
+// It pushes a new view onto the stack of an open modal, using
+// a trigger ID from an interaction in the modal's current view.
+func ViewsPush(ctx workflow.Context, triggerID string, view View) (*events.View, error) {
+	req := ViewsPushRequest{TriggerID: triggerID, View: view}
+	resp, err := internal.ExecuteTimpaniActivity[ViewsPushResponse](ctx, ViewsPushActivityName, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.View, nil
+}
+
+// ViewsUpdateRequest is based on:
+// https://docs.slack.dev/reference/methods/views.update/
+type ViewsUpdateRequest struct {
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+
+	View View `json:"view"`
+
+	ExternalID string `json:"external_id,omitempty"`
+	ViewID     string `json:"view_id,omitempty"`
+	Hash       string `json:"hash,omitempty"`
+}
+
+// ViewsUpdateResponse is based on:
+// https://docs.slack.dev/reference/methods/views.update/
+type ViewsUpdateResponse struct {
+	Response
+
+	View *events.View `json:"view,omitempty"`
+}
+
+// ViewsUpdate is based on:
+// https://docs.slack.dev/reference/methods/views.update/
+//
+// The hash is optional: if it's specified, the update fails
+// if the view was already updated since the hash was returned.
+func ViewsUpdate(ctx workflow.Context, viewID, hash string, view View) (*events.View, error) {
+	req := ViewsUpdateRequest{ViewID: viewID, Hash: hash, View: view}
+	resp, err := internal.ExecuteTimpaniActivity[ViewsUpdateResponse](ctx, ViewsUpdateActivityName, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.View, nil
+}
+
+// ModalResult is the outcome of a modal which was opened with [ViewsOpenAndWait].
+type ModalResult struct {
+	// Submitted is true if the user submitted the modal, or false if they closed it.
+	Submitted bool
+	User      events.User
+	View      events.View
+
+	// Values are the state values of the modal's input elements when it
+	// was submitted, mapped by block ID, and then by action ID.
+	Values map[string]map[string]events.StateValue
+}
+
+// Value returns the submitted state value of a specific input element in the modal, if it exists.
+func (r *ModalResult) Value(blockID, actionID string) (events.StateValue, bool) {
+	v, ok := r.Values[blockID][actionID]
+	return v, ok
+}
+
+// ViewsOpenAndWait is a convenience wrapper over [ViewsOpen] and [events.ReceiveEither].
+// It opens a modal, and then waits for the user to submit or close it. Slack doesn't
+// notify about closed modals by default, so this function sets [View.NotifyOnClose].
+//
+// If the timeout is positive and expires first, it returns
+// [errors.ErrTimeout], but the modal remains open in Slack.
+//
+// [errors.ErrTimeout]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/errors#ErrTimeout
+func ViewsOpenAndWait(ctx workflow.Context, triggerID string, view View, timeout time.Duration) (*ModalResult, error) {
+	view.NotifyOnClose = true
+	v, err := ViewsOpen(ctx, triggerID, view)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil || v.ID == "" {
+		return nil, errors.New("view ID is missing or invalid")
+	}
+
+	submitted, closed, err := events.ReceiveEither(ctx, events.ViewSubmissionSignal, events.ViewClosedSignal, timeout,
+		func(p *events.ViewSubmissionPayload) bool { return p.View.ID == v.ID },
+		func(p *events.ViewClosedPayload) bool { return p.View.ID == v.ID },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if closed != nil {
+		return &ModalResult{User: closed.User, View: closed.View}, nil
+	}
+
+	r := &ModalResult{Submitted: true, User: submitted.User, View: submitted.View}
+	if submitted.View.State != nil {
+		r.Values = submitted.View.State.Values
+	}
+	return r, nil
+}
@@ -0,0 +1,205 @@
+// Package standalone executes Timpani activities outside Temporal workflows
+// (e.g. in CLIs, cron jobs, and HTTP handlers), with the same typed requests,
+// responses, and errors as the wrapper functions in this module's service packages.
+//
+// A [Client] runs each request either through a tiny generic workflow (see
+// [ExecutorWorkflow], which must be registered in one of your Temporal workers),
+// or directly as a Temporal standalone activity, if the Temporal server supports it.
+package standalone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/registry"
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+// ExecutorWorkflowName is the Temporal name of [ExecutorWorkflow].
+const ExecutorWorkflowName = "timpani-api.execute"
+
+// Mode determines how a [Client] executes Timpani activities.
+type Mode int
+
+// Modes of executing Timpani activities.
+const (
+	// Executor runs each activity in its own [ExecutorWorkflow], so all
+	// the module's activity options, retry profiles and interceptors apply.
+	Executor Mode = iota
+	// StandaloneActivity executes each activity directly, without a workflow,
+	// with the options of [temporal.ActivityDefaultsFor]. This requires
+	// Temporal server support for standalone activities, which is experimental.
+	StandaloneActivity
+)
+
+// Options configure a [Client].
+type Options struct {
+	Mode Mode
+	// TaskQueue is the task queue of the Temporal worker in which [ExecutorWorkflow]
+	// is registered. It is required in [Executor] mode, and ignored otherwise.
+	TaskQueue string
+	// IDPrefix is the prefix of the workflow or activity IDs of each execution,
+	// which are followed by the activity name and a random UUID.
+	// The default is "timpani-".
+	IDPrefix string
+}
+
+// Client executes Timpani activities with a Temporal client.
+type Client struct {
+	temporal client.Client
+	opts     Options
+}
+
+// New returns a new [Client], which uses the given Temporal client.
+func New(c client.Client, opts Options) *Client {
+	if opts.IDPrefix == "" {
+		opts.IDPrefix = "timpani-"
+	}
+	return &Client{temporal: c, opts: opts}
+}
+
+// Execute executes a Timpani activity, by its name (e.g. [slack.ChatPostMessageActivityName]),
+// and waits for its response. The activity must be in the [registry], and the request type
+// (or a pointer to it) and the response type must match its entry, except that the response
+// type may be "any" to ignore the response. The request is validated before it is sent,
+// exactly as in workflows, and activity failures are converted into typed errors when
+// possible (see [errors.Classify]).
+//
+// [slack.ChatPostMessageActivityName]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/slack#ChatPostMessageActivityName
+func Execute[T any](ctx context.Context, c *Client, name string, req any) (*T, error) {
+	if err := checkTypes(name, req, reflect.TypeFor[T]()); err != nil {
+		return nil, err
+	}
+	if err := internal.Validate(name, req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp := new(T)
+	if err := c.execute(ctx, name, req, resp); err != nil {
+		return nil, errors.Classify(name, err)
+	}
+	return resp, nil
+}
+
+// ExecuteNoResp is a convenience wrapper around [Execute]
+// for activities that do not return a response.
+func ExecuteNoResp(ctx context.Context, c *Client, name string, req any) error {
+	_, err := Execute[any](ctx, c, name, req)
+	return err
+}
+
+// checkTypes checks that an activity is in the [registry], and that
+// the request and response types match its entry (see [Execute]).
+func checkTypes(name string, req any, resp reflect.Type) error {
+	e, ok := registry.Lookup(name)
+	if !ok || e.Kind != registry.Activity {
+		return internal.NewValidator(name).Check(false, "unknown Timpani activity").Err()
+	}
+
+	reqType := reflect.TypeOf(req)
+	if reqType != nil && reqType.Kind() == reflect.Pointer {
+		reqType = reqType.Elem()
+	}
+
+	return internal.NewValidator(name).
+		Check(reqType == e.Request, fmt.Sprintf("request type %s, want %s", typeName(reqType), typeName(e.Request))).
+		Check(resp == e.Response || resp == reflect.TypeFor[any](),
+			fmt.Sprintf("response type %s, want %s or any", typeName(resp), typeName(e.Response))).
+		Err()
+}
+
+// typeName returns the name of a type, or "none" if it's nil.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "none"
+	}
+	return t.String()
+}
+
+func (c *Client) execute(ctx context.Context, name string, req, resp any) error {
+	id := fmt.Sprintf("%s%s-%s", c.opts.IDPrefix, name, uuid.NewString())
+
+	if c.opts.Mode == StandaloneActivity {
+		return c.executeActivity(ctx, id, name, req, resp)
+	}
+
+	if c.opts.TaskQueue == "" {
+		return fmt.Errorf("missing task queue for executing %q in executor workflow", name)
+	}
+
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %q request: %w", name, err)
+	}
+
+	opts := client.StartWorkflowOptions{ID: id, TaskQueue: c.opts.TaskQueue, StaticSummary: name}
+	run, err := c.temporal.ExecuteWorkflow(ctx, opts, ExecutorWorkflowName, ExecutorRequest{Name: name, Request: raw})
+	if err != nil {
+		return fmt.Errorf("failed to start executor workflow for %q: %w", name, err)
+	}
+	if err := run.Get(ctx, resp); err != nil {
+		return fmt.Errorf("executor workflow failed: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) executeActivity(ctx context.Context, id, name string, req, resp any) error {
+	ao := temporal.ActivityDefaultsFor(name)
+	opts := client.StartActivityOptions{
+		ID:                     id,
+		TaskQueue:              ao.TaskQueue,
+		ScheduleToCloseTimeout: ao.ScheduleToCloseTimeout,
+		ScheduleToStartTimeout: ao.ScheduleToStartTimeout,
+		StartToCloseTimeout:    ao.StartToCloseTimeout,
+		HeartbeatTimeout:       ao.HeartbeatTimeout,
+		RetryPolicy:            ao.RetryPolicy,
+		Summary:                ao.Summary,
+	}
+
+	h, err := c.temporal.ExecuteActivity(ctx, opts, name, req)
+	if err != nil {
+		return fmt.Errorf("failed to start standalone activity %q: %w", name, err)
+	}
+	if err := h.Get(ctx, resp); err != nil {
+		return fmt.Errorf("standalone activity failed: %w", err)
+	}
+	return nil
+}
+
+// ExecutorRequest is the input of [ExecutorWorkflow]. The activity's request
+// is passed through as raw JSON, to preserve it exactly (e.g. large integer IDs).
+type ExecutorRequest struct {
+	Name    string          `json:"name"`
+	Request json.RawMessage `json:"request"`
+}
+
+// ExecutorWorkflow executes a single Timpani activity on behalf of a [Client], with
+// all the module's activity options, retry profiles and interceptors, and returns
+// the activity's response as-is. The request was already validated by the [Client].
+func ExecutorWorkflow(ctx workflow.Context, req ExecutorRequest) (json.RawMessage, error) {
+	resp, err := internal.ExecuteTimpaniActivity[json.RawMessage](ctx, req.Name, req.Request)
+	if err != nil {
+		// Return the original activity error, for the client to classify.
+		if info, ok := errors.InfoOf(err); ok && info.Unwrap() != nil {
+			err = info.Unwrap()
+		}
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// Register registers [ExecutorWorkflow] in a Temporal worker, which
+// listens to the task queue that is configured in [Options].
+func Register(w worker.WorkflowRegistry) {
+	w.RegisterWorkflowWithOptions(ExecutorWorkflow, workflow.RegisterOptions{Name: ExecutorWorkflowName})
+}
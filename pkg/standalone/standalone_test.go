@@ -0,0 +1,250 @@
+package standalone_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/standalone"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const channelID = "C123"
+
+// fakeClient is a Temporal client which runs executor workflows in a test
+// workflow environment, with a fake Timpani worker, and returns a fixed result
+// for standalone activities. All its other methods panic.
+type fakeClient struct {
+	client.Client
+
+	worker *timpanitest.Worker
+
+	workflows  []client.StartWorkflowOptions
+	activities []client.StartActivityOptions
+	activity   func(name string, req any) (any, error)
+}
+
+func (c *fakeClient) ExecuteWorkflow(_ context.Context, opts client.StartWorkflowOptions, workflow any, args ...any) (client.WorkflowRun, error) {
+	c.workflows = append(c.workflows, opts)
+
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	standalone.Register(env)
+	c.worker.Register(env)
+
+	env.ExecuteWorkflow(workflow, args...)
+	return &fakeRun{env: env}, nil
+}
+
+func (c *fakeClient) ExecuteActivity(_ context.Context, opts client.StartActivityOptions, activity any, args ...any) (client.ActivityHandle, error) {
+	c.activities = append(c.activities, opts)
+
+	resp, err := c.activity(activity.(string), args[0])
+	return &fakeHandle{resp: resp, err: err}, nil
+}
+
+type fakeRun struct {
+	client.WorkflowRun
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (r *fakeRun) Get(_ context.Context, valuePtr any) error {
+	if err := r.env.GetWorkflowError(); err != nil {
+		return err
+	}
+	return r.env.GetWorkflowResult(valuePtr)
+}
+
+type fakeHandle struct {
+	client.ActivityHandle
+
+	resp any
+	err  error
+}
+
+func (h *fakeHandle) Get(_ context.Context, valuePtr any) error {
+	if h.err != nil {
+		return h.err
+	}
+
+	// Round-trip the response, as the Temporal client would.
+	b, err := json.Marshal(h.resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, valuePtr)
+}
+
+func TestExecutor(t *testing.T) {
+	w := timpanitest.New()
+	fc := &fakeClient{worker: w}
+	c := standalone.New(fc, standalone.Options{TaskQueue: "my-task-queue"})
+
+	req := slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"}
+	resp, err := standalone.Execute[slack.ChatPostMessageResponse](t.Context(), c, slack.ChatPostMessageActivityName, req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !resp.OK || resp.Channel != channelID || resp.TS == "" {
+		t.Errorf("Execute() = %+v, want a posted message", resp)
+	}
+	w.AssertCalled(t, slack.ChatPostMessageActivityName, 1)
+
+	if len(fc.workflows) != 1 {
+		t.Fatalf("started %d workflows, want 1", len(fc.workflows))
+	}
+	if got := fc.workflows[0]; got.TaskQueue != "my-task-queue" || !strings.HasPrefix(got.ID, "timpani-slack.chat.postMessage-") {
+		t.Errorf("workflow options = %+v, want task queue and ID prefix", got)
+	}
+
+	// Activity failures are converted into typed errors.
+	req2 := slack.ReactionsAddRequest{Channel: channelID, Timestamp: "1234567890.000001", Name: "eyes"}
+	if err := standalone.ExecuteNoResp(t.Context(), c, slack.ReactionsAddActivityName, req2); !errors.IsNotFound(err) {
+		t.Errorf("ExecuteNoResp() error = %v, want a not-found error", err)
+	}
+
+	// Pointers to requests are also accepted.
+	if err := standalone.ExecuteNoResp(t.Context(), c, slack.ReactionsAddActivityName, &slack.ReactionsAddRequest{
+		Channel: resp.Channel, Timestamp: resp.TS, Name: "eyes",
+	}); err != nil {
+		t.Errorf("ExecuteNoResp() error = %v", err)
+	}
+	w.AssertCalled(t, slack.ReactionsAddActivityName, 2)
+}
+
+func TestExecutorMissingTaskQueue(t *testing.T) {
+	c := standalone.New(&fakeClient{}, standalone.Options{})
+	req := slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"}
+	if _, err := standalone.Execute[slack.ChatPostMessageResponse](t.Context(), c, slack.ChatPostMessageActivityName, req); err == nil {
+		t.Error("Execute() error = nil, want a missing task queue")
+	}
+}
+
+func TestStandaloneActivity(t *testing.T) {
+	var gotName string
+	var gotReq any
+	fc := &fakeClient{activity: func(name string, req any) (any, error) {
+		gotName, gotReq = name, req
+		switch name {
+		case slack.ChatPostMessageActivityName:
+			return slack.ChatPostMessageResponse{Response: slack.Response{OK: true}, Channel: channelID, TS: "1234567890.000001"}, nil
+		default:
+			opts := temporal.ApplicationErrorOptions{NonRetryable: true, Details: []any{errors.Details{Code: "message_not_found"}}}
+			return nil, temporal.NewApplicationErrorWithOptions("message_not_found", errors.TypeNotFound, opts)
+		}
+	}}
+	c := standalone.New(fc, standalone.Options{Mode: standalone.StandaloneActivity, IDPrefix: "cli-"})
+
+	req := slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"}
+	resp, err := standalone.Execute[slack.ChatPostMessageResponse](t.Context(), c, slack.ChatPostMessageActivityName, req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !resp.OK || resp.TS != "1234567890.000001" {
+		t.Errorf("Execute() = %+v, want the activity's response", resp)
+	}
+	if gotName != slack.ChatPostMessageActivityName || !reflect.DeepEqual(gotReq, req) {
+		t.Errorf("ExecuteActivity() name = %q, request = %v, want %q and %v", gotName, gotReq, slack.ChatPostMessageActivityName, req)
+	}
+
+	// Standalone activities have the module's default activity options.
+	if len(fc.activities) != 1 {
+		t.Fatalf("started %d activities, want 1", len(fc.activities))
+	}
+	opts := fc.activities[0]
+	if !strings.HasPrefix(opts.ID, "cli-slack.chat.postMessage-") {
+		t.Errorf("activity ID = %q, want prefix %q", opts.ID, "cli-slack.chat.postMessage-")
+	}
+	if opts.TaskQueue != "timpani" || opts.StartToCloseTimeout != 5*time.Second || opts.RetryPolicy.MaximumAttempts != 5 {
+		t.Errorf("activity options = %+v, want the defaults", opts)
+	}
+
+	// Activity failures are converted into typed errors.
+	req2 := slack.ReactionsAddRequest{Channel: channelID, Timestamp: "1234567890.000001", Name: "eyes"}
+	if err := standalone.ExecuteNoResp(t.Context(), c, slack.ReactionsAddActivityName, req2); !errors.IsNotFound(err) {
+		t.Errorf("ExecuteNoResp() error = %v, want a not-found error", err)
+	}
+}
+
+func TestExecuteInvalid(t *testing.T) {
+	validReq := slack.ChatPostMessageRequest{Channel: channelID, Text: "hello"}
+
+	tests := []struct {
+		name    string
+		execute func(c *standalone.Client) error
+		want    string
+	}{
+		{
+			name: "unknown_activity",
+			execute: func(c *standalone.Client) error {
+				return standalone.ExecuteNoResp(t.Context(), c, "slack.chat.unknown", validReq)
+			},
+			want: "unknown Timpani activity",
+		},
+		{
+			name: "workflow",
+			execute: func(c *standalone.Client) error {
+				return standalone.ExecuteNoResp(t.Context(), c, slack.TimpaniPostApprovalWorkflowName, slack.TimpaniPostApprovalRequest{})
+			},
+			want: "unknown Timpani activity",
+		},
+		{
+			name: "request_type_mismatch",
+			execute: func(c *standalone.Client) error {
+				return standalone.ExecuteNoResp(t.Context(), c, slack.ReactionsAddActivityName, validReq)
+			},
+			want: "request type slack.ChatPostMessageRequest, want slack.ReactionsAddRequest",
+		},
+		{
+			name: "response_type_mismatch",
+			execute: func(c *standalone.Client) error {
+				_, err := standalone.Execute[slack.ChatUpdateResponse](t.Context(), c, slack.ChatPostMessageActivityName, validReq)
+				return err
+			},
+			want: "response type slack.ChatUpdateResponse, want slack.ChatPostMessageResponse or any",
+		},
+		{
+			name: "invalid_request",
+			execute: func(c *standalone.Client) error {
+				req := slack.ChatPostMessageRequest{Text: "hello"}
+				_, err := standalone.Execute[slack.ChatPostMessageResponse](t.Context(), c, slack.ChatPostMessageActivityName, req)
+				return err
+			},
+			want: `missing required field "channel"`,
+		},
+		{
+			name: "time_dependent_validation",
+			execute: func(c *standalone.Client) error {
+				req := slack.ChatScheduleMessageRequest{Channel: channelID, Text: "hello", PostAt: time.Now().Add(-time.Hour).Unix()}
+				_, err := standalone.Execute[slack.ChatScheduleMessageResponse](t.Context(), c, slack.ChatScheduleMessageActivityName, req)
+				return err
+			},
+			want: `"post_at" must be in the future`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeClient{}
+			c := standalone.New(fc, standalone.Options{TaskQueue: "my-task-queue"})
+
+			err := tt.execute(c)
+			if fmt.Sprintf("%T", err) != "*errors.ValidationFailedError" || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Execute() error = %v, want a validation error with %q", err, tt.want)
+			}
+			if len(fc.workflows) > 0 || len(fc.activities) > 0 {
+				t.Error("Execute() started an execution, want none")
+			}
+		})
+	}
+}
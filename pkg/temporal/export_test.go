@@ -0,0 +1,4 @@
+package temporal
+
+// SlackTiers exposes the rate limit tiers of Slack activities to tests.
+var SlackTiers = slackTiers
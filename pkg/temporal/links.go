@@ -0,0 +1,34 @@
+package temporal
+
+import (
+	"maps"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+type linksKey struct{}
+
+// WithThrippyLink returns a copy of the workflow context, which selects a specific
+// [Thrippy] link for all the Timpani activities and child workflows of a specific
+// service (e.g. "slack") that are executed with it. This is useful for multi-tenant
+// workflows, which serve multiple workspaces or organizations of the same service.
+//
+// Requests that specify a Thrippy link ID explicitly take precedence over the
+// context. An empty link ID restores the Timpani worker's default link.
+//
+// [Thrippy]: https://github.com/tzrikka/thrippy
+func WithThrippyLink(ctx workflow.Context, service, linkID string) workflow.Context {
+	links := map[string]string{}
+	if parent, ok := ctx.Value(linksKey{}).(map[string]string); ok {
+		maps.Copy(links, parent)
+	}
+	links[service] = linkID
+	return workflow.WithValue(ctx, linksKey{}, links)
+}
+
+// ThrippyLink returns the Thrippy link ID which is selected in the
+// workflow context for a specific service, or an empty string.
+func ThrippyLink(ctx workflow.Context, service string) string {
+	links, _ := ctx.Value(linksKey{}).(map[string]string)
+	return links[service]
+}
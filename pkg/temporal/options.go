@@ -1,5 +1,7 @@
 // Package temporal provides common, modifiable activity options
 // for all Timpani activities, related to timeouts and retries.
+// These options may be overridden per service, per activity,
+// and per workflow context (see [Overrides]).
 package temporal
 
 import (
@@ -7,6 +9,8 @@ import (
 
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
 )
 
 // ActivityOptions are used when executing all Timpani activities.
@@ -17,13 +21,15 @@ var ActivityOptions = DefaultActivityOptions("timpani")
 // DefaultActivityOptions adds a few reasonable values
 // to the [workflow.ActivityOptions] defaults:
 //   - Maximum number of attempts = 5,
-//   - Maximum runtime for each attempt = 5 seconds.
+//   - Maximum runtime for each attempt = 5 seconds,
+//   - No retries for [errors.NonRetryableTypes] (e.g. not found, permission denied).
 func DefaultActivityOptions(taskQueue string) *workflow.ActivityOptions {
 	return &workflow.ActivityOptions{
 		TaskQueue:           taskQueue,
 		StartToCloseTimeout: 5 * time.Second,
 		RetryPolicy: &RetryPolicy{
-			MaximumAttempts: 5,
+			MaximumAttempts:        5,
+			NonRetryableErrorTypes: errors.NonRetryableTypes(),
 		},
 	}
 }
@@ -0,0 +1,182 @@
+package temporal
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// Overrides are partial [workflow.ActivityOptions], which are applied on top of
+// [ActivityOptions] when executing specific Timpani activities. Zero values are ignored,
+// including those of the retry policy's fields, so a partial retry policy (e.g. only
+// MaximumAttempts) overrides only those fields, and keeps the others.
+type Overrides struct {
+	TaskQueue string
+
+	ScheduleToCloseTimeout time.Duration
+	ScheduleToStartTimeout time.Duration
+	StartToCloseTimeout    time.Duration
+	HeartbeatTimeout       time.Duration
+
+	RetryPolicy *RetryPolicy
+
+	Summary string
+}
+
+type overridesKey struct{}
+
+var (
+	defaultsMu       sync.RWMutex
+	serviceDefaults  = map[string]Overrides{}
+	activityDefaults = map[string]Overrides{}
+)
+
+// SetServiceDefaults registers overrides for all the Timpani activities of a
+// specific service (e.g. "slack", "github"). Temporal workers that use Timpani
+// should call this function only when they start, before running any workflows.
+func SetServiceDefaults(service string, o Overrides) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	serviceDefaults[service] = o
+}
+
+// SetActivityDefaults registers overrides for a single Timpani activity, by its name
+// (e.g. [bitbucket.CommitsDiffActivityName]). Temporal workers that use Timpani
+// should call this function only when they start, before running any workflows.
+// These overrides take precedence over those in [SetServiceDefaults].
+//
+// [bitbucket.CommitsDiffActivityName]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/bitbucket#CommitsDiffActivityName
+func SetActivityDefaults(name string, o Overrides) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	activityDefaults[name] = o
+}
+
+// WithOverrides returns a copy of the workflow context, which applies the given overrides
+// to all the Timpani activities that are executed with it. Nested calls are merged,
+// i.e. non-zero values in inner calls take precedence over those in outer calls.
+//
+// These overrides take precedence over those in [SetServiceDefaults] and [SetActivityDefaults].
+func WithOverrides(ctx workflow.Context, o Overrides) workflow.Context {
+	if parent, ok := ctx.Value(overridesKey{}).(Overrides); ok {
+		o = parent.merge(o)
+	}
+	return workflow.WithValue(ctx, overridesKey{}, o)
+}
+
+// ActivityOptionsFor returns the effective [workflow.ActivityOptions] for executing
+// a specific Timpani activity. They are based on the global [ActivityOptions], with
+// retry profiles (if enabled), service defaults, activity defaults, and context
+// overrides applied in that order.
+func ActivityOptionsFor(ctx workflow.Context, name string) workflow.ActivityOptions {
+	opts := ActivityDefaultsFor(name)
+	if o, ok := ctx.Value(overridesKey{}).(Overrides); ok {
+		o.apply(&opts)
+	}
+	return opts
+}
+
+// ActivityDefaultsFor is similar to [ActivityOptionsFor], but without
+// context overrides, for executing Timpani activities outside workflows.
+func ActivityDefaultsFor(name string) workflow.ActivityOptions {
+	base := ActivityOptions
+	if base == nil {
+		base = DefaultActivityOptions("timpani")
+	}
+	opts := *base
+
+	service, _, _ := strings.Cut(name, ".")
+
+	defaultsMu.RLock()
+	if retryProfiles {
+		RetryProfile(name).apply(&opts)
+	}
+	if o, ok := serviceDefaults[service]; ok {
+		o.apply(&opts)
+	}
+	if o, ok := activityDefaults[name]; ok {
+		o.apply(&opts)
+	}
+	defaultsMu.RUnlock()
+
+	return opts
+}
+
+// merge returns a copy of o, with the non-zero values of other overriding it.
+func (o Overrides) merge(other Overrides) Overrides {
+	if other.TaskQueue != "" {
+		o.TaskQueue = other.TaskQueue
+	}
+	if other.ScheduleToCloseTimeout > 0 {
+		o.ScheduleToCloseTimeout = other.ScheduleToCloseTimeout
+	}
+	if other.ScheduleToStartTimeout > 0 {
+		o.ScheduleToStartTimeout = other.ScheduleToStartTimeout
+	}
+	if other.StartToCloseTimeout > 0 {
+		o.StartToCloseTimeout = other.StartToCloseTimeout
+	}
+	if other.HeartbeatTimeout > 0 {
+		o.HeartbeatTimeout = other.HeartbeatTimeout
+	}
+	if other.RetryPolicy != nil {
+		o.RetryPolicy = mergeRetryPolicy(o.RetryPolicy, other.RetryPolicy)
+	}
+	if other.Summary != "" {
+		o.Summary = other.Summary
+	}
+	return o
+}
+
+// apply sets the non-zero values of o in the given activity options.
+func (o Overrides) apply(opts *workflow.ActivityOptions) {
+	if o.TaskQueue != "" {
+		opts.TaskQueue = o.TaskQueue
+	}
+	if o.ScheduleToCloseTimeout > 0 {
+		opts.ScheduleToCloseTimeout = o.ScheduleToCloseTimeout
+	}
+	if o.ScheduleToStartTimeout > 0 {
+		opts.ScheduleToStartTimeout = o.ScheduleToStartTimeout
+	}
+	if o.StartToCloseTimeout > 0 {
+		opts.StartToCloseTimeout = o.StartToCloseTimeout
+	}
+	if o.HeartbeatTimeout > 0 {
+		opts.HeartbeatTimeout = o.HeartbeatTimeout
+	}
+	if o.RetryPolicy != nil {
+		opts.RetryPolicy = mergeRetryPolicy(opts.RetryPolicy, o.RetryPolicy)
+	}
+	if o.Summary != "" {
+		opts.Summary = o.Summary
+	}
+}
+
+// mergeRetryPolicy returns a copy of rp (which may be nil),
+// with the non-zero values of other overriding it.
+func mergeRetryPolicy(rp, other *RetryPolicy) *RetryPolicy {
+	merged := RetryPolicy{}
+	if rp != nil {
+		merged = *rp
+	}
+
+	if other.InitialInterval > 0 {
+		merged.InitialInterval = other.InitialInterval
+	}
+	if other.BackoffCoefficient > 0 {
+		merged.BackoffCoefficient = other.BackoffCoefficient
+	}
+	if other.MaximumInterval > 0 {
+		merged.MaximumInterval = other.MaximumInterval
+	}
+	if other.MaximumAttempts > 0 {
+		merged.MaximumAttempts = other.MaximumAttempts
+	}
+	if other.NonRetryableErrorTypes != nil {
+		merged.NonRetryableErrorTypes = other.NonRetryableErrorTypes
+	}
+	return &merged
+}
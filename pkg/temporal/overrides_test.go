@@ -0,0 +1,188 @@
+package temporal_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+const activityName = "slack.chat.postMessage"
+
+// activityOptionsFor returns the result of [temporal.ActivityOptionsFor] in
+// a test workflow, with nested context overrides (outermost first).
+func activityOptionsFor(t *testing.T, name string, overrides ...temporal.Overrides) workflow.ActivityOptions {
+	t.Helper()
+
+	var opts workflow.ActivityOptions
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		for _, o := range overrides {
+			ctx = temporal.WithOverrides(ctx, o)
+		}
+		opts = temporal.ActivityOptionsFor(ctx, name)
+		return nil
+	})
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+	return opts
+}
+
+func TestActivityOptionsFor(t *testing.T) {
+	tests := []struct {
+		name             string
+		activity         string
+		retryProfiles    bool
+		serviceDefaults  temporal.Overrides
+		activityDefaults temporal.Overrides
+		ctxOverrides     []temporal.Overrides
+		want             workflow.ActivityOptions
+	}{
+		{
+			name:     "global_options",
+			activity: activityName,
+			want:     *temporal.DefaultActivityOptions("timpani"),
+		},
+		{
+			name:          "retry_profile",
+			activity:      activityName,
+			retryProfiles: true,
+			want: workflow.ActivityOptions{
+				TaskQueue:              "timpani",
+				ScheduleToCloseTimeout: 10 * time.Minute,
+				StartToCloseTimeout:    5 * time.Second,
+				RetryPolicy:            temporal.SlackRetryPolicy(temporal.SlackTier3),
+			},
+		},
+		{
+			name:            "service_defaults_over_retry_profile",
+			activity:        activityName,
+			retryProfiles:   true,
+			serviceDefaults: temporal.Overrides{StartToCloseTimeout: 10 * time.Second, RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 3}},
+			want: workflow.ActivityOptions{
+				TaskQueue:              "timpani",
+				ScheduleToCloseTimeout: 10 * time.Minute,
+				StartToCloseTimeout:    10 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:        1200 * time.Millisecond,
+					BackoffCoefficient:     2.0,
+					MaximumInterval:        time.Minute,
+					MaximumAttempts:        3,
+					NonRetryableErrorTypes: errors.NonRetryableTypes(),
+				},
+			},
+		},
+		{
+			name:            "activity_defaults_over_service_defaults",
+			activity:        activityName,
+			serviceDefaults: temporal.Overrides{TaskQueue: "service", StartToCloseTimeout: 10 * time.Second},
+			activityDefaults: temporal.Overrides{
+				StartToCloseTimeout: 20 * time.Second,
+				RetryPolicy:         &temporal.RetryPolicy{MaximumInterval: time.Minute},
+			},
+			want: workflow.ActivityOptions{
+				TaskQueue:           "service",
+				StartToCloseTimeout: 20 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					MaximumInterval:        time.Minute,
+					MaximumAttempts:        5,
+					NonRetryableErrorTypes: errors.NonRetryableTypes(),
+				},
+			},
+		},
+		{
+			name:             "context_overrides_over_all_defaults",
+			activity:         activityName,
+			retryProfiles:    true,
+			serviceDefaults:  temporal.Overrides{StartToCloseTimeout: 10 * time.Second, RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 3}},
+			activityDefaults: temporal.Overrides{StartToCloseTimeout: 20 * time.Second, Summary: "activity"},
+			ctxOverrides: []temporal.Overrides{
+				{StartToCloseTimeout: 30 * time.Second, RetryPolicy: &temporal.RetryPolicy{InitialInterval: 5 * time.Second}},
+			},
+			want: workflow.ActivityOptions{
+				TaskQueue:              "timpani",
+				ScheduleToCloseTimeout: 10 * time.Minute,
+				StartToCloseTimeout:    30 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:        5 * time.Second,
+					BackoffCoefficient:     2.0,
+					MaximumInterval:        time.Minute,
+					MaximumAttempts:        3,
+					NonRetryableErrorTypes: errors.NonRetryableTypes(),
+				},
+				Summary: "activity",
+			},
+		},
+		{
+			name:     "nested_context_overrides",
+			activity: activityName,
+			ctxOverrides: []temporal.Overrides{
+				{StartToCloseTimeout: 30 * time.Second, Summary: "outer", RetryPolicy: &temporal.RetryPolicy{InitialInterval: 5 * time.Second}},
+				{Summary: "inner", RetryPolicy: &temporal.RetryPolicy{MaximumAttempts: 2}},
+			},
+			want: workflow.ActivityOptions{
+				TaskQueue:           "timpani",
+				StartToCloseTimeout: 30 * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:        5 * time.Second,
+					MaximumAttempts:        2,
+					NonRetryableErrorTypes: errors.NonRetryableTypes(),
+				},
+				Summary: "inner",
+			},
+		},
+		{
+			name:             "other_service",
+			activity:         "github.users.get",
+			serviceDefaults:  temporal.Overrides{StartToCloseTimeout: 10 * time.Second},
+			activityDefaults: temporal.Overrides{StartToCloseTimeout: 20 * time.Second},
+			want:             *temporal.DefaultActivityOptions("timpani"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			temporal.UseRetryProfiles(tt.retryProfiles)
+			temporal.SetServiceDefaults("slack", tt.serviceDefaults)
+			temporal.SetActivityDefaults(activityName, tt.activityDefaults)
+			t.Cleanup(func() {
+				temporal.UseRetryProfiles(false)
+				temporal.SetServiceDefaults("slack", temporal.Overrides{})
+				temporal.SetActivityDefaults(activityName, temporal.Overrides{})
+			})
+
+			got := activityOptionsFor(t, tt.activity, tt.ctxOverrides...)
+			if !reflect.DeepEqual(got.RetryPolicy, tt.want.RetryPolicy) {
+				t.Errorf("ActivityOptionsFor() retry policy = %+v, want %+v", got.RetryPolicy, tt.want.RetryPolicy)
+			}
+			got.RetryPolicy, tt.want.RetryPolicy = nil, nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActivityOptionsFor() = %+v, want %+v", got, tt.want)
+			}
+
+			// The global options must not be modified by any of the overrides.
+			if !reflect.DeepEqual(temporal.ActivityOptions, temporal.DefaultActivityOptions("timpani")) {
+				t.Errorf("ActivityOptions = %+v, want the defaults", temporal.ActivityOptions)
+			}
+		})
+	}
+}
+
+func TestActivityDefaultsFor(t *testing.T) {
+	temporal.SetActivityDefaults(activityName, temporal.Overrides{StartToCloseTimeout: time.Minute})
+	t.Cleanup(func() { temporal.SetActivityDefaults(activityName, temporal.Overrides{}) })
+
+	// Unlike ActivityOptionsFor, this doesn't have a workflow context with overrides.
+	want := *temporal.DefaultActivityOptions("timpani")
+	want.StartToCloseTimeout = time.Minute
+	if got := temporal.ActivityDefaultsFor(activityName); !reflect.DeepEqual(got, want) {
+		t.Errorf("ActivityDefaultsFor() = %+v, want %+v", got, want)
+	}
+}
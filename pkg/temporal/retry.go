@@ -0,0 +1,194 @@
+package temporal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+// Slack's rate limit tiers, based on:
+// https://docs.slack.dev/apis/web-api/rate-limits
+const (
+	SlackTier1 = 1 // 1+ per minute.
+	SlackTier2 = 2 // 20+ per minute.
+	SlackTier3 = 3 // 50+ per minute.
+	SlackTier4 = 4 // 100+ per minute.
+)
+
+// slackTiers maps Slack activities to their rate limit tiers, based on the "Rate limits"
+// section of each method in https://docs.slack.dev/reference/methods. Methods with
+// special rate limits are mapped to the closest tier.
+var slackTiers = map[string]int{
+	"slack.auth.test": SlackTier4,
+
+	"slack.bookmarks.add":    SlackTier2,
+	"slack.bookmarks.edit":   SlackTier2,
+	"slack.bookmarks.list":   SlackTier3,
+	"slack.bookmarks.remove": SlackTier2,
+
+	"slack.bots.info": SlackTier3,
+
+	"slack.chat.delete":                 SlackTier3,
+	"slack.chat.deleteScheduledMessage": SlackTier3,
+	"slack.chat.getPermalink":           SlackTier4,
+	"slack.chat.postEphemeral":          SlackTier4,
+	"slack.chat.postMessage":            SlackTier3, // Special: 1 per second per channel.
+	"slack.chat.scheduleMessage":        SlackTier3, // Special: 30 per 5 minutes per channel.
+	"slack.chat.scheduledMessages.list": SlackTier3,
+	"slack.chat.update":                 SlackTier3,
+
+	"slack.conversations.archive":    SlackTier2,
+	"slack.conversations.close":      SlackTier2,
+	"slack.conversations.create":     SlackTier2,
+	"slack.conversations.history":    SlackTier3,
+	"slack.conversations.info":       SlackTier3,
+	"slack.conversations.invite":     SlackTier3,
+	"slack.conversations.join":       SlackTier3,
+	"slack.conversations.kick":       SlackTier3,
+	"slack.conversations.leave":      SlackTier3,
+	"slack.conversations.list":       SlackTier2,
+	"slack.conversations.members":    SlackTier4,
+	"slack.conversations.open":       SlackTier3,
+	"slack.conversations.rename":     SlackTier2,
+	"slack.conversations.replies":    SlackTier3,
+	"slack.conversations.setPurpose": SlackTier2,
+	"slack.conversations.setTopic":   SlackTier2,
+
+	"slack.files.completeUploadExternal": SlackTier4,
+	"slack.files.delete":                 SlackTier3,
+	"slack.files.getUploadURLExternal":   SlackTier4,
+
+	"slack.reactions.add":    SlackTier3,
+	"slack.reactions.get":    SlackTier3,
+	"slack.reactions.list":   SlackTier2,
+	"slack.reactions.remove": SlackTier2,
+
+	"slack.timpani.uploadExternal": SlackTier4, // Same as the files methods that it calls.
+
+	"slack.usergroups.list":       SlackTier2,
+	"slack.usergroups.users.list": SlackTier2,
+
+	"slack.users.conversations": SlackTier3,
+	"slack.users.getPresence":   SlackTier3,
+	"slack.users.info":          SlackTier4,
+	"slack.users.list":          SlackTier2,
+	"slack.users.lookupByEmail": SlackTier3,
+	"slack.users.profile.get":   SlackTier4,
+
+	"slack.views.open":    SlackTier4,
+	"slack.views.publish": SlackTier4,
+	"slack.views.push":    SlackTier4,
+	"slack.views.update":  SlackTier4,
+}
+
+// SlackTier returns the rate limit tier of a Slack activity, by its name
+// (e.g. "slack.chat.postMessage"). Unknown activities are considered Tier 2,
+// to err on the safe side.
+func SlackTier(name string) int {
+	if tier, ok := slackTiers[name]; ok {
+		return tier
+	}
+	return SlackTier2
+}
+
+// SlackRetryPolicy returns a retry policy which spreads retries according to a Slack
+// rate limit tier: the initial interval is the tier's minimal interval between calls,
+// and the maximum interval is Slack's rate limit window (1 minute).
+func SlackRetryPolicy(tier int) *RetryPolicy {
+	perMinute := map[int]int{SlackTier1: 1, SlackTier2: 20, SlackTier3: 50, SlackTier4: 100}[tier]
+	if perMinute == 0 {
+		perMinute = 20
+	}
+
+	return &RetryPolicy{
+		InitialInterval:        time.Minute / time.Duration(perMinute),
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        time.Minute,
+		MaximumAttempts:        8,
+		NonRetryableErrorTypes: errors.NonRetryableTypes(),
+	}
+}
+
+// GitHubRetryPolicy returns a retry policy for GitHub activities, based on:
+// https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+func GitHubRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval:        2 * time.Second,
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        2 * time.Minute,
+		MaximumAttempts:        6,
+		NonRetryableErrorTypes: errors.NonRetryableTypes(),
+	}
+}
+
+// BitbucketRetryPolicy returns a retry policy for Bitbucket activities, based on:
+// https://support.atlassian.com/bitbucket-cloud/docs/api-request-limits/
+func BitbucketRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval:        2 * time.Second,
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        5 * time.Minute,
+		MaximumAttempts:        6,
+		NonRetryableErrorTypes: errors.NonRetryableTypes(),
+	}
+}
+
+// JiraRetryPolicy returns a retry policy for Jira activities, based on:
+// https://developer.atlassian.com/cloud/jira/platform/rate-limiting/
+func JiraRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval:        2 * time.Second,
+		BackoffCoefficient:     2.0,
+		MaximumInterval:        time.Minute,
+		MaximumAttempts:        5,
+		NonRetryableErrorTypes: errors.NonRetryableTypes(),
+	}
+}
+
+// retryProfileTimeout bounds the total time of all the attempts of a single
+// activity, including upstream rate limit hints which may be long (e.g.
+// GitHub's primary rate limit resets every hour). When it expires, the activity
+// returns its last failure, e.g. a [errors.RateLimitedError] with the upstream hint.
+const retryProfileTimeout = 10 * time.Minute
+
+// RetryProfile returns the service-aware [Overrides] for a specific Timpani activity,
+// by its name (e.g. "slack.chat.postMessage"), or zero overrides if its service
+// doesn't have a retry profile. See [UseRetryProfiles] for more details.
+func RetryProfile(name string) Overrides {
+	var rp *RetryPolicy
+	switch service, _, _ := strings.Cut(name, "."); service {
+	case "slack":
+		rp = SlackRetryPolicy(SlackTier(name))
+	case "github":
+		rp = GitHubRetryPolicy()
+	case "bitbucket":
+		rp = BitbucketRetryPolicy()
+	case "jira":
+		rp = JiraRetryPolicy()
+	default:
+		return Overrides{}
+	}
+
+	return Overrides{ScheduleToCloseTimeout: retryProfileTimeout, RetryPolicy: rp}
+}
+
+var retryProfiles bool
+
+// UseRetryProfiles enables or disables service-aware retry policies for all Timpani
+// activities (see [RetryProfile]), instead of the generic one in [ActivityOptions].
+// They are applied on top of [ActivityOptions], and below all other [Overrides].
+// Temporal workers that use Timpani should call this function only when they
+// start, before running any workflows.
+//
+// When the Timpani worker encounters an upstream rate limit (e.g. Slack's HTTP 429
+// responses, GitHub's "x-ratelimit-reset" and "retry-after" headers, Bitbucket's
+// HTTP 429 responses), it sets the activity failure's next retry delay accordingly.
+// Temporal uses that delay instead of the retry policy's backoff interval, so retry
+// policies determine only the backoff of other failures, and the maximum number of
+// attempts. The retry profiles' schedule-to-close timeout limits the total duration.
+func UseRetryProfiles(enabled bool) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	retryProfiles = enabled
+}
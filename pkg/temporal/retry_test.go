@@ -0,0 +1,25 @@
+package temporal_test
+
+import (
+	"testing"
+
+	"github.com/tzrikka/timpani-api/pkg/registry"
+	"github.com/tzrikka/timpani-api/pkg/temporal"
+)
+
+func TestSlackTiers(t *testing.T) {
+	for _, e := range registry.Service("slack") {
+		if e.Kind != registry.Activity {
+			continue
+		}
+		if _, ok := temporal.SlackTiers[e.Name]; !ok {
+			t.Errorf("Slack activity %q has no rate limit tier", e.Name)
+		}
+	}
+
+	for name := range temporal.SlackTiers {
+		if e, ok := registry.Lookup(name); !ok || e.Kind != registry.Activity {
+			t.Errorf("rate limit tier of %q, which isn't a Slack activity in the registry", name)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package timpani
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/async"
+)
+
+// CapabilitiesAsync is an asynchronous version of [Capabilities].
+func CapabilitiesAsync(ctx workflow.Context) async.Future[*CapabilitiesResponse] {
+	return async.Go(ctx, func(ctx workflow.Context) (*CapabilitiesResponse, error) {
+		return Capabilities(ctx)
+	})
+}
@@ -0,0 +1,78 @@
+package timpani
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/internal"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+//revive:disable:exported
+const (
+	CapabilitiesActivityName = "timpani.capabilities"
+) //revive:enable:exported
+
+// CapabilitiesResponse describes the version and capabilities of the Timpani worker.
+type CapabilitiesResponse struct {
+	Version string `json:"version"`
+
+	Activities []string `json:"activities"`
+	Workflows  []string `json:"workflows,omitempty"`
+
+	Links []Link `json:"links,omitempty"`
+}
+
+// Link describes a third-party service which is linked to the Timpani worker.
+type Link struct {
+	Service string `json:"service"`
+
+	// ThrippyLinkID is empty for the worker's default link of the service.
+	ThrippyLinkID string `json:"thrippy_link_id,omitempty"`
+}
+
+// Supports reports whether the Timpani worker supports the
+// given activity or child workflow name (see the registry package).
+func (r *CapabilitiesResponse) Supports(name string) bool {
+	return slices.Contains(r.Activities, name) || slices.Contains(r.Workflows, name)
+}
+
+// Capabilities returns the version and capabilities of the Timpani worker:
+// its supported activities and workflows, and its linked third-party services.
+func Capabilities(ctx workflow.Context) (*CapabilitiesResponse, error) {
+	return internal.ExecuteTimpaniActivity[CapabilitiesResponse](ctx, CapabilitiesActivityName, nil)
+}
+
+// Require checks that the Timpani worker supports all the given activity and child workflow
+// names, so workflows can fail fast instead of failing midway. If the worker does not support
+// one or more of them, Require returns an [errors.UnsupportedError] which lists all the missing
+// names. If the worker is too old to support this check at all, the error lists all the given
+// names, because there is no way to tell which of them are missing. For example:
+//
+//	err := timpani.Require(ctx, slack.ChatPostMessageActivityName, github.PullRequestsReviewsDismissActivityName)
+func Require(ctx workflow.Context, names ...string) error {
+	caps, err := Capabilities(ctx)
+	if err != nil {
+		if info, ok := errors.InfoOf(err); ok && errors.IsUnsupported(err) {
+			return &errors.UnsupportedError{Info: *info, Missing: slices.Clone(names)}
+		}
+		return err
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !caps.Supports(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Timpani worker %s does not support: %s", caps.Version, strings.Join(missing, ", "))
+	info := errors.Info{Activity: CapabilitiesActivityName, Message: msg}
+	return &errors.UnsupportedError{Info: info, Missing: missing, WorkerVersion: caps.Version}
+}
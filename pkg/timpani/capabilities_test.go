@@ -0,0 +1,93 @@
+package timpani_test
+
+import (
+	"slices"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpani"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+// requireWorkflow returns the missing names in [timpani.Require]'s
+// error, because typed errors don't cross the workflow's boundary as-is.
+func requireWorkflow(ctx workflow.Context, names []string) ([]string, error) {
+	err := timpani.Require(ctx, names...)
+	if u, ok := err.(*errors.UnsupportedError); ok { //nolint:errorlint // Require returns it unwrapped.
+		return u.Missing, nil
+	}
+	return nil, err
+}
+
+func TestRequire(t *testing.T) {
+	names := []string{slack.ChatPostMessageActivityName, slack.ViewsOpenActivityName, slack.TimpaniPostApprovalWorkflowName}
+
+	tests := []struct {
+		name    string
+		remove  []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all_supported",
+		},
+		{
+			name:   "one_missing",
+			remove: []string{slack.ViewsOpenActivityName},
+			want:   []string{slack.ViewsOpenActivityName},
+		},
+		{
+			name:   "several_missing",
+			remove: []string{slack.ChatPostMessageActivityName, slack.ViewsOpenActivityName},
+			want:   []string{slack.ChatPostMessageActivityName, slack.ViewsOpenActivityName},
+		},
+		{
+			name:   "unsupported_handshake",
+			remove: []string{timpani.CapabilitiesActivityName},
+			want:   names,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			for _, name := range tt.remove {
+				w.Handle(name, nil)
+			}
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			w.Register(env)
+
+			env.ExecuteWorkflow(requireWorkflow, names)
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			var got []string
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatalf("failed to get workflow result: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Require() missing = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupports(t *testing.T) {
+	caps := &timpani.CapabilitiesResponse{Activities: []string{"a.b"}, Workflows: []string{"c.d"}}
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{"a.b", true},
+		{"c.d", true},
+		{"e.f", false},
+	} {
+		if got := caps.Supports(tt.name); got != tt.want {
+			t.Errorf("Supports(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package timpani provides request/response types, and Timpani activity names and
+// wrapper functions, for interacting with the Timpani worker itself (as opposed to
+// third-party services), e.g. to check which activities it supports.
+package timpani
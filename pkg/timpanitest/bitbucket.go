@@ -0,0 +1,355 @@
+package timpanitest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+	"github.com/tzrikka/timpani-api/pkg/errors"
+)
+
+func bitbucketHandlers() map[string]Handler {
+	return map[string]Handler{
+		bitbucket.CommitsDiffActivityName:     typed(bitbucketCommitsDiff),
+		bitbucket.CommitsDiffstatActivityName: typed(bitbucketCommitsDiffstat),
+
+		bitbucket.PullRequestsApproveActivityName:         typed(bitbucketPullRequestsApprove),
+		bitbucket.PullRequestsCreateCommentActivityName:   typed(bitbucketPullRequestsCreateComment),
+		bitbucket.PullRequestsDeclineActivityName:         typed(bitbucketPullRequestsDecline),
+		bitbucket.PullRequestsDeleteCommentActivityName:   typed(bitbucketPullRequestsDeleteComment),
+		bitbucket.PullRequestsDiffstatActivityName:        typed(bitbucketPullRequestsDiffstat),
+		bitbucket.PullRequestsGetActivityName:             typed(bitbucketPullRequestsGet),
+		bitbucket.PullRequestsGetCommentActivityName:      typed(bitbucketPullRequestsGetComment),
+		bitbucket.PullRequestsListActivityLogActivityName: typed(bitbucketPullRequestsListActivityLog),
+		bitbucket.PullRequestsListCommitsActivityName:     typed(bitbucketPullRequestsListCommits),
+		bitbucket.PullRequestsListForCommitActivityName:   typed(bitbucketPullRequestsListForCommit),
+		bitbucket.PullRequestsListTasksActivityName:       typed(bitbucketPullRequestsListTasks),
+		bitbucket.PullRequestsMergeActivityName:           typed(bitbucketPullRequestsMerge),
+		bitbucket.PullRequestsUnapproveActivityName:       typed(bitbucketPullRequestsUnapprove),
+		bitbucket.PullRequestsUpdateActivityName:          typed(bitbucketPullRequestsUpdate),
+		bitbucket.PullRequestsUpdateCommentActivityName:   typed(bitbucketPullRequestsUpdateComment),
+
+		bitbucket.SourceGetFileActivityName: typed(bitbucketSourceGetFile),
+
+		bitbucket.UsersGetActivityName: typed(bitbucketUsersGet),
+
+		bitbucket.WorkspacesListMembersActivityName: typed(bitbucketWorkspacesListMembers),
+	}
+}
+
+var bitbucketBot = bitbucket.User{Type: "app_user", DisplayName: "Timpani", AccountID: "timpani", UUID: "{timpani}"}
+
+func (w *Worker) bitbucketPR(req bitbucket.PullRequestsRequest) (*bitbucketPR, error) {
+	pr, ok := w.state.bitbucketPRs[bitbucketKey(req.Workspace, req.RepoSlug, req.PullRequestID)]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return pr, nil
+}
+
+func (w *Worker) bitbucketComment(req bitbucket.PullRequestsRequest, commentID string) (*bitbucketPR, *bitbucket.Comment, error) {
+	pr, err := w.bitbucketPR(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := strconv.Atoi(commentID)
+	if err != nil {
+		return nil, nil, httpErr(errors.TypeValidationFailed, http.StatusBadRequest)
+	}
+	c, ok := pr.comments[id]
+	if !ok {
+		return nil, nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return pr, c, nil
+}
+
+// bitbucketOpen returns a conflict error if the PR is not open anymore.
+func bitbucketOpen(pr *bitbucketPR) error {
+	if pr.pr["state"] != "OPEN" {
+		return httpErr(errors.TypeConflict, http.StatusBadRequest)
+	}
+	return nil
+}
+
+func bitbucketCommitsDiff(w *Worker, req bitbucket.CommitsDiffRequest) (any, error) {
+	d, ok := w.state.bitbucketDiffs[req.Workspace+"/"+req.RepoSlug+"@"+req.Spec]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return d.diff, nil
+}
+
+func bitbucketCommitsDiffstat(w *Worker, req bitbucket.CommitsDiffstatRequest) (any, error) {
+	d, ok := w.state.bitbucketDiffs[req.Workspace+"/"+req.RepoSlug+"@"+req.Spec]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+
+	values, next := bitbucketPage(d.diffstat, req.Next, req.PageLen)
+	return bitbucket.CommitsDiffstatResponse{Values: values, Size: len(d.diffstat), Next: next}, nil
+}
+
+func bitbucketPullRequestsApprove(w *Worker, req bitbucket.PullRequestsApproveRequest) (any, error) {
+	pr, err := w.bitbucketPR(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := bitbucketOpen(pr); err != nil {
+		return nil, err
+	}
+
+	pr.activity = append(pr.activity, map[string]any{"approval": map[string]any{"user": bitbucketBot, "date": now()}})
+	return nil, nil
+}
+
+func bitbucketPullRequestsCreateComment(w *Worker, req bitbucket.PullRequestsCreateCommentRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &bitbucket.Comment{
+		ID:        w.state.nextID(),
+		Content:   bitbucket.Rendered{Raw: req.Markdown, Markup: "markdown"},
+		User:      bitbucketBot,
+		CreatedOn: now(),
+	}
+	if req.ParentID != "" {
+		_, parent, err := w.bitbucketComment(req.PullRequestsRequest, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		c.Parent = &bitbucket.Parent{ID: parent.ID}
+	}
+
+	pr.comments[c.ID] = c
+	pr.pr["comment_count"] = len(pr.comments)
+	pr.activity = append(pr.activity, map[string]any{"comment": c})
+	return c, nil
+}
+
+func bitbucketPullRequestsDecline(w *Worker, req bitbucket.PullRequestsDeclineRequest) (any, error) {
+	pr, err := w.bitbucketPR(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := bitbucketOpen(pr); err != nil {
+		return nil, err
+	}
+
+	pr.pr["state"] = "DECLINED"
+	pr.activity = append(pr.activity, map[string]any{"update": map[string]any{"state": "DECLINED", "author": bitbucketBot}})
+	return nil, nil
+}
+
+func bitbucketPullRequestsDeleteComment(w *Worker, req bitbucket.PullRequestsDeleteCommentRequest) (any, error) {
+	pr, c, err := w.bitbucketComment(req.PullRequestsRequest, req.CommentID)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(pr.comments, c.ID)
+	pr.pr["comment_count"] = len(pr.comments)
+	return nil, nil
+}
+
+func bitbucketPullRequestsDiffstat(w *Worker, req bitbucket.PullRequestsDiffstatRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	values, next := bitbucketPage(pr.diffstat, req.Next, req.PageLen)
+	return bitbucket.PullRequestsDiffstatResponse{Values: values, Size: len(pr.diffstat), Next: next}, nil
+}
+
+func bitbucketPullRequestsGet(w *Worker, req bitbucket.PullRequestsGetRequest) (any, error) {
+	pr, err := w.bitbucketPR(req)
+	if err != nil {
+		return nil, err
+	}
+	return pr.pr, nil
+}
+
+func bitbucketPullRequestsGetComment(w *Worker, req bitbucket.PullRequestsGetCommentRequest) (any, error) {
+	_, c, err := w.bitbucketComment(req.PullRequestsRequest, req.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func bitbucketPullRequestsListActivityLog(w *Worker, req bitbucket.PullRequestsListActivityLogRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// The activity log is returned in reverse chronological order.
+	log := slices.Clone(pr.activity)
+	slices.Reverse(log)
+
+	values, next := bitbucketPage(log, req.Next, req.PageLen)
+	return bitbucket.PullRequestsListActivityLogResponse{Values: values, Next: next}, nil
+}
+
+func bitbucketPullRequestsListCommits(w *Worker, req bitbucket.PullRequestsListCommitsRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	values, next := bitbucketPage(pr.commits, req.Next, req.PageLen)
+	return bitbucket.PullRequestsListCommitsResponse{Values: values, Next: next}, nil
+}
+
+func bitbucketPullRequestsListForCommit(w *Worker, req bitbucket.PullRequestsListForCommitRequest) (any, error) {
+	prefix := bitbucketKey(req.Workspace, req.RepoSlug, "")
+	var prs []map[string]any
+	for _, key := range slices.Sorted(maps.Keys(w.state.bitbucketPRs)) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		pr := w.state.bitbucketPRs[key]
+		if slices.ContainsFunc(pr.commits, func(c bitbucket.Commit) bool { return c.Hash == req.Commit }) {
+			prs = append(prs, pr.pr)
+		}
+	}
+
+	values, next := bitbucketPage(prs, req.Next, req.PageLen)
+	return bitbucket.PullRequestsListForCommitResponse{Values: values, Next: next}, nil
+}
+
+func bitbucketPullRequestsListTasks(w *Worker, req bitbucket.PullRequestsListTasksRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	values, next := bitbucketPage(pr.tasks, req.Next, req.PageLen)
+	return bitbucket.PullRequestsListTasksResponse{Values: values, Size: len(pr.tasks), Next: next}, nil
+}
+
+func bitbucketPullRequestsMerge(w *Worker, req bitbucket.PullRequestsMergeRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := bitbucketOpen(pr); err != nil {
+		return nil, err
+	}
+
+	pr.pr["state"] = "MERGED"
+	if req.CloseSourceBranch {
+		pr.pr["close_source_branch"] = true
+	}
+	pr.activity = append(pr.activity, map[string]any{"update": map[string]any{"state": "MERGED", "author": bitbucketBot}})
+	return nil, nil
+}
+
+func bitbucketPullRequestsUnapprove(w *Worker, req bitbucket.PullRequestsUnapproveRequest) (any, error) {
+	pr, err := w.bitbucketPR(req)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(pr.activity, func(a map[string]any) bool {
+		_, ok := a["approval"]
+		return ok
+	})
+	if i < 0 {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	pr.activity = slices.Delete(pr.activity, i, i+1)
+	return nil, nil
+}
+
+func bitbucketPullRequestsUpdate(w *Worker, req bitbucket.PullRequestsUpdateRequest) (any, error) {
+	pr, err := w.bitbucketPR(req.PullRequestsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(pr.pr, req.PullRequest)
+	update := maps.Clone(req.PullRequest)
+	update["author"] = bitbucketBot
+	pr.activity = append(pr.activity, map[string]any{"update": update})
+	return pr.pr, nil
+}
+
+func bitbucketPullRequestsUpdateComment(w *Worker, req bitbucket.PullRequestsUpdateCommentRequest) (any, error) {
+	_, c, err := w.bitbucketComment(req.PullRequestsRequest, req.CommentID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Content = bitbucket.Rendered{Raw: req.Markdown, Markup: "markdown"}
+	c.UpdatedOn = now()
+	return c, nil
+}
+
+func bitbucketSourceGetFile(w *Worker, req bitbucket.SourceGetRequest) (any, error) {
+	f, ok := w.state.bitbucketFiles[req.Workspace+"/"+req.RepoSlug+"@"+req.Commit+":"+req.Path]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return f, nil
+}
+
+func bitbucketUsersGet(w *Worker, req bitbucket.UsersGetRequest) (any, error) {
+	if req.AccountID == "" && req.UUID == "" {
+		return bitbucketBot, nil
+	}
+	if u, ok := w.state.bitbucketUsers[req.AccountID]; ok {
+		return u, nil
+	}
+	for _, u := range w.state.bitbucketUsers {
+		if req.UUID != "" && u.UUID == req.UUID {
+			return u, nil
+		}
+	}
+	return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+}
+
+func bitbucketWorkspacesListMembers(w *Worker, req bitbucket.WorkspacesListMembersRequest) (any, error) {
+	members, ok := w.state.bitbucketMembers[req.Workspace]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+
+	resp := bitbucket.WorkspacesListMembersResponse{Values: []bitbucket.Membership{}}
+	for _, email := range slices.Sorted(maps.Keys(members)) {
+		if len(req.EmailsFilter) > 0 && !slices.Contains(req.EmailsFilter, email) {
+			continue
+		}
+		resp.Values = append(resp.Values, bitbucket.Membership{User: w.state.bitbucketUsers[members[email]]})
+	}
+	return resp, nil
+}
+
+// bitbucketPage returns a single page of items, and the opaque "next" value of the following
+// page (an empty string if there isn't one). The default page length is 10, as in Bitbucket.
+func bitbucketPage[T any](items []T, next, pageLen string) ([]T, string) {
+	start, _ := strconv.Atoi(next)
+	start = min(max(start, 0), len(items))
+	n, err := strconv.Atoi(pageLen)
+	if err != nil || n <= 0 {
+		n = 10
+	}
+
+	end := min(start+n, len(items))
+	page := append([]T{}, items[start:end]...)
+	if end == len(items) {
+		return page, ""
+	}
+	return page, strconv.Itoa(end)
+}
+
+// now returns a fixed timestamp, so that responses are deterministic.
+func now() time.Time {
+	return time.Date(2023, time.November, 14, 22, 13, 20, 0, time.UTC)
+}
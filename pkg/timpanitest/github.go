@@ -0,0 +1,337 @@
+package timpanitest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/github"
+)
+
+func githubHandlers() map[string]Handler {
+	return map[string]Handler{
+		github.IssuesCommentsCreateActivityName: typed(githubIssuesCommentsCreate),
+		github.IssuesCommentsDeleteActivityName: typed(githubIssuesCommentsDelete),
+		github.IssuesCommentsUpdateActivityName: typed(githubIssuesCommentsUpdate),
+
+		github.PullRequestsGetActivityName:         typed(githubPullRequestsGet),
+		github.PullRequestsListCommitsActivityName: typed(githubPullRequestsListCommits),
+		github.PullRequestsListFilesActivityName:   typed(githubPullRequestsListFiles),
+		github.PullRequestsMergeActivityName:       typed(githubPullRequestsMerge),
+		github.PullRequestsUpdateActivityName:      typed(githubPullRequestsUpdate),
+
+		github.PullRequestsCommentsCreateActivityName:      typed(githubPullRequestsCommentsCreate),
+		github.PullRequestsCommentsCreateReplyActivityName: typed(githubPullRequestsCommentsCreateReply),
+		github.PullRequestsCommentsDeleteActivityName:      typed(githubPullRequestsCommentsDelete),
+		github.PullRequestsCommentsUpdateActivityName:      typed(githubPullRequestsCommentsUpdate),
+
+		github.PullRequestsReviewsCreateActivityName:  typed(githubPullRequestsReviewsCreate),
+		github.PullRequestsReviewsDeleteActivityName:  typed(githubPullRequestsReviewsDelete),
+		github.PullRequestsReviewsDismissActivityName: typed(githubPullRequestsReviewsDismiss),
+		github.PullRequestsReviewsSubmitActivityName:  typed(githubPullRequestsReviewsSubmit),
+		github.PullRequestsReviewsUpdateActivityName:  typed(githubPullRequestsReviewsUpdate),
+
+		github.UsersGetActivityName:  typed(githubUsersGet),
+		github.UsersListActivityName: typed(githubUsersList),
+	}
+}
+
+func httpErr(errType string, statusCode int) error {
+	return fail(errType, errors.Details{StatusCode: statusCode})
+}
+
+var githubBot = github.User{ID: 1, Login: "timpani[bot]", Type: "Bot"}
+
+func (w *Worker) githubPR(owner, repo string, number int) (*githubPR, error) {
+	pr, ok := w.state.githubPRs[githubKey(owner, repo, number)]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return pr, nil
+}
+
+func (w *Worker) githubReview(req github.PullRequestsReviewsRequest) (*github.Review, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range pr.reviews {
+		if r.ID == req.ReviewID {
+			return r, nil
+		}
+	}
+	return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+}
+
+func githubIssuesCommentsCreate(w *Worker, req github.IssuesCommentsCreateRequest) (any, error) {
+	if pr, ok := w.state.githubPRs[githubKey(req.Owner, req.Repo, req.IssueNumber)]; ok {
+		pr.pr.Comments++
+	}
+	c := &github.IssueComment{ID: w.state.nextID(), Body: req.Body, User: githubBot}
+	w.state.githubIssues[c.ID] = c
+	return c, nil
+}
+
+func githubIssuesCommentsDelete(w *Worker, req github.IssuesCommentsDeleteRequest) (any, error) {
+	if _, ok := w.state.githubIssues[req.CommentID]; !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	delete(w.state.githubIssues, req.CommentID)
+	return nil, nil
+}
+
+func githubIssuesCommentsUpdate(w *Worker, req github.IssuesCommentsUpdateRequest) (any, error) {
+	c, ok := w.state.githubIssues[req.CommentID]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	c.Body = req.Body
+	return c, nil
+}
+
+func githubPullRequestsGet(w *Worker, req github.PullRequestsGetRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	return pr.pr, nil
+}
+
+func githubPullRequestsListCommits(w *Worker, req github.PullRequestsListCommitsRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	return githubPage(pr.commits, req.Page, req.PerPage), nil
+}
+
+func githubPullRequestsListFiles(w *Worker, req github.PullRequestsListFilesRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	return githubPage(pr.files, req.Page, req.PerPage), nil
+}
+
+func githubPullRequestsMerge(w *Worker, req github.PullRequestsMergeRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	if pr.pr.Merged || pr.pr.State != "open" {
+		return nil, httpErr(errors.TypeConflict, http.StatusMethodNotAllowed)
+	}
+	if req.SHA != "" && req.SHA != pr.pr.Head.SHA {
+		return nil, httpErr(errors.TypeConflict, http.StatusConflict)
+	}
+
+	pr.pr.Merged = true
+	pr.pr.State = "closed"
+	pr.pr.MergedBy = &githubBot
+	pr.pr.MergeCommitSHA = "merge-" + pr.pr.Head.SHA
+	return github.PullRequestsMergeResponse{Merged: true, Message: "Pull Request successfully merged", SHA: pr.pr.MergeCommitSHA}, nil
+}
+
+func githubPullRequestsUpdate(w *Worker, req github.PullRequestsUpdateRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != "" {
+		pr.pr.Title = req.Title
+	}
+	if req.Body != "" {
+		pr.pr.Body = req.Body
+	}
+	if req.State != "" {
+		pr.pr.State = req.State
+	}
+	if req.Base != "" {
+		pr.pr.Base.Ref = req.Base
+	}
+	return pr.pr, nil
+}
+
+func githubPullRequestsCommentsCreate(w *Worker, req github.PullRequestsCommentsCreateRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+	if req.InReplyTo != 0 {
+		if _, ok := w.state.githubComments[req.InReplyTo]; !ok {
+			return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+		}
+	}
+
+	c := github.PullComment{
+		ID: w.state.nextID(), CommitID: req.CommitID, Path: req.Path, SubjectType: req.SubjectType,
+		User: githubBot, Body: req.Body, StartLine: req.StartLine, StartSide: req.StartSide, Line: req.Line, Side: req.Side,
+	}
+	if req.InReplyTo != 0 {
+		c.InReplyTo = &req.InReplyTo
+	}
+
+	pr.pr.ReviewComments++
+	w.state.githubComments[c.ID] = &githubComment{key: githubKey(req.Owner, req.Repo, req.PullNumber), comment: c}
+	return c, nil
+}
+
+func githubPullRequestsCommentsCreateReply(w *Worker, req github.PullRequestsCommentsCreateReplyRequest) (any, error) {
+	parent, ok := w.state.githubComments[req.CommentID]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+
+	return githubPullRequestsCommentsCreate(w, github.PullRequestsCommentsCreateRequest{
+		PullRequestsRequest: req.PullRequestsRequest,
+		Body:                req.Body,
+		CommitID:            parent.comment.CommitID,
+		Path:                parent.comment.Path,
+		InReplyTo:           req.CommentID,
+	})
+}
+
+func githubPullRequestsCommentsDelete(w *Worker, req github.PullRequestsCommentsDeleteRequest) (any, error) {
+	c, ok := w.state.githubComments[req.CommentID]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	if pr, ok := w.state.githubPRs[c.key]; ok {
+		pr.pr.ReviewComments--
+	}
+	delete(w.state.githubComments, req.CommentID)
+	return nil, nil
+}
+
+func githubPullRequestsCommentsUpdate(w *Worker, req github.PullRequestsCommentsUpdateRequest) (any, error) {
+	c, ok := w.state.githubComments[req.CommentID]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	c.comment.Body = req.Body
+	return c.comment, nil
+}
+
+func githubPullRequestsReviewsCreate(w *Worker, req github.PullRequestsReviewsCreateRequest) (any, error) {
+	pr, err := w.githubPR(req.Owner, req.Repo, req.PullNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	state := "PENDING"
+	switch req.Event {
+	case "APPROVE":
+		state = "APPROVED"
+	case "REQUEST_CHANGES":
+		state = "CHANGES_REQUESTED"
+	case "COMMENT":
+		state = "COMMENTED"
+	}
+
+	commitID := req.CommitID
+	if commitID == "" {
+		commitID = pr.pr.Head.SHA
+	}
+
+	r := &github.Review{ID: w.state.nextID(), User: githubBot, State: state, Body: req.Body, CommitID: commitID}
+	pr.reviews = append(pr.reviews, r)
+	return r, nil
+}
+
+func githubPullRequestsReviewsDelete(w *Worker, req github.PullRequestsReviewsDeleteRequest) (any, error) {
+	r, err := w.githubReview(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.State != "PENDING" {
+		return nil, httpErr(errors.TypeValidationFailed, http.StatusUnprocessableEntity)
+	}
+
+	pr := w.state.githubPRs[githubKey(req.Owner, req.Repo, req.PullNumber)]
+	pr.reviews = slices.DeleteFunc(pr.reviews, func(r *github.Review) bool { return r.ID == req.ReviewID })
+	return r, nil
+}
+
+func githubPullRequestsReviewsDismiss(w *Worker, req github.PullRequestsReviewsDismissRequest) (any, error) {
+	r, err := w.githubReview(req.PullRequestsReviewsRequest)
+	if err != nil {
+		return nil, err
+	}
+	r.State = "DISMISSED"
+	return r, nil
+}
+
+func githubPullRequestsReviewsSubmit(w *Worker, req github.PullRequestsReviewsSubmitRequest) (any, error) {
+	r, err := w.githubReview(req.PullRequestsReviewsRequest)
+	if err != nil {
+		return nil, err
+	}
+	if r.State != "PENDING" {
+		return nil, httpErr(errors.TypeValidationFailed, http.StatusUnprocessableEntity)
+	}
+
+	switch req.Event {
+	case "APPROVE":
+		r.State = "APPROVED"
+	case "REQUEST_CHANGES":
+		r.State = "CHANGES_REQUESTED"
+	default:
+		r.State = "COMMENTED"
+	}
+	if req.Body != "" {
+		r.Body = req.Body
+	}
+	return r, nil
+}
+
+func githubPullRequestsReviewsUpdate(w *Worker, req github.PullRequestsReviewsUpdateRequest) (any, error) {
+	r, err := w.githubReview(req.PullRequestsReviewsRequest)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = req.Body
+	return r, nil
+}
+
+func githubUsersGet(w *Worker, req github.UsersGetRequest) (any, error) {
+	if req.AccountID == "" && req.Username == "" {
+		return githubBot, nil
+	}
+	if u, ok := w.state.githubUsers[req.Username]; ok {
+		return u, nil
+	}
+	for _, u := range w.state.githubUsers {
+		if req.AccountID != "" && req.AccountID == strconv.FormatInt(u.ID, 10) {
+			return u, nil
+		}
+	}
+	return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+}
+
+func githubUsersList(w *Worker, req github.UsersListRequest) (any, error) {
+	users := []github.User{}
+	for _, u := range w.state.githubUsers {
+		if u.ID > int64(req.Since) {
+			users = append(users, u)
+		}
+	}
+	slices.SortFunc(users, func(a, b github.User) int { return cmp.Compare(a.ID, b.ID) })
+	return githubPage(users, 1, req.PerPage), nil
+}
+
+// githubPage returns a single page of items, based on GitHub's "page"
+// and "per_page" query parameters (defaults: page = 1, per_page = 30).
+func githubPage[T any](items []T, page, perPage int) []T {
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 30
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	return append([]T{}, items[start:end]...)
+}
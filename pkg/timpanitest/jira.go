@@ -0,0 +1,40 @@
+package timpanitest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/jira"
+)
+
+func jiraHandlers() map[string]Handler {
+	return map[string]Handler{
+		jira.UsersGetActivityName:    typed(jiraUsersGet),
+		jira.UsersSearchActivityName: typed(jiraUsersSearch),
+	}
+}
+
+func jiraUsersGet(w *Worker, req jira.UsersGetRequest) (any, error) {
+	u, ok := w.state.jiraUsers[req.AccountID]
+	if !ok {
+		return nil, httpErr(errors.TypeNotFound, http.StatusNotFound)
+	}
+	return u, nil
+}
+
+// jiraUsersSearch matches the query against the display names
+// and email addresses of users, case-insensitively, like Jira.
+func jiraUsersSearch(w *Worker, req jira.UsersSearchRequest) (any, error) {
+	q := strings.ToLower(req.Query)
+	users := []jira.User{}
+	for _, id := range slices.Sorted(maps.Keys(w.state.jiraUsers)) {
+		u := w.state.jiraUsers[id]
+		if strings.Contains(strings.ToLower(u.DisplayName), q) || strings.Contains(strings.ToLower(u.Email), q) {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,793 @@
+package timpanitest
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+)
+
+func slackHandlers() map[string]Handler {
+	return map[string]Handler{
+		slack.AuthTestActivityName: typed(slackAuthTest),
+		slack.BotsInfoActivityName: typed(slackBotsInfo),
+
+		slack.BookmarksAddActivityName:    typed(slackBookmarksAdd),
+		slack.BookmarksEditActivityName:   typed(slackBookmarksEdit),
+		slack.BookmarksListActivityName:   typed(slackBookmarksList),
+		slack.BookmarksRemoveActivityName: typed(slackBookmarksRemove),
+
+		slack.ChatDeleteActivityName:                 typed(slackChatDelete),
+		slack.ChatDeleteScheduledMessageActivityName: typed(slackChatDeleteScheduledMessage),
+		slack.ChatGetPermalinkActivityName:           typed(slackChatGetPermalink),
+		slack.ChatPostEphemeralActivityName:          typed(slackChatPostEphemeral),
+		slack.ChatPostMessageActivityName:            typed(slackChatPostMessage),
+		slack.ChatScheduleMessageActivityName:        typed(slackChatScheduleMessage),
+		slack.ChatScheduledMessagesListActivityName:  typed(slackChatScheduledMessagesList),
+		slack.ChatUpdateActivityName:                 typed(slackChatUpdate),
+
+		slack.ConversationsArchiveActivityName:    typed(slackConversationsArchive),
+		slack.ConversationsCloseActivityName:      typed(slackConversationsClose),
+		slack.ConversationsCreateActivityName:     typed(slackConversationsCreate),
+		slack.ConversationsHistoryActivityName:    typed(slackConversationsHistory),
+		slack.ConversationsInfoActivityName:       typed(slackConversationsInfo),
+		slack.ConversationsInviteActivityName:     typed(slackConversationsInvite),
+		slack.ConversationsJoinActivityName:       typed(slackConversationsJoin),
+		slack.ConversationsKickActivityName:       typed(slackConversationsKick),
+		slack.ConversationsLeaveActivityName:      typed(slackConversationsLeave),
+		slack.ConversationsListActivityName:       typed(slackConversationsList),
+		slack.ConversationsMembersActivityName:    typed(slackConversationsMembers),
+		slack.ConversationsOpenActivityName:       typed(slackConversationsOpen),
+		slack.ConversationsRenameActivityName:     typed(slackConversationsRename),
+		slack.ConversationsRepliesActivityName:    typed(slackConversationsReplies),
+		slack.ConversationsSetPurposeActivityName: typed(slackConversationsSetPurpose),
+		slack.ConversationsSetTopicActivityName:   typed(slackConversationsSetTopic),
+
+		slack.FilesCompleteUploadExternalActivityName: typed(slackFilesCompleteUploadExternal),
+		slack.FilesDeleteActivityName:                 typed(slackFilesDelete),
+		slack.FilesGetUploadURLExternalActivityName:   typed(slackFilesGetUploadURLExternal),
+		slack.TimpaniUploadExternalActivityName:       typed(slackTimpaniUploadExternal),
+
+		slack.ReactionsAddActivityName:    typed(slackReactionsAdd),
+		slack.ReactionsGetActivityName:    typed(slackReactionsGet),
+		slack.ReactionsListActivityName:   typed(slackReactionsList),
+		slack.ReactionsRemoveActivityName: typed(slackReactionsRemove),
+
+		slack.UserGroupsListActivityName:      typed(slackUserGroupsList),
+		slack.UserGroupsUsersListActivityName: typed(slackUserGroupsUsersList),
+
+		slack.UsersConversationsActivityName: typed(slackUsersConversations),
+		slack.UsersGetPresenceActivityName:   typed(slackUsersGetPresence),
+		slack.UsersInfoActivityName:          typed(slackUsersInfo),
+		slack.UsersListActivityName:          typed(slackUsersList),
+		slack.UsersLookupByEmailActivityName: typed(slackUsersLookupByEmail),
+		slack.UsersProfileGetActivityName:    typed(slackUsersProfileGet),
+
+		slack.ViewsOpenActivityName:    typed(slackViewsOpen),
+		slack.ViewsPublishActivityName: typed(slackViewsPublish),
+		slack.ViewsPushActivityName:    typed(slackViewsPush),
+		slack.ViewsUpdateActivityName:  typed(slackViewsUpdate),
+	}
+}
+
+var slackOK = slack.Response{OK: true}
+
+func slackErr(errType, code string) error {
+	return fail(errType, errors.Details{Code: code})
+}
+
+// slackPage returns a single page of items, based on an opaque Slack cursor (which
+// is simply the index of the first item in the page) and a limit (default = 100).
+func slackPage[T any](items []T, cursor string, limit int) ([]T, *slack.ResponseMetadata) {
+	start, _ := strconv.Atoi(cursor)
+	start = min(max(start, 0), len(items))
+	if limit <= 0 {
+		limit = 100
+	}
+
+	end := min(start+limit, len(items))
+	if end == len(items) {
+		return items[start:end], nil
+	}
+	return items[start:end], &slack.ResponseMetadata{NextCursor: strconv.Itoa(end)}
+}
+
+func slackAuthTest(_ *Worker, _ any) (any, error) {
+	return slack.AuthTestResponse{Response: slackOK, TeamID: TeamID, UserID: BotUserID, BotID: BotID}, nil
+}
+
+func slackBotsInfo(_ *Worker, req slack.BotsInfoRequest) (any, error) {
+	if req.Bot != BotID {
+		return nil, slackErr(errors.TypeNotFound, "bot_not_found")
+	}
+	bot := &slack.Bot{ID: BotID, TeamID: TeamID, Name: "timpani", UserID: BotUserID}
+	return slack.BotsInfoResponse{Response: slackOK, Bot: bot}, nil
+}
+
+func slackBookmarksAdd(w *Worker, req slack.BookmarksAddRequest) (any, error) {
+	b := slack.Bookmark{ID: "Bk" + strconv.Itoa(w.state.nextID()), ChannelID: req.ChannelID, Title: req.Title, Type: req.Type}
+	if req.Link != "" {
+		b.Link = &req.Link
+	}
+	if req.Emoji != "" {
+		b.Emoji = &req.Emoji
+	}
+	w.state.bookmarks[req.ChannelID] = append(w.state.bookmarks[req.ChannelID], b)
+	return slack.BookmarksAddResponse{Response: slackOK, Bookmark: &b}, nil
+}
+
+func slackBookmarksEdit(w *Worker, req slack.BookmarksEditRequest) (any, error) {
+	for i, b := range w.state.bookmarks[req.ChannelID] {
+		if b.ID != req.BookmarkID {
+			continue
+		}
+		if req.Title != "" {
+			b.Title = req.Title
+		}
+		if req.Link != "" {
+			b.Link = &req.Link
+		}
+		if req.Emoji != "" {
+			b.Emoji = &req.Emoji
+		}
+		w.state.bookmarks[req.ChannelID][i] = b
+		return slack.BookmarksEditResponse{Response: slackOK, Bookmark: &b}, nil
+	}
+	return nil, slackErr(errors.TypeNotFound, "bookmark_not_found")
+}
+
+func slackBookmarksList(w *Worker, req slack.BookmarksListRequest) (any, error) {
+	return slack.BookmarksListResponse{Response: slackOK, Bookmarks: w.state.bookmarks[req.ChannelID]}, nil
+}
+
+func slackBookmarksRemove(w *Worker, req slack.BookmarksRemoveRequest) (any, error) {
+	bs := w.state.bookmarks[req.ChannelID]
+	i := slices.IndexFunc(bs, func(b slack.Bookmark) bool { return b.ID == req.BookmarkID })
+	if i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "bookmark_not_found")
+	}
+	w.state.bookmarks[req.ChannelID] = slices.Delete(bs, i, i+1)
+	return slackOK, nil
+}
+
+func slackChatDelete(w *Worker, req slack.ChatDeleteRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	i, _ := c.message(req.TS)
+	if i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+	c.messages = slices.Delete(c.messages, i, i+1)
+	id, _ := c.info["id"].(string)
+	return slack.ChatDeleteResponse{Response: slackOK, Channel: id, TS: req.TS}, nil
+}
+
+func slackChatDeleteScheduledMessage(w *Worker, req slack.ChatDeleteScheduledMessageRequest) (any, error) {
+	id, _ := w.state.channel(req.Channel).info["id"].(string)
+	i := slices.IndexFunc(w.state.scheduled, func(m slack.ScheduledMessage) bool {
+		return m.ID == req.ScheduledMessageID && m.ChannelID == id
+	})
+	if i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "invalid_scheduled_message_id")
+	}
+	w.state.scheduled = slices.Delete(w.state.scheduled, i, i+1)
+	return slack.ChatDeleteScheduledMessageResponse(slackOK), nil
+}
+
+func slackChatGetPermalink(w *Worker, req slack.ChatGetPermalinkRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	if i, _ := c.message(req.MessageTS); i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+	link := "https://fake.slack.com/archives/" + req.Channel + "/p" + strings.ReplaceAll(req.MessageTS, ".", "")
+	return slack.ChatGetPermalinkResponse{Response: slackOK, Channel: req.Channel, Permalink: link}, nil
+}
+
+func slackChatPostEphemeral(w *Worker, req slack.ChatPostEphemeralRequest) (any, error) {
+	if req.Text == "" && req.MarkdownText == "" && len(req.Blocks) == 0 && len(req.Attachments) == 0 {
+		return nil, slackErr(errors.TypeValidationFailed, "no_text")
+	}
+	return slack.ChatPostEphemeralResponse{Response: slackOK, MessageTS: w.state.nextTS()}, nil
+}
+
+func slackChatPostMessage(w *Worker, req slack.ChatPostMessageRequest) (any, error) {
+	if req.Text == "" && req.MarkdownText == "" && len(req.Blocks) == 0 && len(req.Attachments) == 0 {
+		return nil, slackErr(errors.TypeValidationFailed, "no_text")
+	}
+
+	c := w.state.channel(req.Channel)
+	if archived, _ := c.info["is_archived"].(bool); archived {
+		return nil, slackErr(errors.TypeConflict, "is_archived")
+	}
+
+	text := req.Text
+	if text == "" {
+		text = req.MarkdownText
+	}
+
+	ts := w.state.nextTS()
+	msg := map[string]any{"type": "message", "ts": ts, "text": text, "user": BotUserID, "bot_id": BotID, "team": TeamID}
+	if len(req.Blocks) > 0 {
+		msg["blocks"] = req.Blocks
+	}
+	if len(req.Attachments) > 0 {
+		msg["attachments"] = req.Attachments
+	}
+	if req.Metadata != nil {
+		msg["metadata"] = req.Metadata
+	}
+
+	if req.ThreadTS != "" {
+		_, parent := c.message(req.ThreadTS)
+		if parent == nil {
+			return nil, slackErr(errors.TypeNotFound, "thread_not_found")
+		}
+		msg["thread_ts"] = req.ThreadTS
+		parent["thread_ts"] = req.ThreadTS
+		parent["reply_count"] = toInt(parent["reply_count"]) + 1
+		parent["latest_reply"] = ts
+		users, _ := parent["reply_users"].([]string)
+		if !slices.Contains(users, BotUserID) {
+			parent["reply_users"] = append(users, BotUserID)
+		}
+	}
+
+	c.messages = append(c.messages, msg)
+	id, _ := c.info["id"].(string)
+	return slack.ChatPostMessageResponse{Response: slackOK, Channel: id, TS: ts, Message: as[*slack.Message](msg)}, nil
+}
+
+func slackChatScheduleMessage(w *Worker, req slack.ChatScheduleMessageRequest) (any, error) {
+	if req.Text == "" && req.MarkdownText == "" && len(req.Blocks) == 0 && len(req.Attachments) == 0 {
+		return nil, slackErr(errors.TypeValidationFailed, "no_text")
+	}
+
+	id, _ := w.state.channel(req.Channel).info["id"].(string)
+	text := cmp.Or(req.Text, req.MarkdownText)
+	m := slack.ScheduledMessage{ID: "Q" + strconv.Itoa(w.state.nextID()), ChannelID: id, PostAt: req.PostAt, Text: text}
+	w.state.scheduled = append(w.state.scheduled, m)
+
+	msg := &slack.Message{Type: "delayed_message", Text: text, User: BotUserID, BotID: BotID, Team: TeamID, Blocks: req.Blocks}
+	return slack.ChatScheduleMessageResponse{Response: slackOK, Channel: id, ScheduledMessageID: m.ID, PostAt: m.PostAt, Message: msg}, nil
+}
+
+func slackChatScheduledMessagesList(w *Worker, req slack.ChatScheduledMessagesListRequest) (any, error) {
+	var id string
+	if req.Channel != "" {
+		id, _ = w.state.channel(req.Channel).info["id"].(string)
+	}
+
+	var ms []slack.ScheduledMessage
+	for _, m := range w.state.scheduled {
+		if id != "" && m.ChannelID != id {
+			continue
+		}
+		if inRange(strconv.FormatInt(m.PostAt, 10), req.Oldest, req.Latest, true) {
+			ms = append(ms, m)
+		}
+	}
+
+	page, meta := slackPage(ms, req.Cursor, req.Limit)
+	return slack.ChatScheduledMessagesListResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, ScheduledMessages: page}, nil
+}
+
+func slackChatUpdate(w *Worker, req slack.ChatUpdateRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	_, msg := c.message(req.TS)
+	if msg == nil {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+
+	if req.Text != "" {
+		msg["text"] = req.Text
+	} else if req.MarkdownText != "" {
+		msg["text"] = req.MarkdownText
+	}
+	if req.Blocks != nil {
+		msg["blocks"] = req.Blocks
+	}
+	if req.Attachments != nil {
+		msg["attachments"] = req.Attachments
+	}
+	if req.Metadata != nil {
+		msg["metadata"] = req.Metadata
+	}
+	msg["edited"] = map[string]any{"user": BotUserID, "ts": w.state.nextTS()}
+
+	text, _ := msg["text"].(string)
+	return slack.ChatUpdateResponse{Response: slackOK, Channel: req.Channel, TS: req.TS, Text: text, Message: as[*slack.Message](msg)}, nil
+}
+
+func slackConversationsArchive(w *Worker, req slack.ConversationsArchiveRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	if archived, _ := c.info["is_archived"].(bool); archived {
+		return nil, slackErr(errors.TypeConflict, "already_archived")
+	}
+	c.info["is_archived"] = true
+	return slackOK, nil
+}
+
+func slackConversationsClose(w *Worker, req slack.ConversationsCloseRequest) (any, error) {
+	w.state.channel(req.Channel).info["is_open"] = false
+	return slack.ConversationsCloseResponse{Response: slackOK}, nil
+}
+
+func slackConversationsCreate(w *Worker, req slack.ConversationsCreateRequest) (any, error) {
+	for _, c := range w.state.channels {
+		if c.info["name"] == req.Name {
+			return nil, slackErr(errors.TypeConflict, "name_taken")
+		}
+	}
+
+	c := w.state.channel("C" + strconv.Itoa(w.state.nextID()))
+	c.info["name"] = req.Name
+	c.info["is_private"] = req.IsPrivate
+	c.members = []string{BotUserID}
+	return slack.ConversationsCreateResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackConversationsHistory(w *Worker, req slack.ConversationsHistoryRequest) (any, error) {
+	var msgs []map[string]any
+	c := w.state.channel(req.Channel)
+	for _, m := range slices.Backward(c.messages) {
+		if ts, ok := m["thread_ts"]; ok && ts != m["ts"] {
+			continue // Thread replies are not included in the channel's history.
+		}
+		if inRange(m["ts"], req.Oldest, req.Latest, req.Inclusive) {
+			msgs = append(msgs, maps.Clone(m))
+		}
+	}
+
+	page, meta := slackPage(msgs, req.Cursor, req.Limit)
+	return slack.ConversationsHistoryResponse{
+		Response: slack.Response{OK: true, ResponseMetadata: meta},
+		Messages: as[[]slack.Message](page),
+		HasMore:  meta != nil,
+	}, nil
+}
+
+func slackConversationsInfo(w *Worker, req slack.ConversationsInfoRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	info := maps.Clone(c.info)
+	if req.IncludeNumMembers {
+		info["num_members"] = len(c.members)
+	}
+	return slack.ConversationsInfoResponse{Response: slackOK, Channel: as[*slack.Conversation](info)}, nil
+}
+
+func slackConversationsInvite(w *Worker, req slack.ConversationsInviteRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	for u := range strings.SplitSeq(req.Users, ",") {
+		if slices.Contains(c.members, u) {
+			if !req.Force {
+				return nil, slackErr(errors.TypeConflict, "already_in_channel")
+			}
+			continue
+		}
+		c.members = append(c.members, u)
+	}
+	return slack.ConversationsInviteResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackConversationsJoin(w *Worker, req slack.ConversationsJoinRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	if !slices.Contains(c.members, BotUserID) {
+		c.members = append(c.members, BotUserID)
+	}
+	return slack.ConversationsJoinResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackConversationsKick(w *Worker, req slack.ConversationsKickRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	i := slices.Index(c.members, req.User)
+	if i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "not_in_channel")
+	}
+	c.members = slices.Delete(c.members, i, i+1)
+	return slackOK, nil
+}
+
+func slackConversationsLeave(w *Worker, req slack.ConversationsLeaveRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	i := slices.Index(c.members, BotUserID)
+	if i < 0 {
+		return slack.ConversationsLeaveResponse{Response: slackOK, NotInChannel: true}, nil
+	}
+	c.members = slices.Delete(c.members, i, i+1)
+	return slack.ConversationsLeaveResponse{Response: slackOK}, nil
+}
+
+func slackConversationsList(w *Worker, req slack.ConversationsListRequest) (any, error) {
+	var chs []map[string]any
+	for _, id := range slices.Sorted(maps.Keys(w.state.channels)) {
+		c := w.state.channels[id]
+		if archived, _ := c.info["is_archived"].(bool); archived && req.ExcludeArchived {
+			continue
+		}
+		chs = append(chs, maps.Clone(c.info))
+	}
+
+	page, meta := slackPage(chs, req.Cursor, req.Limit)
+	return slack.ConversationsListResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, Channels: as[[]slack.Conversation](page)}, nil
+}
+
+func slackConversationsMembers(w *Worker, req slack.ConversationsMembersRequest) (any, error) {
+	page, meta := slackPage(w.state.channel(req.Channel).members, req.Cursor, req.Limit)
+	return slack.ConversationsMembersResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, Members: page}, nil
+}
+
+func slackConversationsOpen(w *Worker, req slack.ConversationsOpenRequest) (any, error) {
+	id := req.Channel
+	if id == "" {
+		users := strings.Split(req.Users, ",")
+		if len(users) > 1 {
+			slices.Sort(users)
+			id = "G" + strings.Join(users, "")
+		} else {
+			id = users[0]
+		}
+	}
+
+	c := w.state.channel(id)
+	c.info["is_open"] = true
+	return slack.ConversationsOpenResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackConversationsRename(w *Worker, req slack.ConversationsRenameRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	c.info["name"] = req.Name
+	return slack.ConversationsRenameResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackConversationsReplies(w *Worker, req slack.ConversationsRepliesRequest) (any, error) {
+	var msgs []map[string]any
+	for _, m := range w.state.channel(req.Channel).messages {
+		if m["ts"] != req.TS && m["thread_ts"] != req.TS {
+			continue
+		}
+		// The thread's parent message is always included.
+		if m["ts"] == req.TS || inRange(m["ts"], req.Oldest, req.Latest, req.Inclusive) {
+			msgs = append(msgs, maps.Clone(m))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil, slackErr(errors.TypeNotFound, "thread_not_found")
+	}
+
+	page, meta := slackPage(msgs, req.Cursor, req.Limit)
+	return slack.ConversationsRepliesResponse{
+		Response: slack.Response{OK: true, ResponseMetadata: meta},
+		Messages: as[[]slack.Message](page),
+		HasMore:  meta != nil,
+	}, nil
+}
+
+func slackConversationsSetPurpose(w *Worker, req slack.ConversationsSetPurposeRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	c.info["purpose"] = map[string]any{"value": req.Purpose, "creator": BotUserID}
+	return slack.ConversationsSetPurposeResponse{Response: slackOK, Channel: maps.Clone(c.info)}, nil
+}
+
+func slackConversationsSetTopic(w *Worker, req slack.ConversationsSetTopicRequest) (any, error) {
+	c := w.state.channel(req.Channel)
+	c.info["topic"] = map[string]any{"value": req.Topic, "creator": BotUserID}
+	return slack.ConversationsSetTopicResponse{Response: slackOK, Channel: as[*slack.Conversation](c.info)}, nil
+}
+
+func slackFilesCompleteUploadExternal(w *Worker, req slack.FilesCompleteUploadExternalRequest) (any, error) {
+	files := make([]slack.File, 0, len(req.Files))
+	for _, f := range req.Files {
+		stored, ok := w.state.files[f.ID]
+		if !ok {
+			return nil, slackErr(errors.TypeNotFound, "file_not_found")
+		}
+		if f.Title != "" {
+			stored.Title = f.Title
+		}
+		if req.ChannelID != "" {
+			stored.Channels = append(stored.Channels, req.ChannelID)
+		}
+		w.state.files[f.ID] = stored
+		files = append(files, stored)
+	}
+	return slack.FilesCompleteUploadExternalResponse{Response: slackOK, Files: files}, nil
+}
+
+func slackFilesDelete(w *Worker, req slack.FilesDeleteRequest) (any, error) {
+	if _, ok := w.state.files[req.File]; !ok {
+		return nil, slackErr(errors.TypeNotFound, "file_not_found")
+	}
+	delete(w.state.files, req.File)
+	return slackOK, nil
+}
+
+func slackFilesGetUploadURLExternal(w *Worker, req slack.FilesGetUploadURLExternalRequest) (any, error) {
+	id := "F" + strconv.Itoa(w.state.nextID())
+	w.state.files[id] = slack.File{ID: id, Name: req.Filename, Title: req.Filename, Size: req.Length, User: BotUserID}
+	url := "https://files.fake.slack.com/upload/" + id
+	return slack.FilesGetUploadURLExternalResponse{Response: slackOK, UploadURL: url, FileID: id}, nil
+}
+
+func slackTimpaniUploadExternal(_ *Worker, _ slack.TimpaniUploadExternalRequest) (any, error) {
+	return nil, nil
+}
+
+func slackReactionsAdd(w *Worker, req slack.ReactionsAddRequest) (any, error) {
+	_, msg := w.state.channel(req.Channel).message(req.Timestamp)
+	if msg == nil {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+
+	reactions, _ := msg["reactions"].([]map[string]any)
+	for _, r := range reactions {
+		if r["name"] != req.Name {
+			continue
+		}
+		users, _ := r["users"].([]string)
+		if slices.Contains(users, BotUserID) {
+			return nil, slackErr(errors.TypeConflict, "already_reacted")
+		}
+		r["users"] = append(users, BotUserID)
+		r["count"] = toInt(r["count"]) + 1
+		return slackOK, nil
+	}
+
+	msg["reactions"] = append(reactions, map[string]any{"name": req.Name, "users": []string{BotUserID}, "count": 1})
+	return slackOK, nil
+}
+
+func slackReactionsGet(w *Worker, req slack.ReactionsGetRequest) (any, error) {
+	_, msg := w.state.channel(req.Channel).message(req.Timestamp)
+	if msg == nil {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+	return slack.ReactionsGetResponse{Response: slackOK, Type: "message", Channel: req.Channel, Message: maps.Clone(msg)}, nil
+}
+
+func slackReactionsList(w *Worker, req slack.ReactionsListRequest) (any, error) {
+	user := req.User
+	if user == "" {
+		user = BotUserID
+	}
+
+	var items []map[string]any
+	for _, id := range slices.Sorted(maps.Keys(w.state.channels)) {
+		for _, m := range w.state.channels[id].messages {
+			reactions, _ := m["reactions"].([]map[string]any)
+			if slices.ContainsFunc(reactions, func(r map[string]any) bool {
+				users, _ := r["users"].([]string)
+				return slices.Contains(users, user)
+			}) {
+				items = append(items, map[string]any{"type": "message", "channel": id, "message": maps.Clone(m)})
+			}
+		}
+	}
+
+	page, meta := slackPage(items, req.Cursor, req.Limit)
+	return slack.ReactionsListResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, Items: page}, nil
+}
+
+func slackReactionsRemove(w *Worker, req slack.ReactionsRemoveRequest) (any, error) {
+	_, msg := w.state.channel(req.Channel).message(req.Timestamp)
+	if msg == nil {
+		return nil, slackErr(errors.TypeNotFound, "message_not_found")
+	}
+
+	reactions, _ := msg["reactions"].([]map[string]any)
+	for i, r := range reactions {
+		users, _ := r["users"].([]string)
+		j := slices.Index(users, BotUserID)
+		if r["name"] != req.Name || j < 0 {
+			continue
+		}
+		r["users"] = slices.Delete(users, j, j+1)
+		r["count"] = toInt(r["count"]) - 1
+		if toInt(r["count"]) == 0 {
+			msg["reactions"] = slices.Delete(reactions, i, i+1)
+		}
+		return slackOK, nil
+	}
+
+	return nil, slackErr(errors.TypeNotFound, "no_reaction")
+}
+
+func slackUserGroupsList(w *Worker, req slack.UserGroupsListRequest) (any, error) {
+	var groups []slack.UserGroup
+	for _, id := range slices.Sorted(maps.Keys(w.state.userGroups)) {
+		g := w.state.userGroups[id]
+		if g.DateDelete > 0 && !req.IncludeDisabled {
+			continue
+		}
+		if req.IncludeCount {
+			g.UserCount = len(g.Users)
+		}
+		if !req.IncludeUsers {
+			g.Users = nil
+		}
+		groups = append(groups, g)
+	}
+	return slack.UserGroupsListResponse{Response: slackOK, Usergroups: groups}, nil
+}
+
+func slackUserGroupsUsersList(w *Worker, req slack.UserGroupsUsersListRequest) (any, error) {
+	g, ok := w.state.userGroups[req.Usergroup]
+	if !ok {
+		return nil, slackErr(errors.TypeNotFound, "no_such_subteam")
+	}
+	return slack.UserGroupsUsersListResponse{Response: slackOK, Users: g.Users}, nil
+}
+
+func slackUsersConversations(w *Worker, req slack.UsersConversationsRequest) (any, error) {
+	user := req.User
+	if user == "" {
+		user = BotUserID
+	}
+
+	var chs []map[string]any
+	for _, id := range slices.Sorted(maps.Keys(w.state.channels)) {
+		if c := w.state.channels[id]; slices.Contains(c.members, user) {
+			chs = append(chs, maps.Clone(c.info))
+		}
+	}
+
+	page, meta := slackPage(chs, req.Cursor, req.Limit)
+	return slack.UsersConversationsResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, Channels: as[[]slack.Conversation](page)}, nil
+}
+
+func slackUsersGetPresence(w *Worker, req slack.UsersGetPresenceRequest) (any, error) {
+	if _, ok := w.state.users[req.User]; !ok && req.User != "" {
+		return nil, slackErr(errors.TypeNotFound, "user_not_found")
+	}
+	return slack.UsersGetPresenceResponse{Response: slackOK, Presence: "active"}, nil
+}
+
+func slackUsersInfo(w *Worker, req slack.UsersInfoRequest) (any, error) {
+	u, ok := w.state.users[req.User]
+	if !ok {
+		return nil, slackErr(errors.TypeNotFound, "user_not_found")
+	}
+	return slack.UsersInfoResponse{Response: slackOK, User: &u}, nil
+}
+
+func slackUsersList(w *Worker, req slack.UsersListRequest) (any, error) {
+	var users []map[string]any
+	for _, id := range slices.Sorted(maps.Keys(w.state.users)) {
+		u := w.state.users[id]
+		users = append(users, map[string]any{
+			"id": u.ID, "team_id": u.TeamID, "real_name": u.RealName, "is_bot": u.IsBot,
+			"tz": u.TZ, "tz_label": u.TZLabel, "tz_offset": u.TZOffset, "profile": u.Profile,
+		})
+	}
+
+	page, meta := slackPage(users, req.Cursor, req.Limit)
+	return slack.UsersListResponse{Response: slack.Response{OK: true, ResponseMetadata: meta}, Members: page}, nil
+}
+
+func slackUsersLookupByEmail(w *Worker, req slack.UsersLookupByEmailRequest) (any, error) {
+	for _, id := range slices.Sorted(maps.Keys(w.state.users)) {
+		if u := w.state.users[id]; strings.EqualFold(u.Profile.Email, req.Email) {
+			return slack.UsersLookupByEmailResponse{Response: slackOK, User: &u}, nil
+		}
+	}
+	return nil, slackErr(errors.TypeNotFound, "users_not_found")
+}
+
+func slackUsersProfileGet(w *Worker, req slack.UsersProfileGetRequest) (any, error) {
+	u, ok := w.state.users[req.User]
+	if !ok {
+		return nil, slackErr(errors.TypeNotFound, "user_not_found")
+	}
+	return slack.UsersProfileGetResponse{Response: slackOK, Profile: &u.Profile}, nil
+}
+
+func slackViewsOpen(w *Worker, req slack.ViewsOpenRequest) (any, error) {
+	return slack.ViewsOpenResponse{Response: slackOK, View: w.state.newView(req.View, "")}, nil
+}
+
+func slackViewsPublish(w *Worker, req slack.ViewsPublishRequest) (any, error) {
+	if id, ok := w.state.homeViews[req.UserID]; ok {
+		return slackViewsUpdate(w, slack.ViewsUpdateRequest{View: req.View, ViewID: id, Hash: req.Hash})
+	}
+
+	v := w.state.newView(req.View, "")
+	w.state.homeViews[req.UserID] = v.ID
+	return slack.ViewsPublishResponse{Response: slackOK, View: v}, nil
+}
+
+func slackViewsPush(w *Worker, req slack.ViewsPushRequest) (any, error) {
+	var root string
+	for _, v := range slices.Backward(w.state.views) {
+		if v.Type == "modal" {
+			root = v.RootViewID
+			break
+		}
+	}
+	return slack.ViewsPushResponse{Response: slackOK, View: w.state.newView(req.View, root)}, nil
+}
+
+func slackViewsUpdate(w *Worker, req slack.ViewsUpdateRequest) (any, error) {
+	i := slices.IndexFunc(w.state.views, func(v *events.View) bool {
+		return (req.ViewID != "" && v.ID == req.ViewID) || (req.ExternalID != "" && v.ExternalID == req.ExternalID)
+	})
+	if i < 0 {
+		return nil, slackErr(errors.TypeNotFound, "not_found")
+	}
+
+	old := w.state.views[i]
+	if req.Hash != "" && req.Hash != old.Hash {
+		return nil, slackErr(errors.TypeConflict, "hash_conflict")
+	}
+
+	v := as[events.View](req.View)
+	v.ID, v.TeamID, v.AppID, v.BotID = old.ID, old.TeamID, old.AppID, old.BotID
+	v.RootViewID, v.PreviousViewID = old.RootViewID, old.PreviousViewID
+	v.Hash = w.state.nextTS()
+	w.state.views[i] = &v
+
+	return slack.ViewsUpdateResponse{Response: slackOK, View: &v}, nil
+}
+
+// newView adds a view to the fake's state, and returns it. The root view ID
+// is empty for new modals and App Home tabs, or the ID of pushed modals' root.
+func (s *state) newView(req slack.View, root string) *events.View {
+	v := as[events.View](req)
+	v.ID = "V" + strconv.Itoa(s.nextID())
+	v.TeamID, v.AppID, v.BotID = TeamID, "A0TIMPANI", BotID
+	v.Hash = s.nextTS()
+	v.RootViewID = cmp.Or(root, v.ID)
+
+	s.views = append(s.views, &v)
+	return &v
+}
+
+// inRange checks whether a Slack timestamp is within an optional time range.
+func inRange(ts any, oldest, latest string, inclusive bool) bool {
+	t, _ := ts.(string)
+	if oldest != "" {
+		if c := compareTS(t, oldest); c < 0 || (c == 0 && !inclusive) {
+			return false
+		}
+	}
+	if latest != "" {
+		if c := compareTS(t, latest); c > 0 || (c == 0 && !inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareTS compares two Slack timestamps ("seconds.microseconds") numerically, without
+// parsing them as floating point numbers, which would lose some of their precision.
+func compareTS(a, b string) int {
+	as, am, _ := strings.Cut(a, ".")
+	bs, bm, _ := strings.Cut(b, ".")
+	if c := cmp.Compare(toInt64(as), toInt64(bs)); c != 0 {
+		return c
+	}
+	return cmp.Compare(toInt64(am), toInt64(bm))
+}
+
+func toInt64(s string) int64 {
+	i, _ := strconv.ParseInt(s, 10, 64)
+	return i
+}
+
+// as converts a JSON-like value in the fake's state (e.g. a Slack channel or
+// message) into a typed API object, by encoding and decoding it as JSON.
+func as[T any](v any) T {
+	var t T
+	if b, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(b, &t)
+	}
+	return t
+}
+
+func toInt(v any) int {
+	i, _ := v.(int)
+	return i
+}
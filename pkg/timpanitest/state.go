@@ -0,0 +1,304 @@
+package timpanitest
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/tzrikka/timpani-api/pkg/bitbucket"
+	"github.com/tzrikka/timpani-api/pkg/github"
+	"github.com/tzrikka/timpani-api/pkg/jira"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/slack/events"
+)
+
+const (
+	// BotUserID is the Slack user ID of the fake Timpani worker's bot.
+	BotUserID = "U0TIMPANI"
+	// BotID is the Slack bot ID of the fake Timpani worker's bot.
+	BotID = "B0TIMPANI"
+	// TeamID is the Slack workspace ID of the fake Timpani worker.
+	TeamID = "T0TIMPANI"
+)
+
+type state struct {
+	seq int // For deterministic IDs and timestamps.
+
+	channels   map[string]*channel
+	users      map[string]slack.User
+	userGroups map[string]slack.UserGroup
+	bookmarks  map[string][]slack.Bookmark
+	files      map[string]slack.File
+	scheduled  []slack.ScheduledMessage
+	views      []*events.View    // Creation order.
+	homeViews  map[string]string // User ID -> view ID.
+
+	githubUsers    map[string]github.User // Login -> user.
+	githubPRs      map[string]*githubPR   // "owner/repo#number" -> PR.
+	githubComments map[int]*githubComment // Comment ID -> comment.
+	githubIssues   map[int]*github.IssueComment
+
+	bitbucketUsers   map[string]bitbucket.User    // Account ID -> user.
+	bitbucketMembers map[string]map[string]string // Workspace -> email -> account ID.
+	bitbucketPRs     map[string]*bitbucketPR      // "workspace/repo#id" -> PR.
+	bitbucketFiles   map[string]string            // "workspace/repo@commit:path" -> contents.
+	bitbucketDiffs   map[string]*bitbucketDiff    // "workspace/repo@spec" -> diff.
+
+	jiraUsers map[string]jira.User // Account ID -> user.
+}
+
+type channel struct {
+	info     map[string]any
+	members  []string
+	messages []map[string]any // Chronological order, including thread replies.
+}
+
+type githubPR struct {
+	pr      github.PullRequest
+	commits []github.Commit
+	files   []github.File
+	reviews []*github.Review
+}
+
+type githubComment struct {
+	key     string // Of the PR.
+	comment github.PullComment
+}
+
+type bitbucketDiff struct {
+	diff     string
+	diffstat []bitbucket.Diffstat
+}
+
+type bitbucketPR struct {
+	pr       map[string]any
+	comments map[int]*bitbucket.Comment
+	commits  []bitbucket.Commit
+	tasks    []bitbucket.Task
+	diffstat []bitbucket.Diffstat
+	activity []map[string]any
+}
+
+func newState() *state {
+	return &state{
+		channels:   map[string]*channel{},
+		users:      map[string]slack.User{},
+		userGroups: map[string]slack.UserGroup{},
+		bookmarks:  map[string][]slack.Bookmark{},
+		files:      map[string]slack.File{},
+		homeViews:  map[string]string{},
+
+		githubUsers:    map[string]github.User{},
+		githubPRs:      map[string]*githubPR{},
+		githubComments: map[int]*githubComment{},
+		githubIssues:   map[int]*github.IssueComment{},
+
+		bitbucketUsers:   map[string]bitbucket.User{},
+		bitbucketMembers: map[string]map[string]string{},
+		bitbucketPRs:     map[string]*bitbucketPR{},
+		bitbucketFiles:   map[string]string{},
+		bitbucketDiffs:   map[string]*bitbucketDiff{},
+
+		jiraUsers: map[string]jira.User{},
+	}
+}
+
+// nextID returns a new unique numeric ID.
+func (s *state) nextID() int {
+	s.seq++
+	return s.seq
+}
+
+// nextTS returns a new unique and monotonically increasing Slack timestamp.
+func (s *state) nextTS() string {
+	return fmt.Sprintf("1700000000.%06d", s.nextID())
+}
+
+// channel returns the channel with the given ID, and creates it implicitly if it doesn't
+// exist yet. IDs of users are converted into IDs of direct-message channels with them.
+func (s *state) channel(id string) *channel {
+	if strings.HasPrefix(id, "U") || strings.HasPrefix(id, "W") {
+		id = "D" + id[1:]
+	}
+	if c, ok := s.channels[id]; ok {
+		return c
+	}
+
+	c := &channel{info: map[string]any{"id": id, "name": strings.ToLower(id), "created": s.seq}}
+	c.info["is_im"] = strings.HasPrefix(id, "D")
+	s.channels[id] = c
+	return c
+}
+
+func (c *channel) message(ts string) (int, map[string]any) {
+	for i, m := range c.messages {
+		if m["ts"] == ts {
+			return i, m
+		}
+	}
+	return -1, nil
+}
+
+func githubKey(owner, repo string, number int) string {
+	return owner + "/" + repo + "#" + strconv.Itoa(number)
+}
+
+func bitbucketKey(workspace, repo, id string) string {
+	return workspace + "/" + repo + "#" + id
+}
+
+// AddChannel adds a Slack channel to the fake's state. Channels are also created
+// implicitly when they are referenced for the first time, but this function allows
+// setting their name and initial members (e.g. for "slack.conversations.list").
+func (w *Worker) AddChannel(id, name string, members ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	c := w.state.channel(id)
+	c.info["name"] = name
+	c.members = append(c.members, members...)
+}
+
+// AddUser adds a Slack user to the fake's state.
+func (w *Worker) AddUser(u slack.User) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.users[u.ID] = u
+}
+
+// AddUserGroup adds a Slack user group to the fake's state.
+func (w *Worker) AddUserGroup(g slack.UserGroup) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.userGroups[g.ID] = g
+}
+
+// AddGitHubUser adds a GitHub user to the fake's state.
+func (w *Worker) AddGitHubUser(u github.User) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.githubUsers[u.Login] = u
+}
+
+// AddGitHubPullRequest adds a GitHub PR, and optionally its commits
+// and changed files, to the fake's state. The PR's number must be set.
+func (w *Worker) AddGitHubPullRequest(owner, repo string, pr github.PullRequest, commits []github.Commit, files []github.File) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if pr.State == "" {
+		pr.State = "open"
+	}
+	w.state.githubPRs[githubKey(owner, repo, pr.Number)] = &githubPR{pr: pr, commits: commits, files: files}
+}
+
+// AddBitbucketUser adds a Bitbucket user to the fake's state. If a
+// workspace and email address are specified, the user is also added
+// as a member of that workspace (e.g. for [bitbucket.WorkspacesListMembers]).
+func (w *Worker) AddBitbucketUser(u bitbucket.User, workspace, email string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.state.bitbucketUsers[u.AccountID] = u
+	if workspace == "" || email == "" {
+		return
+	}
+	if w.state.bitbucketMembers[workspace] == nil {
+		w.state.bitbucketMembers[workspace] = map[string]string{}
+	}
+	w.state.bitbucketMembers[workspace][email] = u.AccountID
+}
+
+// AddBitbucketPullRequest adds a Bitbucket PR to the fake's state. The PR's
+// "id" key must be set, and the PR may also contain any other key, e.g. "state".
+func (w *Worker) AddBitbucketPullRequest(workspace, repo string, pr map[string]any, commits []bitbucket.Commit, tasks []bitbucket.Task) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := pr["state"]; !ok {
+		pr["state"] = "OPEN"
+	}
+	key := bitbucketKey(workspace, repo, fmt.Sprint(pr["id"]))
+	w.state.bitbucketPRs[key] = &bitbucketPR{pr: pr, comments: map[int]*bitbucket.Comment{}, commits: commits, tasks: tasks}
+}
+
+// SetBitbucketPullRequestDiffstat sets the diffstat of a Bitbucket PR which was
+// already added to the fake's state with [Worker.AddBitbucketPullRequest].
+func (w *Worker) SetBitbucketPullRequestDiffstat(workspace, repo, id string, ds []bitbucket.Diffstat) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if pr, ok := w.state.bitbucketPRs[bitbucketKey(workspace, repo, id)]; ok {
+		pr.diffstat = ds
+	}
+}
+
+// AddBitbucketFile adds the contents of a file in a specific
+// Bitbucket commit to the fake's state (for [bitbucket.SourceGetFile]).
+func (w *Worker) AddBitbucketFile(workspace, repo, commit, path, contents string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.bitbucketFiles[workspace+"/"+repo+"@"+commit+":"+path] = contents
+}
+
+// AddBitbucketDiff adds the diff and diffstat of a Bitbucket commit spec to the
+// fake's state (for [bitbucket.CommitsDiff] and [bitbucket.CommitsDiffstat]).
+func (w *Worker) AddBitbucketDiff(workspace, repo, spec, diff string, ds []bitbucket.Diffstat) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.bitbucketDiffs[workspace+"/"+repo+"@"+spec] = &bitbucketDiff{diff: diff, diffstat: ds}
+}
+
+// AddJiraUser adds a Jira user to the fake's state.
+func (w *Worker) AddJiraUser(u jira.User) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.jiraUsers[u.AccountID] = u
+}
+
+// Messages returns a copy of all the messages in a Slack
+// channel, including thread replies, in chronological order.
+func (w *Worker) Messages(channelID string) []slack.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return as[[]slack.Message](w.state.channel(channelID).messages)
+}
+
+// Views returns a copy of all the Slack views (modals and App Home tabs) in the fake's
+// state, in creation order, e.g. to construct [events.ViewSubmissionPayload] signals.
+func (w *Worker) Views() []events.View {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	vs := make([]events.View, 0, len(w.state.views))
+	for _, v := range w.state.views {
+		vs = append(vs, *v)
+	}
+	return vs
+}
+
+// GitHubPullRequest returns a copy of a GitHub PR in the fake's state.
+func (w *Worker) GitHubPullRequest(owner, repo string, number int) (github.PullRequest, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pr, ok := w.state.githubPRs[githubKey(owner, repo, number)]
+	if !ok {
+		return github.PullRequest{}, false
+	}
+	return pr.pr, true
+}
+
+// BitbucketPullRequest returns a copy of a Bitbucket PR in the fake's state.
+func (w *Worker) BitbucketPullRequest(workspace, repo, id string) (map[string]any, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pr, ok := w.state.bitbucketPRs[bitbucketKey(workspace, repo, id)]
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(pr.pr), true
+}
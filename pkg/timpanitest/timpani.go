@@ -0,0 +1,32 @@
+package timpanitest
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpani"
+)
+
+// WorkerVersion is the version that the fake Timpani worker reports in [timpani.Capabilities].
+const WorkerVersion = "timpanitest"
+
+func timpaniHandlers() map[string]Handler {
+	return map[string]Handler{
+		timpani.CapabilitiesActivityName: timpaniCapabilities,
+	}
+}
+
+// timpaniCapabilities reports all the activities that the fake supports, including
+// those that were added or replaced with [Worker.Handle], unless it was used to
+// remove one by setting its handler to nil (to simulate an older Timpani worker).
+func timpaniCapabilities(w *Worker, _ json.RawMessage) (any, error) {
+	resp := timpani.CapabilitiesResponse{Version: WorkerVersion, Workflows: []string{slack.TimpaniPostApprovalWorkflowName}}
+	for _, name := range slices.Sorted(maps.Keys(w.handlers)) {
+		if w.handlers[name] != nil {
+			resp.Activities = append(resp.Activities, name)
+		}
+	}
+	return resp, nil
+}
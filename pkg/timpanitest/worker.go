@@ -0,0 +1,228 @@
+// Package timpanitest provides an in-memory fake of the Timpani worker, for unit
+// tests of Temporal workflows that use Timpani activities and child workflows.
+//
+// The fake registers all the activity names that are defined in this module in a
+// [testsuite.TestWorkflowEnvironment], and backs them with a stateful in-memory model
+// of Slack channels, messages, reactions and users, GitHub and Bitbucket PRs and comments,
+// and Jira users. For example, a message which is posted with [slack.ChatPostMessage]
+// is later returned by "slack.conversations.history", and a reaction which is added
+// with [slack.ReactionsAdd] is later returned by [slack.ReactionsGet].
+//
+// Usage:
+//
+//	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+//	w := timpanitest.New()
+//	w.AddUser(slack.User{ID: "U123", Profile: slack.Profile{Email: "me@example.com"}})
+//	w.Register(env)
+//
+//	env.ExecuteWorkflow(MyWorkflow, input)
+//
+//	w.AssertCalled(t, slack.ChatPostMessageActivityName, 1)
+//	msgs := w.Messages("C456")
+package timpanitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/registry"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+)
+
+// Handler is a fake implementation of a single Timpani activity.
+// It receives the raw JSON request, and returns a response that
+// will be encoded as JSON, or an error. Handlers are called while
+// the [Worker] is locked, so they must not call its exported methods.
+type Handler func(w *Worker, req json.RawMessage) (any, error)
+
+// Call is a record of a single Timpani activity execution.
+type Call struct {
+	Name     string
+	Request  json.RawMessage
+	Response json.RawMessage
+	Err      error
+}
+
+// Decode unmarshals the call's request into v.
+func (c Call) Decode(v any) error {
+	if err := json.Unmarshal(c.Request, v); err != nil {
+		return fmt.Errorf("failed to decode %q request: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Worker is an in-memory fake of the Timpani worker. Its zero value is not usable, use [New].
+type Worker struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []Call
+	state    *state
+
+	// ApprovalEvent is returned by the fake "slack.timpani.postApproval" child workflow.
+	ApprovalEvent map[string]any
+}
+
+// New returns a fake Timpani worker with an empty state.
+func New() *Worker {
+	w := &Worker{handlers: map[string]Handler{}, state: newState()}
+	maps.Copy(w.handlers, slackHandlers())
+	maps.Copy(w.handlers, githubHandlers())
+	maps.Copy(w.handlers, bitbucketHandlers())
+	maps.Copy(w.handlers, jiraHandlers())
+	maps.Copy(w.handlers, timpaniHandlers())
+	return w
+}
+
+// Handle replaces the fake implementation of a single Timpani activity,
+// or adds one for an activity name which is not defined in this module.
+// A nil handler removes the activity, to simulate an older Timpani worker.
+// It must be called before [Worker.Register].
+func (w *Worker) Handle(name string, h Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[name] = h
+}
+
+// Register registers all the fake Timpani activities and child workflows in the
+// given Temporal test workflow environment. Activities in the [registry] which
+// don't have a stateful fake implementation return a zero-value response.
+func (w *Worker) Register(env *testsuite.TestWorkflowEnvironment) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, e := range registry.All() {
+		if _, ok := w.handlers[e.Name]; !ok && e.Kind == registry.Activity {
+			w.handlers[e.Name] = zero(e)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(w.handlers)) {
+		if w.handlers[name] == nil {
+			continue
+		}
+		env.RegisterActivityWithOptions(w.activity(name), activity.RegisterOptions{Name: name})
+	}
+	env.RegisterWorkflowWithOptions(w.postApproval, workflow.RegisterOptions{Name: slack.TimpaniPostApprovalWorkflowName})
+}
+
+func (w *Worker) activity(name string) func(context.Context, json.RawMessage) (json.RawMessage, error) {
+	return func(_ context.Context, req json.RawMessage) (json.RawMessage, error) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		call := Call{Name: name, Request: req}
+		resp, err := w.handlers[name](w, req)
+		if err == nil {
+			call.Response, err = json.Marshal(resp)
+		}
+		call.Err = err
+		w.calls = append(w.calls, call)
+
+		if err != nil {
+			return nil, err
+		}
+		return call.Response, nil
+	}
+}
+
+func (w *Worker) postApproval(_ workflow.Context, _ slack.TimpaniPostApprovalRequest) (*slack.TimpaniPostApprovalResponse, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.calls = append(w.calls, Call{Name: slack.TimpaniPostApprovalWorkflowName})
+	return &slack.TimpaniPostApprovalResponse{Response: slack.Response{OK: true}, InteractionEvent: w.ApprovalEvent}, nil
+}
+
+// Calls returns all the recorded executions of a specific Timpani
+// activity, in chronological order. An empty name returns all calls.
+func (w *Worker) Calls(name string) []Call {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var calls []Call
+	for _, c := range w.calls {
+		if name == "" || c.Name == name {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Requests returns the decoded requests of all the recorded
+// executions of a specific Timpani activity, in chronological order.
+func Requests[T any](w *Worker, name string) ([]T, error) {
+	calls := w.Calls(name)
+	reqs := make([]T, len(calls))
+	for i, c := range calls {
+		if err := c.Decode(&reqs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return reqs, nil
+}
+
+// AssertCalled fails the test if a specific Timpani activity was never executed.
+// If times is specified, it fails if the number of executions is different.
+func (w *Worker) AssertCalled(t testing.TB, name string, times ...int) {
+	t.Helper()
+
+	n := len(w.Calls(name))
+	switch {
+	case len(times) == 0 && n == 0:
+		t.Errorf("expected %q to be called, but it was not", name)
+	case len(times) > 0 && n != times[0]:
+		t.Errorf("expected %q to be called %d time(s), but it was called %d time(s)", name, times[0], n)
+	}
+}
+
+// AssertNotCalled fails the test if a specific Timpani activity was executed.
+func (w *Worker) AssertNotCalled(t testing.TB, name string) {
+	t.Helper()
+
+	if n := len(w.Calls(name)); n > 0 {
+		t.Errorf("expected %q not to be called, but it was called %d time(s)", name, n)
+	}
+}
+
+// typed adapts a handler function with a typed request into a [Handler].
+func typed[Req any](fn func(w *Worker, req Req) (any, error)) Handler {
+	return func(w *Worker, raw json.RawMessage) (any, error) {
+		var req Req
+		if len(raw) > 0 && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &req); err != nil {
+				return nil, temporal.NewNonRetryableApplicationError(err.Error(), errors.TypeValidationFailed, err)
+			}
+		}
+		return fn(w, req)
+	}
+}
+
+// zero returns a [Handler] which always returns the zero
+// value of a registry entry's response type (or nothing).
+func zero(e registry.Entry) Handler {
+	return func(_ *Worker, _ json.RawMessage) (any, error) {
+		return e.NewResponse(), nil
+	}
+}
+
+// fail returns an application error that resembles the ones which are returned by
+// the real Timpani worker, so that wrapper functions convert them into typed errors.
+func fail(errType string, d errors.Details) error {
+	msg := d.Code
+	if msg == "" {
+		msg = fmt.Sprintf("HTTP %d", d.StatusCode)
+	}
+	opts := temporal.ApplicationErrorOptions{NonRetryable: errType != errors.TypeRateLimited, Details: []any{d}}
+	return temporal.NewApplicationErrorWithOptions(msg, errType, opts)
+}
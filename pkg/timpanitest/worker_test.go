@@ -0,0 +1,240 @@
+package timpanitest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/errors"
+	"github.com/tzrikka/timpani-api/pkg/slack"
+	"github.com/tzrikka/timpani-api/pkg/timpanitest"
+)
+
+const channelID = "C123"
+
+// postAndReact is a workflow which posts a Slack message, reacts to
+// it, and returns the channel's history and the message's reactions.
+func postAndReact(ctx workflow.Context, text string) (map[string]any, error) {
+	resp, err := slack.ChatPostMessage(ctx, slack.ChatPostMessageRequest{Channel: channelID, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	if err := slack.ReactionsAdd(ctx, channelID, resp.TS, "eyes"); err != nil {
+		return nil, err
+	}
+
+	history, err := slack.ConversationsHistory(ctx, channelID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	msg, err := slack.ReactionsGet(ctx, channelID, resp.TS)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"ts": resp.TS, "history": history, "message": msg}, nil
+}
+
+// classify is a workflow which reacts to a Slack message, and returns the name of its
+// error type, because typed errors don't cross the workflow's boundary as-is.
+func classify(ctx workflow.Context, timestamp string) (string, error) {
+	err := slack.ReactionsAdd(ctx, channelID, timestamp, "eyes")
+	switch {
+	case err == nil:
+		return "", nil
+	case errors.IsNotFound(err):
+		return errors.TypeNotFound, nil
+	case !errors.IsRetryable(err):
+		return fmt.Sprintf("%T", err), nil
+	default:
+		return "", err
+	}
+}
+
+func newEnv(t *testing.T, w *timpanitest.Worker) *testsuite.TestWorkflowEnvironment {
+	t.Helper()
+
+	env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+	w.Register(env)
+	return env
+}
+
+func TestRoundTrip(t *testing.T) {
+	w := timpanitest.New()
+	env := newEnv(t, w)
+
+	env.ExecuteWorkflow(postAndReact, "hello")
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+
+	var got struct {
+		TS      string          `json:"ts"`
+		History []slack.Message `json:"history"`
+		Message map[string]any  `json:"message"`
+	}
+	if err := env.GetWorkflowResult(&got); err != nil {
+		t.Fatalf("failed to get workflow result: %v", err)
+	}
+
+	if len(got.History) != 1 || got.History[0].TS != got.TS || got.History[0].Text != "hello" {
+		t.Errorf("conversations.history = %+v, want a single message %q with text %q", got.History, got.TS, "hello")
+	}
+	if got.History[0].User != timpanitest.BotUserID {
+		t.Errorf("message user = %q, want %q", got.History[0].User, timpanitest.BotUserID)
+	}
+
+	want := `[{"count":1,"name":"eyes","users":["U0TIMPANI"]}]`
+	if reactions, _ := json.Marshal(got.Message["reactions"]); string(reactions) != want {
+		t.Errorf("reactions.get = %s, want %s", reactions, want)
+	}
+
+	if msgs := w.Messages(channelID); len(msgs) != 1 || msgs[0].TS != got.TS {
+		t.Errorf("Messages() = %+v, want a single message %q", msgs, got.TS)
+	}
+
+	reqs, err := timpanitest.Requests[slack.ChatPostMessageRequest](w, slack.ChatPostMessageActivityName)
+	if err != nil {
+		t.Fatalf("Requests() error: %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].Channel != channelID || reqs[0].Text != "hello" {
+		t.Errorf("Requests() = %+v, want a single request to %q with text %q", reqs, channelID, "hello")
+	}
+}
+
+func TestErrorInjection(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler timpanitest.Handler
+		want    string
+	}{
+		{
+			name: "stateful_fake",
+			want: errors.TypeNotFound,
+		},
+		{
+			name: "slack_error_code",
+			handler: func(_ *timpanitest.Worker, _ json.RawMessage) (any, error) {
+				return nil, temporal.NewNonRetryableApplicationError("Slack API error", "not_in_channel", nil)
+			},
+			want: "*errors.PermissionDeniedError",
+		},
+		{
+			name: "http_status_code",
+			handler: func(_ *timpanitest.Worker, _ json.RawMessage) (any, error) {
+				return nil, temporal.NewNonRetryableApplicationError("HTTP 409", "", nil, errors.Details{StatusCode: 409})
+			},
+			want: "*errors.ConflictError",
+		},
+		{
+			name: "success",
+			handler: func(_ *timpanitest.Worker, _ json.RawMessage) (any, error) {
+				return slack.Response{OK: true}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := timpanitest.New()
+			if tt.handler != nil {
+				w.Handle(slack.ReactionsAddActivityName, tt.handler)
+			}
+			env := newEnv(t, w)
+
+			env.ExecuteWorkflow(classify, "1234567890.000001")
+			if err := env.GetWorkflowError(); err != nil {
+				t.Fatalf("workflow error: %v", err)
+			}
+
+			var got string
+			if err := env.GetWorkflowResult(&got); err != nil {
+				t.Fatalf("failed to get workflow result: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("error type = %q, want %q", got, tt.want)
+			}
+
+			calls := w.Calls(slack.ReactionsAddActivityName)
+			if len(calls) != 1 {
+				t.Fatalf("Calls() = %d calls, want 1", len(calls))
+			}
+			if (calls[0].Err != nil) != (tt.want != "") {
+				t.Errorf("Calls()[0].Err = %v, want error: %v", calls[0].Err, tt.want != "")
+			}
+		})
+	}
+}
+
+// recorder is a [testing.TB] which records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	w := timpanitest.New()
+	env := newEnv(t, w)
+
+	env.ExecuteWorkflow(postAndReact, "hello")
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		assert func(t testing.TB)
+		fail   bool
+	}{
+		{
+			name:   "called",
+			assert: func(t testing.TB) { w.AssertCalled(t, slack.ChatPostMessageActivityName) },
+		},
+		{
+			name:   "called_times",
+			assert: func(t testing.TB) { w.AssertCalled(t, slack.ReactionsAddActivityName, 1) },
+		},
+		{
+			name:   "called_wrong_times",
+			assert: func(t testing.TB) { w.AssertCalled(t, slack.ReactionsAddActivityName, 2) },
+			fail:   true,
+		},
+		{
+			name:   "not_called_but_expected",
+			assert: func(t testing.TB) { w.AssertCalled(t, slack.ChatDeleteActivityName) },
+			fail:   true,
+		},
+		{
+			name:   "not_called",
+			assert: func(t testing.TB) { w.AssertNotCalled(t, slack.ChatDeleteActivityName) },
+		},
+		{
+			name:   "called_but_not_expected",
+			assert: func(t testing.TB) { w.AssertNotCalled(t, slack.ReactionsGetActivityName) },
+			fail:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			tt.assert(r)
+			if failed := len(r.failures) > 0; failed != tt.fail {
+				t.Errorf("assertion failed = %v, want %v: %q", failed, tt.fail, r.failures)
+			}
+		})
+	}
+
+	if n := len(w.Calls("")); n != 4 {
+		t.Errorf("Calls(\"\") = %d calls, want 4", n)
+	}
+}
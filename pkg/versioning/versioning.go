@@ -0,0 +1,152 @@
+// Package versioning keeps long-running workflows safe when Timpani activities
+// are renamed, or their request shapes change, between versions of this module.
+//
+// Each [Migration] lists all the versions of a single activity, and is registered
+// under the activity's current name, i.e. the one that the module's wrapper
+// functions use. When a workflow executes the activity, [workflow.GetVersion]
+// selects the version according to the workflow's history: workflows which started
+// before the migration keep using the old name and request shape, and all the
+// others use the latest version.
+package versioning
+
+import (
+	"fmt"
+	"sync"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// Version is a single version of a Timpani activity.
+type Version struct {
+	// Name is the name of the activity in the Timpani worker, e.g. "github.pulls.reviews.deletePending".
+	Name string
+	// Request converts a request of the activity's current type into this version's
+	// request shape. If it's nil, requests are sent as-is (e.g. after a simple rename).
+	// Responses of all the versions must be compatible with the current response type.
+	Request func(req any) (any, error)
+}
+
+// Migration lists all the versions of a single Timpani activity, oldest first.
+// Workflows which didn't record a version for this migration in their history
+// (i.e. they started before it existed) use the first version, and all the others
+// use the latest one. Versions may be appended, but never removed or reordered.
+type Migration struct {
+	// ChangeID is the change ID in [workflow.GetVersion] calls. The default is
+	// "timpani-api/" followed by the activity's current name.
+	ChangeID string
+	Versions []Version
+}
+
+var (
+	mu         sync.RWMutex
+	migrations = map[string]Migration{}
+	aliases    = map[string]string{}
+)
+
+// Register adds a migration for a Timpani activity, by its current name (e.g.
+// [github.PullRequestsReviewsDeleteActivityName]). Temporal workers that
+// use Timpani should call this function only when they start, before running any
+// workflows. Calling it again for the same activity replaces its migration.
+//
+// [github.PullRequestsReviewsDeleteActivityName]: https://pkg.go.dev/github.com/tzrikka/timpani-api/pkg/github#PullRequestsReviewsDeleteActivityName
+func Register(name string, m Migration) {
+	if m.ChangeID == "" {
+		m.ChangeID = "timpani-api/" + name
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	migrations[name] = m
+	for _, v := range m.Versions {
+		if v.Name != name {
+			aliases[v.Name] = name
+		}
+	}
+}
+
+// Unregister removes the migration of a Timpani activity, by its current name,
+// including all its old names. This is mostly useful in tests, which must not
+// affect other tests that run in the same process.
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(migrations, name)
+	for alias, current := range aliases {
+		if current == name {
+			delete(aliases, alias)
+		}
+	}
+}
+
+// Rename returns a migration for a Timpani activity which was renamed
+// without any change to its request shape. The old name is the first version.
+func Rename(oldName, newName string) Migration {
+	return Migration{Versions: []Version{{Name: oldName}, {Name: newName}}}
+}
+
+// Convert adapts a typed request conversion function to the [Version.Request] field.
+// It accepts requests of the current type either as values or as pointers.
+func Convert[From, To any](fn func(From) To) func(any) (any, error) {
+	return func(req any) (any, error) {
+		switch r := req.(type) {
+		case From:
+			return fn(r), nil
+		case *From:
+			if r != nil {
+				return fn(*r), nil
+			}
+		}
+		var want From
+		return nil, fmt.Errorf("unexpected request type %T, expected %T", req, want)
+	}
+}
+
+// Canonical returns the current name of a Timpani activity, if
+// the given name is an old version of it in a registered migration.
+// Otherwise, it returns the given name as-is.
+func Canonical(name string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current, ok := aliases[name]; ok {
+		return current
+	}
+	return name
+}
+
+// Resolve returns the activity name and request shape which the calling workflow should use
+// for a Timpani activity, by its current name. If the activity has a registered migration,
+// this is based on [workflow.GetVersion]. Otherwise, the name and request are returned as-is.
+func Resolve(ctx workflow.Context, name string, req any) (string, any, error) {
+	mu.RLock()
+	m, ok := migrations[name]
+	mu.RUnlock()
+
+	if !ok || len(m.Versions) == 0 {
+		return name, req, nil
+	}
+
+	v := m.Versions[Select(ctx, m)]
+	if v.Request == nil {
+		return v.Name, req, nil
+	}
+
+	converted, err := v.Request(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to convert request for %q: %w", v.Name, err)
+	}
+	return v.Name, converted, nil
+}
+
+// Select returns the index of the migration's version which the calling workflow should use.
+// This is based on [workflow.GetVersion], so it's deterministic during workflow replays.
+func Select(ctx workflow.Context, m Migration) int {
+	if len(m.Versions) < 2 {
+		return 0
+	}
+
+	v := workflow.GetVersion(ctx, m.ChangeID, workflow.DefaultVersion, workflow.Version(len(m.Versions)-1))
+	return max(int(v), 0)
+}
@@ -0,0 +1,206 @@
+package versioning_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/tzrikka/timpani-api/pkg/versioning"
+)
+
+type oldRequest struct {
+	Channel string
+}
+
+type newRequest struct {
+	ChannelID string
+}
+
+func toOld(r newRequest) oldRequest {
+	return oldRequest{Channel: r.ChannelID}
+}
+
+func init() {
+	versioning.Register("test.renamed", versioning.Rename("test.renamed.v0", "test.renamed"))
+	versioning.Register("test.converted", versioning.Migration{
+		ChangeID: "custom-change-id",
+		Versions: []versioning.Version{
+			{Name: "test.converted.v0", Request: versioning.Convert(toOld)},
+			{Name: "test.converted.v1"},
+			{Name: "test.converted"},
+		},
+	})
+	versioning.Register("test.single", versioning.Migration{Versions: []versioning.Version{{Name: "test.single.v0"}}})
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"test.renamed.v0", "test.renamed"},
+		{"test.renamed", "test.renamed"},
+		{"test.converted.v0", "test.converted"},
+		{"test.converted.v1", "test.converted"},
+		{"test.single.v0", "test.single"},
+		{"test.unknown", "test.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versioning.Canonical(tt.name); got != tt.want {
+				t.Errorf("Canonical(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	versioning.Register("test.unregistered", versioning.Rename("test.unregistered.v0", "test.unregistered"))
+	if got := versioning.Canonical("test.unregistered.v0"); got != "test.unregistered" {
+		t.Fatalf("Canonical() = %q, want %q", got, "test.unregistered")
+	}
+
+	versioning.Unregister("test.unregistered")
+	if got := versioning.Canonical("test.unregistered.v0"); got != "test.unregistered.v0" {
+		t.Errorf("Canonical() after Unregister() = %q, want %q", got, "test.unregistered.v0")
+	}
+	if got := versioning.Canonical("test.renamed.v0"); got != "test.renamed" {
+		t.Errorf("Canonical() of another migration = %q, want %q", got, "test.renamed")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	req := newRequest{ChannelID: "C123"}
+
+	tests := []struct {
+		name     string
+		activity string
+		changeID string // Of a mocked workflow version.
+		version  workflow.Version
+		req      any
+		wantName string
+		wantReq  any
+		wantErr  string
+	}{
+		{
+			name:     "no_migration",
+			activity: "test.unknown",
+			req:      req,
+			wantName: "test.unknown",
+			wantReq:  req,
+		},
+		{
+			name:     "single_version",
+			activity: "test.single",
+			req:      req,
+			wantName: "test.single.v0",
+			wantReq:  req,
+		},
+		{
+			name:     "rename_new_workflow",
+			activity: "test.renamed",
+			req:      req,
+			wantName: "test.renamed",
+			wantReq:  req,
+		},
+		{
+			name:     "rename_old_workflow",
+			activity: "test.renamed",
+			changeID: "timpani-api/test.renamed",
+			version:  workflow.DefaultVersion,
+			req:      req,
+			wantName: "test.renamed.v0",
+			wantReq:  req,
+		},
+		{
+			name:     "convert_new_workflow",
+			activity: "test.converted",
+			req:      req,
+			wantName: "test.converted",
+			wantReq:  req,
+		},
+		{
+			name:     "convert_intermediate_workflow",
+			activity: "test.converted",
+			changeID: "custom-change-id",
+			version:  1,
+			req:      req,
+			wantName: "test.converted.v1",
+			wantReq:  req,
+		},
+		{
+			name:     "convert_old_workflow",
+			activity: "test.converted",
+			changeID: "custom-change-id",
+			version:  workflow.DefaultVersion,
+			req:      req,
+			wantName: "test.converted.v0",
+			wantReq:  oldRequest{Channel: "C123"},
+		},
+		{
+			name:     "convert_old_workflow_pointer",
+			activity: "test.converted",
+			changeID: "custom-change-id",
+			version:  workflow.DefaultVersion,
+			req:      &req,
+			wantName: "test.converted.v0",
+			wantReq:  oldRequest{Channel: "C123"},
+		},
+		{
+			name:     "convert_unexpected_type",
+			activity: "test.converted",
+			changeID: "custom-change-id",
+			version:  workflow.DefaultVersion,
+			req:      oldRequest{},
+			wantErr:  "unexpected request type",
+		},
+		{
+			name:     "convert_nil_pointer",
+			activity: "test.converted",
+			changeID: "custom-change-id",
+			version:  workflow.DefaultVersion,
+			req:      (*newRequest)(nil),
+			wantErr:  "unexpected request type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := new(testsuite.WorkflowTestSuite).NewTestWorkflowEnvironment()
+			if tt.changeID != "" {
+				env.OnGetVersion(tt.changeID, workflow.DefaultVersion, workflow.Version(2)).Return(tt.version)
+				env.OnGetVersion(tt.changeID, workflow.DefaultVersion, workflow.Version(1)).Return(tt.version)
+			}
+
+			var (
+				gotName string
+				gotReq  any
+				err     error
+			)
+			env.ExecuteWorkflow(func(ctx workflow.Context) error {
+				gotName, gotReq, err = versioning.Resolve(ctx, tt.activity, tt.req)
+				return nil
+			})
+			if wfErr := env.GetWorkflowError(); wfErr != nil {
+				t.Fatalf("workflow error: %v", wfErr)
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if gotName != tt.wantName {
+				t.Errorf("Resolve() name = %q, want %q", gotName, tt.wantName)
+			}
+			if gotReq != tt.wantReq {
+				t.Errorf("Resolve() request = %#v, want %#v", gotReq, tt.wantReq)
+			}
+		})
+	}
+}